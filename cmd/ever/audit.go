@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// gatewayMethods - ClientGateway methods whose first string argument is the SDK function name.
+var gatewayMethods = map[string]bool{
+	"GetResult":          true,
+	"GetResultContext":   true,
+	"GetResponse":        true,
+	"GetResponseContext": true,
+	"Request":            true,
+	"RequestContext":     true,
+	"Call":               true,
+	"CallContext":        true,
+	"CallStream":         true,
+}
+
+var sdkFunction = regexp.MustCompile(`^[a-z]+\.[a-z0-9_]+$`)
+
+type (
+	// implementation - SDK function called by the binding.
+	implementation struct {
+		method string
+		params string
+		pos    string
+	}
+
+	mismatch struct {
+		method   string
+		params   string
+		expected string
+		missing  []string
+		unknown  []string
+	}
+
+	report struct {
+		version     string
+		functions   int
+		implemented int
+		missing     []string
+		mismatches  []mismatch
+		unknown     []*implementation
+	}
+)
+
+// scanSources - SDK functions called from the Go sources under dirs, with the domain types of their params.
+func scanSources(dirs ...string) (map[string]*implementation, map[string]map[string]bool, error) {
+	impls := make(map[string]*implementation)
+	types := make(map[string]map[string]bool)
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			scanFile(fset, file, impls, types)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return impls, types, nil
+}
+
+func scanFile(fset *token.FileSet, file *ast.File, impls map[string]*implementation, types map[string]map[string]bool) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						types[spec.Name.Name] = jsonFields(st)
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Body == nil {
+				continue
+			}
+			params := make(map[string]string)
+			for _, field := range decl.Type.Params.List {
+				for _, name := range field.Names {
+					params[name.Name] = typeName(field.Type)
+				}
+			}
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || !gatewayMethods[sel.Sel.Name] {
+					return true
+				}
+				for i, arg := range call.Args {
+					lit, ok := arg.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					method, err := strconv.Unquote(lit.Value)
+					if err != nil || !sdkFunction.MatchString(method) {
+						break
+					}
+					impl := &implementation{method: method, pos: fset.Position(lit.Pos()).String()}
+					if i+1 < len(call.Args) {
+						if ident, ok := call.Args[i+1].(*ast.Ident); ok {
+							impl.params = params[ident.Name]
+						}
+					}
+					if existing, ok := impls[method]; !ok || (existing.params == "" && impl.params != "") {
+						impls[method] = impl
+					}
+					break
+				}
+				return true
+			})
+		}
+	}
+}
+
+func jsonFields(st *ast.StructType) map[string]bool {
+	fields := make(map[string]bool)
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+
+	return fields
+}
+
+// typeName - name of the named type behind expr: *domain.ParamsOfX -> ParamsOfX.
+func typeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return typeName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	default:
+		return ""
+	}
+}
+
+// audit compares the API reference with the SDK functions the binding calls.
+func audit(api *domain.API, impls map[string]*implementation, types map[string]map[string]bool) *report {
+	apiTypes := make(map[string]*domain.APIType)
+	for _, module := range api.Modules {
+		for _, t := range module.Types {
+			apiTypes[t.Name] = t
+		}
+	}
+
+	r := &report{version: api.Version}
+	known := make(map[string]bool)
+	for _, module := range api.Modules {
+		for _, f := range module.Functions {
+			method := module.Name + "." + f.Name
+			known[method] = true
+			r.functions++
+			impl, ok := impls[method]
+			if !ok {
+				r.missing = append(r.missing, method)
+				continue
+			}
+			r.implemented++
+			if m, ok := compareParams(method, f, impl, apiTypes, types); ok {
+				r.mismatches = append(r.mismatches, m)
+			}
+		}
+	}
+	for method, impl := range impls {
+		if !known[method] {
+			r.unknown = append(r.unknown, impl)
+		}
+	}
+	sort.Slice(r.unknown, func(i, j int) bool { return r.unknown[i].method < r.unknown[j].method })
+
+	return r
+}
+
+func compareParams(method string, f *domain.APIFunction, impl *implementation, apiTypes map[string]*domain.APIType, types map[string]map[string]bool) (mismatch, bool) {
+	m := mismatch{method: method, params: impl.params}
+	for _, param := range f.Params {
+		if param.Name == "params" && param.Type == "Ref" {
+			m.expected = param.RefName[strings.LastIndex(param.RefName, ".")+1:]
+		}
+	}
+	expected, ok := apiTypes[m.expected]
+	if m.expected == "" || !ok || expected.Type != "Struct" {
+		return m, false
+	}
+	fields, ok := types[impl.params]
+	if !ok {
+		return m, true
+	}
+	apiFields := make(map[string]bool)
+	for _, field := range expected.Struct {
+		apiFields[field.Name] = true
+		if !fields[field.Name] {
+			m.missing = append(m.missing, field.Name)
+		}
+	}
+	for field := range fields {
+		if !apiFields[field] {
+			m.unknown = append(m.unknown, field)
+		}
+	}
+	sort.Strings(m.unknown)
+
+	return m, len(m.missing) != 0 || len(m.unknown) != 0 || m.params != m.expected
+}
+
+func (r *report) clean() bool {
+	return len(r.missing) == 0 && len(r.mismatches) == 0 && len(r.unknown) == 0
+}
+
+func (r *report) write(w io.Writer) {
+	fmt.Fprintf(w, "API %s: %d functions, %d implemented\n", r.version, r.functions, r.implemented)
+	if len(r.missing) != 0 {
+		fmt.Fprintln(w, "\nMissing functions:")
+		for _, method := range r.missing {
+			fmt.Fprintf(w, "  %s\n", method)
+		}
+	}
+	if len(r.mismatches) != 0 {
+		fmt.Fprintln(w, "\nParam mismatches:")
+		for _, m := range r.mismatches {
+			var details []string
+			if m.params != m.expected {
+				params := m.params
+				if params == "" {
+					params = "none"
+				}
+				details = append(details, fmt.Sprintf("params %s, expected %s", params, m.expected))
+			}
+			if len(m.missing) != 0 {
+				details = append(details, "missing fields: "+strings.Join(m.missing, ", "))
+			}
+			if len(m.unknown) != 0 {
+				details = append(details, "unknown fields: "+strings.Join(m.unknown, ", "))
+			}
+			fmt.Fprintf(w, "  %s: %s\n", m.method, strings.Join(details, "; "))
+		}
+	}
+	if len(r.unknown) != 0 {
+		fmt.Fprintln(w, "\nNot in the API reference:")
+		for _, impl := range r.unknown {
+			fmt.Fprintf(w, "  %s (%s)\n", impl.method, impl.pos)
+		}
+	}
+}
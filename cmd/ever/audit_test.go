@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/internal/apiref"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	api, err := apiref.Load(filepath.Join("testdata", "api.json"), "")
+	assert.Equal(t, nil, err)
+	impls, types, err := scanSources(filepath.Join("..", "..", "usecase"), filepath.Join("..", "..", "domain"))
+	assert.Equal(t, nil, err)
+
+	t.Run("TestScan", func(t *testing.T) {
+		encrypt := impls["crypto.encryption_box_encrypt"]
+		assert.NotNil(t, encrypt)
+		assert.Equal(t, "ParamsOfEncryptionBoxEncrypt", encrypt.params)
+		assert.Contains(t, encrypt.pos, filepath.Join("usecase", "crypto", "crypto.go"))
+		assert.NotNil(t, impls["client.version"])
+		assert.NotNil(t, impls["net.query_collection"])
+		assert.True(t, types["KeyPair"]["secret"])
+	})
+
+	t.Run("TestReport", func(t *testing.T) {
+		r := audit(api, impls, types)
+		assert.False(t, r.clean())
+		assert.Equal(t, 4, r.functions)
+		assert.Equal(t, 3, r.implemented)
+		assert.Equal(t, []string{"crypto.encryption_box_rotate"}, r.missing)
+		assert.Equal(t, 1, len(r.mismatches))
+		assert.Equal(t, "crypto.encryption_box_encrypt", r.mismatches[0].method)
+		assert.Equal(t, []string{"padding"}, r.mismatches[0].missing)
+
+		var out bytes.Buffer
+		r.write(&out)
+		assert.Contains(t, out.String(), "API 1.40.0: 4 functions, 3 implemented")
+		assert.Contains(t, out.String(), "  crypto.encryption_box_encrypt: missing fields: padding\n")
+		assert.Contains(t, out.String(), "Not in the API reference:\n")
+		assert.Contains(t, out.String(), "  net.query_collection (")
+	})
+}
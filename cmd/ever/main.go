@@ -0,0 +1,78 @@
+// Command ever contains maintenance tools for ever-client-go.
+//
+//	ever audit [-api api.json | -remote URL] [-src DIR]
+//
+// audit compares the modules and functions of the Core Library API reference with the SDK functions
+// called from usecase/* and domain, and lists missing functions, param field mismatches and calls of
+// functions the reference does not know. Without -api and -remote the reference of the linked library is used.
+// It exits with status 1 if anything was found.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	goever "github.com/move-ton/ever-client-go"
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/internal/apiref"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "audit" {
+		fmt.Fprintln(os.Stderr, "usage: ever audit [-api api.json | -remote URL] [-src DIR]")
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	var (
+		apiPath   = flags.String("api", "", "path to api.json, - for stdin")
+		remoteURL = flags.String("remote", "", "URL of a tonclient server to fetch the API reference from")
+		src       = flags.String("src", ".", "root of the ever-client-go sources")
+	)
+	_ = flags.Parse(os.Args[2:])
+
+	clean, err := runAudit(*apiPath, *remoteURL, *src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ever audit:", err)
+		os.Exit(2)
+	}
+	if !clean {
+		os.Exit(1)
+	}
+}
+
+func runAudit(apiPath, remoteURL, src string) (bool, error) {
+	var (
+		api *domain.API
+		err error
+	)
+	if apiPath == "" && remoteURL == "" {
+		api, err = libraryAPI()
+	} else {
+		api, err = apiref.Load(apiPath, remoteURL)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	impls, types, err := scanSources(filepath.Join(src, "usecase"), filepath.Join(src, "domain"))
+	if err != nil {
+		return false, err
+	}
+	r := audit(api, impls, types)
+	r.write(os.Stdout)
+
+	return r.clean(), nil
+}
+
+func libraryAPI() (*domain.API, error) {
+	ever, err := goever.NewEverWithConfig(domain.ClientConfig{})
+	if err != nil {
+		return nil, err
+	}
+	defer ever.Client.Destroy()
+
+	return apiref.Fetch(ever.Client)
+}
@@ -0,0 +1,500 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+const header = "// Code generated by evergen from the %s API reference. DO NOT EDIT.\n\n"
+
+type (
+	generator struct {
+		api   *domain.API
+		types map[string]*domain.APIType
+	}
+
+	// function - API function reduced to what a use case wrapper needs.
+	// Manual is set for functions which need hand-written wrappers (callbacks, app objects).
+	function struct {
+		api    *domain.APIFunction
+		params string
+		result string
+		manual string
+	}
+
+	printer struct {
+		bytes.Buffer
+	}
+)
+
+func (p *printer) line(format string, args ...interface{}) {
+	fmt.Fprintf(p, format, args...)
+	p.WriteByte('\n')
+}
+
+func newGenerator(api *domain.API) *generator {
+	g := &generator{api: api, types: make(map[string]*domain.APIType)}
+	for _, module := range api.Modules {
+		for _, t := range module.Types {
+			g.types[t.Name] = t
+		}
+	}
+
+	return g
+}
+
+// domainFile - Go source of the domain types, error codes and use case interface of module.
+func (g *generator) domainFile(module *domain.APIModule) ([]byte, error) {
+	var body printer
+	g.errorCodes(&body, module)
+	g.enumConsts(&body, module)
+
+	body.line("type (")
+	for _, t := range module.Types {
+		if isErrorCodes(t) {
+			continue
+		}
+		g.typeDecl(&body, t)
+	}
+	if module.Name != "client" {
+		g.useCaseInterface(&body, module)
+	}
+	body.line(")")
+
+	g.errorCodesInit(&body, module)
+	for _, t := range module.Types {
+		if t.Type == "EnumOfTypes" && len(t.EnumTypes) != 0 {
+			g.enumMarshalers(&body, t)
+		}
+	}
+
+	return g.file("domain", nil, body.Bytes())
+}
+
+// useCaseFile - Go source of the use case implementation of module.
+func (g *generator) useCaseFile(module *domain.APIModule) ([]byte, error) {
+	name := goName(module.Name)
+	recv := module.Name[:1]
+	var body printer
+	body.line("type %s struct {", module.Name)
+	body.line("config domain.ClientConfig")
+	body.line("client domain.ClientGateway")
+	body.line("}")
+	body.line("")
+	body.line("// New%s ...", name)
+	body.line("func New%s(", name)
+	body.line("config domain.ClientConfig,")
+	body.line("client domain.ClientGateway,")
+	body.line(") domain.%sUseCase {", name)
+	body.line("return &%s{", module.Name)
+	body.line("config: config,")
+	body.line("client: client,")
+	body.line("}")
+	body.line("}")
+
+	for _, f := range g.functions(module) {
+		if f.manual != "" {
+			continue
+		}
+		method := goName(f.api.Name)
+		arg, argDecl, ctxArgDecl := "nil", "", "ctx context.Context"
+		if f.params != "" {
+			arg = abbrev(f.params)
+			argDecl = fmt.Sprintf("%s *domain.%s", arg, f.params)
+			ctxArgDecl += ", " + argDecl
+		}
+		returns := "error"
+		if f.result != "" {
+			returns = fmt.Sprintf("(*domain.%s, error)", f.result)
+		}
+		callArgs := "context.Background()"
+		if f.params != "" {
+			callArgs += ", " + arg
+		}
+
+		body.line("")
+		g.doc(&body, method, f.api.Summary, f.api.Description)
+		body.line("func (%s *%s) %s(%s) %s {", recv, module.Name, method, argDecl, returns)
+		body.line("return %s.%sCtx(%s)", recv, method, callArgs)
+		body.line("}")
+		body.line("")
+		body.line("// %sCtx - %s bounded by ctx.", method, method)
+		body.line("func (%s *%s) %sCtx(%s) %s {", recv, module.Name, method, ctxArgDecl, returns)
+		if f.result != "" {
+			body.line("result := new(domain.%s)", f.result)
+			body.line("err := %s.client.GetResultContext(ctx, %q, %s, result)", recv, module.Name+"."+f.api.Name, arg)
+			body.line("return result, err")
+		} else {
+			body.line("_, err := %s.client.GetResponseContext(ctx, %q, %s)", recv, module.Name+"."+f.api.Name, arg)
+			body.line("return err")
+		}
+		body.line("}")
+	}
+
+	return g.file(module.Name, []string{"github.com/move-ton/ever-client-go/domain"}, body.Bytes())
+}
+
+func (g *generator) file(pkg string, imports []string, body []byte) ([]byte, error) {
+	used, err := usedPackages(pkg, body)
+	if err != nil {
+		return nil, err
+	}
+	var std []string
+	for _, path := range []string{"context", "encoding/json", "fmt", "math/big"} {
+		if used[path[strings.LastIndex(path, "/")+1:]] {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(imports)
+
+	var src printer
+	fmt.Fprintf(&src, header, g.api.Version)
+	src.line("package %s", pkg)
+	src.line("")
+	if len(std)+len(imports) != 0 {
+		src.line("import (")
+		for _, path := range std {
+			src.line("%q", path)
+		}
+		if len(std) != 0 && len(imports) != 0 {
+			src.line("")
+		}
+		for _, path := range imports {
+			src.line("%q", path)
+		}
+		src.line(")")
+		src.line("")
+	}
+	src.Write(body)
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg, err)
+	}
+
+	return formatted, nil
+}
+
+// usedPackages - names of the packages referred to by body, e.g. json in json.RawMessage.
+func usedPackages(pkg string, body []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), pkg+".go", append([]byte("package "+pkg+"\n"), body...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg, err)
+	}
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	return used, nil
+}
+
+func (g *generator) doc(p *printer, name, summary, description string) {
+	if summary = sentence(summary); summary != "" {
+		p.line("// %s - %s", name, summary)
+	} else {
+		p.line("// %s ...", name)
+	}
+	for _, text := range strings.Split(strings.TrimSpace(description), "\n") {
+		if text = strings.TrimSpace(text); text != "" {
+			p.line("// %s", text)
+		}
+	}
+}
+
+func isErrorCodes(t *domain.APIType) bool {
+	return t.Type == "EnumOfConsts" && strings.HasSuffix(t.Name, "ErrorCode")
+}
+
+func errorPrefix(module string) string {
+	if prefix, ok := errorPrefixes[module]; ok {
+		return prefix + "Error"
+	}
+
+	return goName(module) + "Error"
+}
+
+func (g *generator) errorCodes(p *printer, module *domain.APIModule) {
+	for _, t := range module.Types {
+		if !isErrorCodes(t) {
+			continue
+		}
+		prefix := errorPrefix(module.Name)
+		p.line("// Error codes of the %s module.", module.Name)
+		p.line("const (")
+		for _, c := range t.EnumConsts {
+			p.line("%s%s = %s", prefix, c.Name, c.Value)
+		}
+		p.line(")")
+		p.line("")
+		p.line("// %sCode ...", prefix)
+		p.line("var %sCode map[string]int", prefix)
+		p.line("")
+	}
+}
+
+func (g *generator) errorCodesInit(p *printer, module *domain.APIModule) {
+	for _, t := range module.Types {
+		if !isErrorCodes(t) {
+			continue
+		}
+		prefix := errorPrefix(module.Name)
+		p.line("")
+		p.line("func init() {")
+		p.line("%sCode = map[string]int{", prefix)
+		for _, c := range t.EnumConsts {
+			p.line("%q: %s%s,", c.Name, prefix, c.Name)
+		}
+		p.line("}")
+		p.line("}")
+	}
+}
+
+func (g *generator) enumConsts(p *printer, module *domain.APIModule) {
+	var consts printer
+	for _, t := range module.Types {
+		if t.Type != "EnumOfConsts" || isErrorCodes(t) {
+			continue
+		}
+		for _, c := range t.EnumConsts {
+			if consts.Len() != 0 {
+				consts.line("")
+			}
+			name := t.Name + goName(c.Name)
+			g.doc(&consts, name, c.Summary, "")
+			if c.Type == "Number" {
+				consts.line("%s %s = %s", name, t.Name, c.Value)
+			} else {
+				value := c.Value
+				if value == "" {
+					value = c.Name
+				}
+				consts.line("%s %s = %q", name, t.Name, value)
+			}
+		}
+	}
+	if consts.Len() == 0 {
+		return
+	}
+	p.line("const (")
+	p.Write(consts.Bytes())
+	p.line(")")
+	p.line("")
+}
+
+func (g *generator) typeDecl(p *printer, t *domain.APIType) {
+	g.doc(p, t.Name, t.Summary, t.Description)
+	switch t.Type {
+	case "Struct":
+		g.structDecl(p, t.Name, t.Struct)
+	case "EnumOfConsts":
+		kind := "string"
+		if len(t.EnumConsts) != 0 && t.EnumConsts[0].Type == "Number" {
+			kind = "int"
+		}
+		p.line("%s %s", t.Name, kind)
+	case "EnumOfTypes":
+		p.line("%s struct {", t.Name)
+		p.line("ValueEnumType interface{}")
+		p.line("}")
+		for _, variant := range t.EnumTypes {
+			p.line("")
+			name := t.Name + goName(variant.Name)
+			g.doc(p, name, variant.Summary, variant.Description)
+			switch variant.Type {
+			case "Struct":
+				g.structDecl(p, name, variant.Struct)
+			case "None":
+				p.line("%s struct{}", name)
+			default:
+				p.line("%s struct {", name)
+				p.line("Value %s `json:\"value\"`", g.goType(variant, "", false))
+				p.line("}")
+			}
+		}
+	default:
+		p.line("%s %s", t.Name, g.goType(t, "", false))
+	}
+	p.line("")
+}
+
+func (g *generator) structDecl(p *printer, name string, fields []*domain.APIType) {
+	if len(fields) == 0 {
+		p.line("%s struct{}", name)
+		return
+	}
+	p.line("%s struct {", name)
+	for _, field := range fields {
+		optional := field.Type == "Optional"
+		tag := field.Name
+		if optional {
+			tag += ",omitempty"
+		}
+		p.line("%s %s `json:\"%s\"`", goName(field.Name), g.goType(field, "", false), tag)
+	}
+	p.line("}")
+}
+
+// goType - Go type of t. Types of the domain package are qualified with qualifier.
+// Optional scalars are pointers; strings, slices and raw JSON use omitempty instead.
+func (g *generator) goType(t *domain.APIType, qualifier string, optional bool) string {
+	pointer := func(name string) string {
+		if optional {
+			return "*" + name
+		}
+		return name
+	}
+	switch t.Type {
+	case "String":
+		return "string"
+	case "Boolean":
+		return pointer("bool")
+	case "Number":
+		if t.NumberType == "Float" {
+			return pointer("float64")
+		}
+		return pointer("int")
+	case "BigInt":
+		return pointer("big.Int")
+	case "None":
+		return "struct{}"
+	case "Optional":
+		return g.goType(t.OptionalInner, qualifier, true)
+	case "Array":
+		return "[]" + g.goType(t.ArrayItem, qualifier, false)
+	case "Ref":
+		switch t.RefName {
+		case "Value", "Any":
+			return "json.RawMessage"
+		case "API":
+			return "*" + qualifier + "API"
+		}
+		name := localName(t.RefName)
+		named, ok := g.types[name]
+		if !ok {
+			return "json.RawMessage"
+		}
+		switch named.Type {
+		case "Struct", "EnumOfTypes":
+			return "*" + qualifier + name
+		case "String", "EnumOfConsts":
+			if len(named.EnumConsts) == 0 || named.EnumConsts[0].Type != "Number" {
+				return qualifier + name
+			}
+		}
+		return pointer(qualifier + name)
+	default:
+		return "json.RawMessage"
+	}
+}
+
+func (g *generator) enumMarshalers(p *printer, t *domain.APIType) {
+	recv := abbrev(t.Name)
+	p.line("")
+	p.line("func (%s *%s) MarshalJSON() ([]byte, error) {", recv, t.Name)
+	p.line("switch value := (%s.ValueEnumType).(type) {", recv)
+	for _, variant := range t.EnumTypes {
+		name := t.Name + goName(variant.Name)
+		p.line("case %s:", name)
+		p.line("return json.Marshal(struct {")
+		p.line("Type string `json:\"type\"`")
+		p.line("%s", name)
+		p.line("}{%q, value})", variant.Name)
+	}
+	p.line("default:")
+	p.line("return nil, fmt.Errorf(\"unsupported type for %s %%v\", %s.ValueEnumType)", t.Name, recv)
+	p.line("}")
+	p.line("}")
+	p.line("")
+	p.line("func (%s *%s) UnmarshalJSON(b []byte) error {", recv, t.Name)
+	p.line("var typeD EnumType")
+	p.line("if err := json.Unmarshal(b, &typeD); err != nil {")
+	p.line("return err")
+	p.line("}")
+	p.line("")
+	p.line("switch typeD.Type {")
+	for _, variant := range t.EnumTypes {
+		p.line("case %q:", variant.Name)
+		p.line("var valueEnum %s", t.Name+goName(variant.Name))
+		p.line("if err := json.Unmarshal(b, &valueEnum); err != nil {")
+		p.line("return err")
+		p.line("}")
+		p.line("%s.ValueEnumType = valueEnum", recv)
+	}
+	p.line("default:")
+	p.line("return fmt.Errorf(\"unsupported type for %s %%v\", typeD.Type)", t.Name)
+	p.line("}")
+	p.line("return nil")
+	p.line("}")
+}
+
+func (g *generator) useCaseInterface(p *printer, module *domain.APIModule) {
+	name := goName(module.Name) + "UseCase"
+	p.line("// %s ...", name)
+	p.line("%s interface {", name)
+	for _, f := range g.functions(module) {
+		method := goName(f.api.Name)
+		if f.manual != "" {
+			p.line("// %s: written by hand (%s).", method, f.manual)
+			continue
+		}
+		arg, ctxArgs := "", "context.Context"
+		if f.params != "" {
+			arg = "*" + f.params
+			ctxArgs += ", " + arg
+		}
+		returns := "error"
+		if f.result != "" {
+			returns = fmt.Sprintf("(*%s, error)", f.result)
+		}
+		p.line("%s(%s) %s", method, arg, returns)
+		p.line("%sCtx(%s) %s", method, ctxArgs, returns)
+	}
+	p.line("}")
+}
+
+// functions - functions of module with their params and result types resolved.
+func (g *generator) functions(module *domain.APIModule) []*function {
+	functions := make([]*function, 0, len(module.Functions))
+	for _, api := range module.Functions {
+		f := &function{api: api}
+		for _, param := range api.Params {
+			switch {
+			case param.Name == "context":
+			case param.Name == "params" && param.Type == "Ref":
+				f.params = localName(param.RefName)
+			case param.GenericName == "AppObject":
+				f.manual = "app object"
+			default:
+				f.manual = "callback"
+			}
+		}
+		result := api.Result
+		if result != nil && result.Type == "Generic" && len(result.GenericArgs) == 1 {
+			result = result.GenericArgs[0]
+		}
+		switch {
+		case result == nil || result.Type == "None":
+		case result.Type == "Ref":
+			f.result = localName(result.RefName)
+		default:
+			f.manual = "result of type " + result.Type
+		}
+		functions = append(functions, f)
+	}
+
+	return functions
+}
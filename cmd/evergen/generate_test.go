@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/internal/apiref"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	api, err := apiref.Load(filepath.Join("testdata", "api.json"), "")
+	assert.Equal(t, nil, err)
+
+	out, err := ioutil.TempDir("", "evergen")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(out)
+	assert.Equal(t, nil, generate(api, out, "client,crypto,net"))
+
+	read := func(path ...string) string {
+		src, err := ioutil.ReadFile(filepath.Join(append([]string{out}, path...)...))
+		assert.Equal(t, nil, err)
+		return string(src)
+	}
+
+	t.Run("TestDomain", func(t *testing.T) {
+		crypto := read("domain", "crypto.go")
+		assert.True(t, strings.HasPrefix(crypto, "// Code generated by evergen from the 1.40.0 API reference. DO NOT EDIT."))
+		assert.Contains(t, crypto, "CryptoErrorInvalidSignature = 122")
+		assert.Contains(t, crypto, `"InvalidSignature": CryptoErrorInvalidSignature,`)
+		assert.Contains(t, crypto, `CipherModeCBC CipherMode = "CBC"`)
+		assert.Contains(t, crypto, "EncryptionBoxHandle int\n")
+		assert.Contains(t, crypto, "EncryptionBox EncryptionBoxHandle `json:\"encryption_box\"`")
+		assert.Contains(t, crypto, "Keys        *KeyPair   `json:\"keys,omitempty\"`")
+		assert.Contains(t, crypto, "WordCount   *int       `json:\"word_count,omitempty\"`")
+		assert.Contains(t, crypto, "LastTransLt *big.Int   `json:\"last_trans_lt,omitempty\"`")
+		assert.Contains(t, crypto, "// ParamsOfAppEncryptionBoxGetInfo - Get encryption box info.\n\tParamsOfAppEncryptionBoxGetInfo struct{}")
+		assert.Contains(t, crypto, "func (pOAEB *ParamsOfAppEncryptionBox) UnmarshalJSON(b []byte) error {")
+		assert.Contains(t, crypto, `}{"Encrypt", value})`)
+		assert.Contains(t, crypto, "EncryptionBoxEncryptCtx(context.Context, *ParamsOfEncryptionBoxEncrypt) (*ResultOfEncryptionBoxEncrypt, error)")
+		assert.Contains(t, crypto, "// RegisterEncryptionBox: written by hand (app object).")
+
+		client := read("domain", "client.go")
+		assert.Contains(t, client, "ClientErrorInvalidConfig  = 15")
+		assert.Contains(t, client, "Result json.RawMessage `json:\"result\"`")
+		assert.NotContains(t, client, "ClientUseCase")
+	})
+
+	t.Run("TestUseCase", func(t *testing.T) {
+		crypto := read("usecase", "crypto", "crypto.go")
+		assert.Contains(t, crypto, "func (c *crypto) GenerateRandomSignKeysCtx(ctx context.Context) (*domain.KeyPair, error) {")
+		assert.Contains(t, crypto, `err := c.client.GetResultContext(ctx, "crypto.generate_random_sign_keys", nil, result)`)
+		assert.Contains(t, crypto, `err := c.client.GetResultContext(ctx, "crypto.encryption_box_encrypt", pOEBE, result)`)
+		assert.Contains(t, crypto, "// decryption to retrieve the original data from decrypted data.\nfunc (c *crypto) EncryptionBoxEncrypt(")
+		assert.NotContains(t, crypto, "RegisterEncryptionBox")
+
+		net := read("usecase", "net", "net.go")
+		assert.Contains(t, net, "func (n *net) Suspend() error {")
+		assert.Contains(t, net, `_, err := n.client.GetResponseContext(ctx, "net.suspend", nil)`)
+		assert.NotContains(t, net, "SubscribeCollection(")
+		assert.NotContains(t, net, `"encoding/json"`)
+
+		_, err := os.Stat(filepath.Join(out, "usecase", "client"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("TestFormatted", func(t *testing.T) {
+		for _, path := range []string{"domain/client.go", "domain/crypto.go", "domain/net.go", "usecase/crypto/crypto.go", "usecase/net/net.go"} {
+			src := read(filepath.FromSlash(path))
+			formatted, err := format.Source([]byte(src))
+			assert.Equal(t, nil, err)
+			assert.Equal(t, src, string(formatted), path)
+		}
+	})
+
+	t.Run("TestTypeCheck", func(t *testing.T) {
+		fset := token.NewFileSet()
+		imports := importer.ForCompiler(fset, "source", nil)
+		domainPkg, err := checkDomain(fset, imports, filepath.Join("..", "..", "domain"), filepath.Join(out, "domain"))
+		assert.Equal(t, nil, err)
+		for _, module := range []string{"crypto", "net"} {
+			files, err := parseDir(fset, filepath.Join(out, "usecase", module))
+			assert.Equal(t, nil, err)
+			_, err = checkPackage(fset, module, files, packageImporter{domain: domainPkg, fallback: imports})
+			assert.Equal(t, nil, err, module)
+		}
+	})
+
+	t.Run("TestNames", func(t *testing.T) {
+		assert.Equal(t, "ShardBlockID", goName("shard_block_id"))
+		assert.Equal(t, "JSON", goName("json"))
+		assert.Equal(t, "GenerateRandomSignKeys", goName("generate_random_sign_keys"))
+		assert.Equal(t, "pOCA", abbrev("ParamsOfConvertAddress"))
+		assert.Equal(t, "KeyPair", localName("crypto.KeyPair"))
+		assert.Equal(t, "TVMError", errorPrefix("tvm"))
+	})
+}
+
+const domainPath = "github.com/move-ton/ever-client-go/domain"
+
+// checkDomain type-checks the generated domain files of dir together with the hand-written rest of
+// the domain package in src: the declarations the generator writes are replaced with the generated ones.
+func checkDomain(fset *token.FileSet, imports types.Importer, src, dir string) (*types.Package, error) {
+	generated, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]bool)
+	for _, file := range generated {
+		for _, decl := range file.Decls {
+			for _, name := range declNames(decl) {
+				declared[name] = true
+			}
+		}
+	}
+
+	handWritten, err := parseDir(fset, src)
+	if err != nil {
+		return nil, err
+	}
+	files := generated
+	for _, file := range handWritten {
+		if strings.HasSuffix(fset.Position(file.Pos()).Filename, "_test.go") {
+			continue
+		}
+		decls := file.Decls[:0]
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok != token.IMPORT {
+				specs := gen.Specs[:0]
+				for _, spec := range gen.Specs {
+					if !anyDeclared(declared, specNames(spec)) {
+						specs = append(specs, spec)
+					}
+				}
+				if gen.Specs = specs; len(specs) == 0 {
+					continue
+				}
+			} else if anyDeclared(declared, declNames(decl)) {
+				continue
+			}
+			decls = append(decls, decl)
+		}
+		file.Decls = decls
+		files = append(files, file)
+	}
+
+	return checkPackage(fset, "domain", files, imports)
+}
+
+// checkPackage type-checks files. Imports left unused by checkDomain are ignored.
+func checkPackage(fset *token.FileSet, path string, files []*ast.File, imports types.Importer) (*types.Package, error) {
+	var errs []string
+	config := types.Config{
+		Importer: imports,
+		Error: func(err error) {
+			if !strings.Contains(err.Error(), "imported and not used") {
+				errs = append(errs, err.Error())
+			}
+		},
+	}
+	pkg, _ := config.Check(path, fset, files, nil)
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	return pkg, nil
+}
+
+// packageImporter imports the checked domain package instead of the one in the module.
+type packageImporter struct {
+	domain   *types.Package
+	fallback types.Importer
+}
+
+func (i packageImporter) Import(path string) (*types.Package, error) {
+	if path == domainPath {
+		return i.domain, nil
+	}
+
+	return i.fallback.Import(path)
+}
+
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*ast.File, 0, len(paths))
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// declNames returns the names a declaration adds to the package scope; methods are named Type.Method.
+func declNames(decl ast.Decl) []string {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil {
+			return []string{decl.Name.Name}
+		}
+		recv := decl.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		return []string{recv.(*ast.Ident).Name + "." + decl.Name.Name}
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range decl.Specs {
+			names = append(names, specNames(spec)...)
+		}
+		return names
+	}
+
+	return nil
+}
+
+func specNames(spec ast.Spec) []string {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		return []string{spec.Name.Name}
+	case *ast.ValueSpec:
+		names := make([]string, len(spec.Names))
+		for i, name := range spec.Names {
+			names[i] = name.Name
+		}
+		return names
+	}
+
+	return nil
+}
+
+func anyDeclared(declared map[string]bool, names []string) bool {
+	for _, name := range names {
+		if declared[name] && name != "init" && name != "_" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+// Command evergen generates the domain types and use case wrappers of ever-client-go
+// from the API reference of the Core Library, so that moving to a new library version is a regenerate.
+//
+// The reference is read from an api.json file (the result of client.get_api_reference or its "api" field)
+// or fetched from a tonclient server through the remote gateway:
+//
+//	go run ./cmd/evergen -api api.json -out ./gen
+//	go run ./cmd/evergen -remote http://localhost:8080 -out ./gen -modules crypto,utils
+//
+// For every module it writes <out>/domain/<module>.go and <out>/usecase/<module>/<module>.go.
+// Functions with callbacks or app objects are listed in the generated interfaces but their wrappers are written by hand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/internal/apiref"
+)
+
+func main() {
+	var (
+		apiPath   = flag.String("api", "", "path to api.json, - for stdin")
+		remoteURL = flag.String("remote", "", "URL of a tonclient server to fetch the API reference from")
+		out       = flag.String("out", ".", "output directory")
+		modules   = flag.String("modules", "", "comma-separated modules to generate, all by default")
+	)
+	flag.Parse()
+
+	api, err := apiref.Load(*apiPath, *remoteURL)
+	if err == nil {
+		err = generate(api, *out, *modules)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "evergen:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(api *domain.API, out, only string) error {
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(only, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected[name] = true
+		}
+	}
+
+	g := newGenerator(api)
+	for _, module := range api.Modules {
+		if len(selected) != 0 && !selected[module.Name] {
+			continue
+		}
+		src, err := g.domainFile(module)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(out, "domain", module.Name+".go"), src); err != nil {
+			return err
+		}
+		if module.Name == "client" {
+			// The client module is implemented by the gateways.
+			continue
+		}
+		src, err = g.useCaseFile(module)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(out, "usecase", module.Name, module.Name+".go"), src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, src []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, src, 0644)
+}
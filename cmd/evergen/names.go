@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms - words which the repo writes in upper case in Go names.
+var initialisms = map[string]string{
+	"id":   "ID",
+	"url":  "URL",
+	"json": "JSON",
+	"api":  "API",
+}
+
+// errorPrefixes - error constant prefixes which differ from the module name.
+var errorPrefixes = map[string]string{
+	"tvm": "TVM",
+}
+
+// goName converts snake_case and camelCase API names to exported Go names: shard_block_id -> ShardBlockID.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == ' ' || r == '.' }) {
+		if upper, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
+
+// abbrev - short variable name made of the capitals of typeName: ParamsOfConvertAddress -> pOCA.
+func abbrev(typeName string) string {
+	var b strings.Builder
+	for _, r := range typeName {
+		if unicode.IsUpper(r) {
+			b.WriteRune(r)
+		}
+	}
+	short := []rune(b.String())
+	if len(short) == 0 {
+		return "v"
+	}
+	short[0] = unicode.ToLower(short[0])
+
+	return string(short)
+}
+
+// localName strips the module qualifier of a reference: crypto.KeyPair -> KeyPair.
+func localName(refName string) string {
+	if i := strings.LastIndex(refName, "."); i >= 0 {
+		return refName[i+1:]
+	}
+
+	return refName
+}
+
+// sentence makes a doc comment sentence of an API summary.
+func sentence(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasSuffix(text, ".") {
+		return text
+	}
+
+	return text + "."
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -39,6 +40,25 @@ const (
 	MessageBodyTypeEvent MessageBodyType = "Event"
 )
 
+// Error codes of the abi module.
+const (
+	AbiErrorRequiredAddressMissingForEncodeMessage    = 301
+	AbiErrorRequiredCallSetMissingForEncodeMessage    = 302
+	AbiErrorInvalidJson                               = 303
+	AbiErrorInvalidMessage                            = 304
+	AbiErrorEncodeDeployMessageFailed                 = 305
+	AbiErrorEncodeRunMessageFailed                    = 306
+	AbiErrorAttachSignatureFailed                     = 307
+	AbiErrorInvalidTvcImage                           = 308
+	AbiErrorRequiredPublicKeyMissingForFunctionHeader = 309
+	AbiErrorInvalidSigner                             = 310
+	AbiErrorInvalidAbi                                = 311
+	AbiErrorInvalidFunctionId                         = 312
+	AbiErrorInvalidData                               = 313
+	AbiErrorEncodeInitialDataFailed                   = 314
+	AbiErrorInvalidFunctionName                       = 315
+)
+
 // AbiErrorCode ...
 var AbiErrorCode map[string]int
 
@@ -439,41 +459,57 @@ type (
 	//AbiUseCase ...
 	AbiUseCase interface {
 		EncodeMessageBody(*ParamsOfEncodeMessageBody) (*ResultOfEncodeMessageBody, error)
+		EncodeMessageBodyCtx(context.Context, *ParamsOfEncodeMessageBody) (*ResultOfEncodeMessageBody, error)
 		AttachSignatureToMessageBody(*ParamsOfAttachSignatureToMessageBody) (*ResultOfAttachSignatureToMessageBody, error)
+		AttachSignatureToMessageBodyCtx(context.Context, *ParamsOfAttachSignatureToMessageBody) (*ResultOfAttachSignatureToMessageBody, error)
 		EncodeMessage(*ParamsOfEncodeMessage) (*ResultOfEncodeMessage, error)
+		EncodeMessageCtx(context.Context, *ParamsOfEncodeMessage) (*ResultOfEncodeMessage, error)
 		EncodeInternalMessage(*ParamsOfEncodeInternalMessage) (*ResultOfEncodeInternalMessage, error)
+		EncodeInternalMessageCtx(context.Context, *ParamsOfEncodeInternalMessage) (*ResultOfEncodeInternalMessage, error)
 		AttachSignature(*ParamsOfAttachSignature) (*ResultOfAttachSignature, error)
+		AttachSignatureCtx(context.Context, *ParamsOfAttachSignature) (*ResultOfAttachSignature, error)
 		DecodeMessage(*ParamsOfDecodeMessage) (*DecodedMessageBody, error)
+		DecodeMessageCtx(context.Context, *ParamsOfDecodeMessage) (*DecodedMessageBody, error)
 		DecodeMessageBody(*ParamsOfDecodeMessageBody) (*DecodedMessageBody, error)
+		DecodeMessageBodyCtx(context.Context, *ParamsOfDecodeMessageBody) (*DecodedMessageBody, error)
 		EncodeAccount(*ParamsOfEncodeAccount) (*ResultOfEncodeAccount, error)
+		EncodeAccountCtx(context.Context, *ParamsOfEncodeAccount) (*ResultOfEncodeAccount, error)
 		DecodeAccountData(*ParamsOfDecodeAccountData) (*ResultOfDecodeData, error)
+		DecodeAccountDataCtx(context.Context, *ParamsOfDecodeAccountData) (*ResultOfDecodeData, error)
 		UpdateInitialData(*ParamsOfUpdateInitialData) (*ResultOfUpdateInitialData, error)
+		UpdateInitialDataCtx(context.Context, *ParamsOfUpdateInitialData) (*ResultOfUpdateInitialData, error)
 		EncodeInitialData(*ParamsOfEncodeInitialData) (*ResultOfEncodeInitialData, error)
+		EncodeInitialDataCtx(context.Context, *ParamsOfEncodeInitialData) (*ResultOfEncodeInitialData, error)
 		DecodeInitialData(*ParamsOfDecodeInitialData) (*ResultOfDecodeInitialData, error)
+		DecodeInitialDataCtx(context.Context, *ParamsOfDecodeInitialData) (*ResultOfDecodeInitialData, error)
 		DecodeBoc(*ParamsOfDecodeBoc) (*ResultOfDecodeBoc, error)
+		DecodeBocCtx(context.Context, *ParamsOfDecodeBoc) (*ResultOfDecodeBoc, error)
 		EncodeBoc(*ParamsOfAbiEncodeBoc) (*ResultOfAbiEncodeBoc, error)
+		EncodeBocCtx(context.Context, *ParamsOfAbiEncodeBoc) (*ResultOfAbiEncodeBoc, error)
 		CalcFunctionID(*ParamsOfCalcFunctionId) (*ResultOfCalcFunctionId, error)
+		CalcFunctionIDCtx(context.Context, *ParamsOfCalcFunctionId) (*ResultOfCalcFunctionId, error)
 		GetSignatureData(*ParamsOfGetSignatureData) (*ResultOfGetSignatureData, error)
+		GetSignatureDataCtx(context.Context, *ParamsOfGetSignatureData) (*ResultOfGetSignatureData, error)
 	}
 )
 
 func init() {
 	AbiErrorCode = map[string]int{
-		"RequiredAddressMissingForEncodeMessage":    301,
-		"RequiredCallSetMissingForEncodeMessage":    302,
-		"InvalidJson":                               303,
-		"InvalidMessage":                            304,
-		"EncodeDeployMessageFailed":                 305,
-		"EncodeRunMessageFailed":                    306,
-		"AttachSignatureFailed":                     307,
-		"InvalidTvcImage":                           308,
-		"RequiredPublicKeyMissingForFunctionHeader": 309,
-		"InvalidSigner":                             310,
-		"InvalidAbi":                                311,
-		"InvalidFunctionId":                         312,
-		"InvalidData":                               313,
-		"EncodeInitialDataFailed":                   314,
-		"InvalidFunctionName":                       315,
+		"RequiredAddressMissingForEncodeMessage":    AbiErrorRequiredAddressMissingForEncodeMessage,
+		"RequiredCallSetMissingForEncodeMessage":    AbiErrorRequiredCallSetMissingForEncodeMessage,
+		"InvalidJson":                               AbiErrorInvalidJson,
+		"InvalidMessage":                            AbiErrorInvalidMessage,
+		"EncodeDeployMessageFailed":                 AbiErrorEncodeDeployMessageFailed,
+		"EncodeRunMessageFailed":                    AbiErrorEncodeRunMessageFailed,
+		"AttachSignatureFailed":                     AbiErrorAttachSignatureFailed,
+		"InvalidTvcImage":                           AbiErrorInvalidTvcImage,
+		"RequiredPublicKeyMissingForFunctionHeader": AbiErrorRequiredPublicKeyMissingForFunctionHeader,
+		"InvalidSigner":                             AbiErrorInvalidSigner,
+		"InvalidAbi":                                AbiErrorInvalidAbi,
+		"InvalidFunctionId":                         AbiErrorInvalidFunctionId,
+		"InvalidData":                               AbiErrorInvalidData,
+		"EncodeInitialDataFailed":                   AbiErrorEncodeInitialDataFailed,
+		"InvalidFunctionName":                       AbiErrorInvalidFunctionName,
 	}
 }
 
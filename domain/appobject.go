@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type (
+	// ErrorHandler - receives errors which have no caller to be returned to, e.g. of app object callbacks.
+	ErrorHandler func(err error)
+
+	// AppObjectError - failure of an app object callback, passed to the ErrorHandler of the gateway.
+	AppObjectError struct {
+		// Method - function which registered the app object, e.g. crypto.register_signing_box.
+		Method       string
+		AppRequestID int
+		Err          error
+	}
+)
+
+func (e *AppObjectError) Error() string {
+	return fmt.Sprintf("%s app object: %v", e.Method, e.Err)
+}
+
+// Unwrap returns the cause.
+func (e *AppObjectError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultErrorHandler - logs err with DefaultLogger, see LogErrors.
+func DefaultErrorHandler(err error) {
+	LogErrors(DefaultLogger)(err)
+}
+
+// ResolveAppObject - passes the request data of appRequest, an AppRequest frame of a Stream, to handle and resolves
+// the request with the result of handle or with an AppRequestResultError. A panic in handle is resolved as an error too.
+// Panics, timeouts and failures which cannot be resolved are passed to client.ReportError; the caller never panics.
+func ResolveAppObject(client ClientGateway, method string, appRequest AppRequest, handle func(requestData json.RawMessage) (interface{}, error)) {
+	result, err := callAppObject(func() (interface{}, error) {
+		return handle(appRequest.RequestData)
+	})
+	var panicErr *appObjectPanic
+	if errors.As(err, &panicErr) || errors.Is(err, ErrAppObjectTimeout) {
+		client.ReportError(&AppObjectError{Method: method, AppRequestID: appRequest.AppRequestID, Err: err})
+	}
+	appRequestResult := &AppRequestResult{}
+	if err == nil {
+		var raw []byte
+		if raw, err = json.Marshal(result); err == nil {
+			appRequestResult.ValueEnumType = AppRequestResultOk{Result: raw}
+		}
+	}
+	if err != nil {
+		appRequestResult.ValueEnumType = AppRequestResultError{Text: err.Error()}
+	}
+
+	err = client.ResolveAppRequest(&ParamsOfResolveAppRequest{AppRequestID: appRequest.AppRequestID, Result: appRequestResult})
+	if err != nil && !errors.Is(err, ErrClientClosed) {
+		client.ReportError(&AppObjectError{Method: method, AppRequestID: appRequest.AppRequestID, Err: err})
+	}
+}
+
+// ServeAppNotify - passes the app notification in payload to handle.
+// Errors and panics of handle are passed to client.ReportError.
+func ServeAppNotify(client ClientGateway, method string, payload []byte, handle func(data json.RawMessage) error) {
+	_, err := callAppObject(func() (interface{}, error) {
+		return nil, handle(payload)
+	})
+	if err != nil {
+		client.ReportError(&AppObjectError{Method: method, Err: err})
+	}
+}
+
+// appObjectPanic - panic of an app object callback.
+type appObjectPanic struct {
+	value interface{}
+}
+
+func (p *appObjectPanic) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+func callAppObject(call func() (interface{}, error)) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &appObjectPanic{value: r}
+		}
+	}()
+
+	return call()
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type resolvingGateway struct {
+	BaseGateway
+	resolved []*ParamsOfResolveAppRequest
+	reported []error
+}
+
+func newResolvingGateway() *resolvingGateway {
+	gw := &resolvingGateway{}
+	gw.Requester = func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+		gw.resolved = append(gw.resolved, paramIn.(*ParamsOfResolveAppRequest))
+		out := make(chan *ClientResponse, 1)
+		out <- &ClientResponse{Code: 0, Data: []byte("{}")}
+		close(out)
+		return out, nil
+	}
+	gw.ErrorHandler = func(err error) {
+		gw.reported = append(gw.reported, err)
+	}
+
+	return gw
+}
+
+func (gw *resolvingGateway) Destroy() {}
+
+func (gw *resolvingGateway) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestResolveAppObject(t *testing.T) {
+	appRequest := AppRequest{AppRequestID: 7, RequestData: json.RawMessage(`{"type":"GetPublicKey"}`)}
+
+	t.Run("TestOk", func(t *testing.T) {
+		gw := newResolvingGateway()
+		ResolveAppObject(gw, "crypto.register_signing_box", appRequest, func(requestData json.RawMessage) (interface{}, error) {
+			return map[string]string{"public_key": "00"}, nil
+		})
+		assert.Equal(t, 1, len(gw.resolved))
+		assert.Equal(t, 7, gw.resolved[0].AppRequestID)
+		_, ok := gw.resolved[0].Result.ValueEnumType.(AppRequestResultOk)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, 0, len(gw.reported))
+	})
+
+	t.Run("TestError", func(t *testing.T) {
+		gw := newResolvingGateway()
+		ResolveAppObject(gw, "crypto.register_signing_box", appRequest, func(requestData json.RawMessage) (interface{}, error) {
+			return nil, errors.New("no key")
+		})
+		assert.Equal(t, 1, len(gw.resolved))
+		assert.Equal(t, AppRequestResultError{Text: "no key"}, gw.resolved[0].Result.ValueEnumType)
+		assert.Equal(t, 0, len(gw.reported))
+	})
+
+	t.Run("TestPanic", func(t *testing.T) {
+		gw := newResolvingGateway()
+		ResolveAppObject(gw, "crypto.register_signing_box", appRequest, func(requestData json.RawMessage) (interface{}, error) {
+			panic("broken box")
+		})
+		assert.Equal(t, 1, len(gw.resolved))
+		assert.Equal(t, AppRequestResultError{Text: "panic: broken box"}, gw.resolved[0].Result.ValueEnumType)
+		assert.Equal(t, 1, len(gw.reported))
+		var appObjectErr *AppObjectError
+		assert.Equal(t, true, errors.As(gw.reported[0], &appObjectErr))
+		assert.Equal(t, 7, appObjectErr.AppRequestID)
+	})
+}
+
+func TestServeAppNotify(t *testing.T) {
+	gw := newResolvingGateway()
+	ServeAppNotify(gw, "debot.init", []byte(`{}`), func(data json.RawMessage) error {
+		panic("broken browser")
+	})
+	ServeAppNotify(gw, "debot.init", []byte(`{}`), func(data json.RawMessage) error {
+		return nil
+	})
+	assert.Equal(t, 1, len(gw.reported))
+	assert.Equal(t, 0, len(gw.resolved))
+}
@@ -1,10 +1,22 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
+// Error codes of the boc module.
+const (
+	BocErrorInvalidBoc            = 201
+	BocErrorSerializationError    = 202
+	BocErrorInappropriateBlock    = 203
+	BocErrorMissingSourceBoc      = 204
+	BocErrorInsufficientCacheSize = 205
+	BocErrorBocRefNotFound        = 206
+	BocErrorInvalidBocRef         = 207
+)
+
 // BocErrorCode ...
 var BocErrorCode map[string]int
 
@@ -237,36 +249,55 @@ type (
 	//BocUseCase ...
 	BocUseCase interface {
 		ParseMessage(*ParamsOfParse) (*ResultOfParse, error)
+		ParseMessageCtx(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseTransaction(*ParamsOfParse) (*ResultOfParse, error)
+		ParseTransactionCtx(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseAccount(*ParamsOfParse) (*ResultOfParse, error)
+		ParseAccountCtx(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseBlock(*ParamsOfParse) (*ResultOfParse, error)
+		ParseBlockCtx(context.Context, *ParamsOfParse) (*ResultOfParse, error)
 		ParseShardstate(*ParamsOfParseShardstate) (*ResultOfParse, error)
+		ParseShardstateCtx(context.Context, *ParamsOfParseShardstate) (*ResultOfParse, error)
 		GetBlockhainConfig(*ParamsOfGetBlockchainConfig) (*ResultOfGetBlockchainConfig, error)
+		GetBlockhainConfigCtx(context.Context, *ParamsOfGetBlockchainConfig) (*ResultOfGetBlockchainConfig, error)
 		GetBocHash(*ParamsOfGetBocHash) (*ResultOfGetBocHash, error)
+		GetBocHashCtx(context.Context, *ParamsOfGetBocHash) (*ResultOfGetBocHash, error)
 		GetBocDepth(*ParamsOfGetBocDepth) (*ResultOfGetBocDepth, error)
+		GetBocDepthCtx(context.Context, *ParamsOfGetBocDepth) (*ResultOfGetBocDepth, error)
 		GetCodeFromTvc(*ParamsOfGetCodeFromTvc) (*ResultOfGetCodeFromTvc, error)
+		GetCodeFromTvcCtx(context.Context, *ParamsOfGetCodeFromTvc) (*ResultOfGetCodeFromTvc, error)
 		CacheGet(*ParamsOfBocCacheGet) (*ResultOfBocCacheGet, error)
+		CacheGetCtx(context.Context, *ParamsOfBocCacheGet) (*ResultOfBocCacheGet, error)
 		CacheSet(*ParamsOfBocCacheSet) (*ResultOfBocCacheSet, error)
+		CacheSetCtx(context.Context, *ParamsOfBocCacheSet) (*ResultOfBocCacheSet, error)
 		CacheUnpin(*ParamsOfBocCacheUnpin) error
+		CacheUnpinCtx(context.Context, *ParamsOfBocCacheUnpin) error
 		EncodeBoc(*ParamsOfEncodeBoc) (*ResultOfEncodeBoc, error)
+		EncodeBocCtx(context.Context, *ParamsOfEncodeBoc) (*ResultOfEncodeBoc, error)
 		GetCodeSalt(*ParamsOfGetCodeSalt) (*ResultOfGetCodeSalt, error)
+		GetCodeSaltCtx(context.Context, *ParamsOfGetCodeSalt) (*ResultOfGetCodeSalt, error)
 		SetCodeSalt(*ParamsOfSetCodeSalt) (*ResultOfSetCodeSalt, error)
+		SetCodeSaltCtx(context.Context, *ParamsOfSetCodeSalt) (*ResultOfSetCodeSalt, error)
 		DecodeTvc(*ParamsOfDecodeTvc) (*ResultOfDecodeTvc, error)
+		DecodeTvcCtx(context.Context, *ParamsOfDecodeTvc) (*ResultOfDecodeTvc, error)
 		EncodeTvc(*ParamsOfEncodeTvc) (*ResultOfEncodeTvc, error)
+		EncodeTvcCtx(context.Context, *ParamsOfEncodeTvc) (*ResultOfEncodeTvc, error)
 		EncodeExternalInMessage(*ParamsOfEncodeExternalInMessage) (*ResultOfEncodeExternalInMessage, error)
+		EncodeExternalInMessageCtx(context.Context, *ParamsOfEncodeExternalInMessage) (*ResultOfEncodeExternalInMessage, error)
 		GetCompilerVersion(version *ParamsOfGetCompilerVersion) (*ResultOfGetCompilerVersion, error)
+		GetCompilerVersionCtx(ctx context.Context, version *ParamsOfGetCompilerVersion) (*ResultOfGetCompilerVersion, error)
 	}
 )
 
 func init() {
 	BocErrorCode = map[string]int{
-		"InvalidBoc":            201,
-		"SerializationError":    202,
-		"InappropriateBlock":    203,
-		"MissingSourceBoc":      204,
-		"InsufficientCacheSize": 205,
-		"BocRefNotFound":        206,
-		"InvalidBocRef":         207,
+		"InvalidBoc":            BocErrorInvalidBoc,
+		"SerializationError":    BocErrorSerializationError,
+		"InappropriateBlock":    BocErrorInappropriateBlock,
+		"MissingSourceBoc":      BocErrorMissingSourceBoc,
+		"InsufficientCacheSize": BocErrorInsufficientCacheSize,
+		"BocRefNotFound":        BocErrorBocRefNotFound,
+		"InvalidBocRef":         BocErrorInvalidBocRef,
 	}
 }
 
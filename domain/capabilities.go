@@ -0,0 +1,56 @@
+package domain
+
+import "sync"
+
+// Capabilities - set of the SDK functions of a library, built from its API reference on first use.
+type Capabilities struct {
+	load      func() (*ResultOfGetAPIReference, error)
+	once      sync.Once
+	functions map[string]bool
+	err       error
+}
+
+// NewCapabilities - capabilities whose API reference is loaded by load, e.g. gateway.GetAPIReference.
+func NewCapabilities(load func() (*ResultOfGetAPIReference, error)) *Capabilities {
+	return &Capabilities{load: load}
+}
+
+// Supports reports whether the library has method, e.g. "processing.monitor_messages".
+// If the API reference could not be loaded every function is reported as supported,
+// so that callers get the error of the call itself.
+func (c *Capabilities) Supports(method string) bool {
+	c.once.Do(func() {
+		var reference *ResultOfGetAPIReference
+		reference, c.err = c.load()
+		if c.err != nil {
+			return
+		}
+		c.functions = FunctionsOf(reference.API)
+	})
+	if c.err != nil {
+		return true
+	}
+
+	return c.functions[method]
+}
+
+// Err returns the error of loading the API reference, if any.
+func (c *Capabilities) Err() error {
+	c.Supports("")
+	return c.err
+}
+
+// FunctionsOf returns the set of "module.function" names of api.
+func FunctionsOf(api *API) map[string]bool {
+	functions := make(map[string]bool)
+	if api == nil {
+		return functions
+	}
+	for _, module := range api.Modules {
+		for _, f := range module.Functions {
+			functions[module.Name+"."+f.Name] = true
+		}
+	}
+
+	return functions
+}
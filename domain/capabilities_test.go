@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilities(t *testing.T) {
+	t.Run("TestSupports", func(t *testing.T) {
+		loads := 0
+		capabilities := NewCapabilities(func() (*ResultOfGetAPIReference, error) {
+			loads++
+			return &ResultOfGetAPIReference{API: &API{Modules: []*APIModule{{
+				Name:      "processing",
+				Functions: []*APIFunction{{Name: "send_message"}, {Name: "monitor_messages"}},
+			}}}}, nil
+		})
+		assert.True(t, capabilities.Supports("processing.monitor_messages"))
+		assert.False(t, capabilities.Supports("processing.fetch_next_monitor_results"))
+		assert.Equal(t, nil, capabilities.Err())
+		assert.Equal(t, 1, loads)
+	})
+
+	t.Run("TestLoadFailed", func(t *testing.T) {
+		failure := errors.New("no reference")
+		capabilities := NewCapabilities(func() (*ResultOfGetAPIReference, error) {
+			return nil, failure
+		})
+		assert.True(t, capabilities.Supports("processing.monitor_messages"))
+		assert.Equal(t, failure, capabilities.Err())
+	})
+	t.Run("TestBaseGateway", func(t *testing.T) {
+		loads := 0
+		gateway := BaseGateway{Requester: func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			loads++
+			out := make(chan *ClientResponse, 1)
+			out <- &ClientResponse{Code: 0, Data: []byte(`{"api":{"modules":[{"name":"client","functions":[{"name":"version"}]}]}}`)}
+			close(out)
+			return out, nil
+		}}
+		// Without Capabilities nothing is loaded.
+		assert.True(t, gateway.Supports("client.unknown_function"))
+		assert.Equal(t, 0, loads)
+
+		gateway.Capabilities = NewCapabilities(gateway.GetAPIReference)
+		assert.True(t, gateway.Supports("client.version"))
+		assert.False(t, gateway.Supports("client.unknown_function"))
+		assert.Equal(t, 1, loads)
+	})
+}
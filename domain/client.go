@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/move-ton/ever-client-go/util"
@@ -15,6 +16,46 @@ const (
 	NetworkQueriesProtocolWS NetworkQueriesProtocol = "WS"
 )
 
+// Error codes of the client module.
+const (
+	ClientErrorNotImplemented                      = 1
+	ClientErrorInvalidHex                          = 2
+	ClientErrorInvalidBase64                       = 3
+	ClientErrorInvalidAddress                      = 4
+	ClientErrorCallbackParamsCantBeConvertedToJson = 5
+	ClientErrorWebsocketConnectError               = 6
+	ClientErrorWebsocketReceiveError               = 7
+	ClientErrorWebsocketSendError                  = 8
+	ClientErrorHttpClientCreateError               = 9
+	ClientErrorHttpRequestCreateError              = 10
+	ClientErrorHttpRequestSendError                = 11
+	ClientErrorHttpRequestParseError               = 12
+	ClientErrorCallbackNotRegistered               = 13
+	ClientErrorNetModuleNotInit                    = 14
+	ClientErrorInvalidConfig                       = 15
+	ClientErrorCannotCreateRuntime                 = 16
+	ClientErrorInvalidContextHandle                = 17
+	ClientErrorCannotSerializeResult               = 18
+	ClientErrorCannotSerializeError                = 19
+	ClientErrorCannotConvertJsValueToJson          = 20
+	ClientErrorCannotReceiveSpawnedResult          = 21
+	ClientErrorSetTimerError                       = 22
+	ClientErrorInvalidParams                       = 23
+	ClientErrorContractsAddressConversionFailed    = 24
+	ClientErrorUnknownFunction                     = 25
+	ClientErrorAppRequestError                     = 26
+	ClientErrorNoSuchRequest                       = 27
+	ClientErrorCanNotSendRequestResult             = 28
+	ClientErrorCanNotReceiveRequestResult          = 29
+	ClientErrorCanNotParseRequestResult            = 30
+	ClientErrorUnexpectedCallbackResponse          = 31
+	ClientErrorCanNotParseNumber                   = 32
+	ClientErrorInternalError                       = 33
+	ClientErrorInvalidHandle                       = 34
+	ClientErrorLocalStorageError                   = 35
+	ClientErrorInvalidData                         = 36
+)
+
 // ClientErrorCode ...
 var ClientErrorCode map[string]int
 
@@ -59,35 +100,58 @@ type (
 		API *API `json:"api"`
 	}
 
-	// API ...
+	// API - Core Library API reference, as returned by client.get_api_reference.
 	API struct {
-		Modules []struct {
-			Description string `json:"description"`
-			Functions   []struct {
-				Description interface{}   `json:"description"`
-				Errors      interface{}   `json:"errors"`
-				Name        string        `json:"name"`
-				Params      []interface{} `json:"params"`
-				Result      struct {
-					Ref string `json:"ref"`
-				} `json:"result"`
-				Summary interface{} `json:"summary"`
-			} `json:"functions"`
-			Name    string `json:"name"`
-			Summary string `json:"summary"`
-			Types   []struct {
-				Description interface{} `json:"description"`
-				Name        string      `json:"name"`
-				Struct      []struct {
-					Description interface{} `json:"description"`
-					Name        string      `json:"name"`
-					Ref         string      `json:"ref"`
-					Summary     interface{} `json:"summary"`
-				} `json:"struct"`
-				Summary interface{} `json:"summary"`
-			} `json:"types"`
-		} `json:"modules"`
-		Version string `json:"version"`
+		Version string       `json:"version"`
+		Modules []*APIModule `json:"modules"`
+	}
+
+	// APIModule ...
+	APIModule struct {
+		Name        string         `json:"name"`
+		Summary     string         `json:"summary"`
+		Description string         `json:"description"`
+		Types       []*APIType     `json:"types"`
+		Functions   []*APIFunction `json:"functions"`
+	}
+
+	// APIFunction ...
+	APIFunction struct {
+		Name        string          `json:"name"`
+		Summary     string          `json:"summary"`
+		Description string          `json:"description"`
+		Params      []*APIType      `json:"params"`
+		Result      *APIType        `json:"result"`
+		Errors      json.RawMessage `json:"errors,omitempty"`
+	}
+
+	// APIType - type description. Type is one of None, Any, Boolean, String, Number, BigInt, Ref, Optional, Array,
+	// Struct, EnumOfConsts, EnumOfTypes or Generic; the fields which describe it depend on Type.
+	// Named types, struct fields and function params have Name set.
+	APIType struct {
+		Name          string      `json:"name,omitempty"`
+		Type          string      `json:"type"`
+		Summary       string      `json:"summary,omitempty"`
+		Description   string      `json:"description,omitempty"`
+		RefName       string      `json:"ref_name,omitempty"`
+		OptionalInner *APIType    `json:"optional_inner,omitempty"`
+		ArrayItem     *APIType    `json:"array_item,omitempty"`
+		Struct        []*APIType  `json:"struct,omitempty"`
+		EnumConsts    []*APIConst `json:"enum_consts,omitempty"`
+		EnumTypes     []*APIType  `json:"enum_types,omitempty"`
+		GenericName   string      `json:"generic_name,omitempty"`
+		GenericArgs   []*APIType  `json:"generic_args,omitempty"`
+		NumberType    string      `json:"number_type,omitempty"`
+		NumberSize    int         `json:"number_size,omitempty"`
+	}
+
+	// APIConst - value of an EnumOfConsts type. Value is empty for constants named by their Name.
+	APIConst struct {
+		Name        string `json:"name"`
+		Type        string `json:"type"`
+		Value       string `json:"value,omitempty"`
+		Summary     string `json:"summary,omitempty"`
+		Description string `json:"description,omitempty"`
 	}
 
 	// ResultOfBuildInfo ...
@@ -122,14 +186,24 @@ type (
 	// ClientGateway ...
 	ClientGateway interface {
 		Destroy()
+		Close(context.Context) error
 		GetResult(string, interface{}, interface{}) error
+		GetResultContext(context.Context, string, interface{}, interface{}) error
 		Request(string, interface{}) (<-chan *ClientResponse, error)
+		RequestContext(context.Context, string, interface{}) (<-chan *ClientResponse, error)
 		GetResponse(string, interface{}) ([]byte, error)
+		GetResponseContext(context.Context, string, interface{}) ([]byte, error)
 		GetAPIReference() (*ResultOfGetAPIReference, error)
 		Version() (*ResultOfVersion, error)
 		Config() (*ClientConfig, error)
 		GetBuildInfo() (*ResultOfBuildInfo, error)
 		ResolveAppRequest(*ParamsOfResolveAppRequest) error
+		Supports(string) bool
+		ReportError(error)
+		AppObjects() *AppObjectDispatcher
+		Call(string, interface{}) (json.RawMessage, error)
+		CallContext(context.Context, string, interface{}) (json.RawMessage, error)
+		CallStream(context.Context, string, interface{}, StreamHandler) (json.RawMessage, error)
 	}
 
 	// AppPasswordProvider ...
@@ -275,14 +349,20 @@ func DictionaryList() map[string]*int {
 	}
 }
 
-// GetMainNetBaseUrls return endpoints main net.
+// GetMainNetBaseUrls return endpoints main net of the mainnet profile of Networks,
+// nil if EVER_PROJECT_ID is not set, which ClientConfig.Validate rejects.
+//
+// Deprecated: use Networks.Config("mainnet"), it reports the missing project ID.
 func GetMainNetBaseUrls() []string {
-	return []string{"https://mainnet.evercloud.dev/662c63951d884a52bbc20f775b5c4baf/graphql/"}
+	return networkEndpoints("mainnet")
 }
 
-// GetDevNetBaseUrls return endpoint dev net.
+// GetDevNetBaseUrls return endpoint dev net of the devnet profile of Networks,
+// nil if EVER_PROJECT_ID is not set, which ClientConfig.Validate rejects.
+//
+// Deprecated: use Networks.Config("devnet"), it reports the missing project ID.
 func GetDevNetBaseUrls() []string {
-	return []string{"https://devnet.evercloud.dev/662c63951d884a52bbc20f775b5c4baf/graphql/"}
+	return networkEndpoints("devnet")
 }
 
 // GetLocalNetBaseUrls return endpoint localhost net.
@@ -293,42 +373,42 @@ func GetLocalNetBaseUrls() []string {
 func init() {
 	// ClientErrorCode - list with error client.
 	ClientErrorCode = map[string]int{
-		"NotImplemented":                      1,
-		"InvalidHex":                          2,
-		"InvalidBase64":                       3,
-		"InvalidAddress":                      4,
-		"CallbackParamsCantBeConvertedToJson": 5,
-		"WebsocketConnectError":               6,
-		"WebsocketReceiveError":               7,
-		"WebsocketSendError":                  8,
-		"HttpClientCreateError":               9,
-		"HttpRequestCreateError":              10,
-		"HttpRequestSendError":                11,
-		"HttpRequestParseError":               12,
-		"CallbackNotRegistered":               13,
-		"NetModuleNotInit":                    14,
-		"InvalidConfig":                       15,
-		"CannotCreateRuntime":                 16,
-		"InvalidContextHandle":                17,
-		"CannotSerializeResult":               18,
-		"CannotSerializeError":                19,
-		"CannotConvertJsValueToJson":          20,
-		"CannotReceiveSpawnedResult":          21,
-		"SetTimerError":                       22,
-		"InvalidParams":                       23,
-		"ContractsAddressConversionFailed":    24,
-		"UnknownFunction":                     25,
-		"AppRequestError":                     26,
-		"NoSuchRequest":                       27,
-		"CanNotSendRequestResult":             28,
-		"CanNotReceiveRequestResult":          29,
-		"CanNotParseRequestResult":            30,
-		"UnexpectedCallbackResponse":          31,
-		"CanNotParseNumber":                   32,
-		"InternalError":                       33,
-		"InvalidHandle":                       34,
-		"LocalStorageError":                   35,
-		"InvalidData":                         36,
+		"NotImplemented":                      ClientErrorNotImplemented,
+		"InvalidHex":                          ClientErrorInvalidHex,
+		"InvalidBase64":                       ClientErrorInvalidBase64,
+		"InvalidAddress":                      ClientErrorInvalidAddress,
+		"CallbackParamsCantBeConvertedToJson": ClientErrorCallbackParamsCantBeConvertedToJson,
+		"WebsocketConnectError":               ClientErrorWebsocketConnectError,
+		"WebsocketReceiveError":               ClientErrorWebsocketReceiveError,
+		"WebsocketSendError":                  ClientErrorWebsocketSendError,
+		"HttpClientCreateError":               ClientErrorHttpClientCreateError,
+		"HttpRequestCreateError":              ClientErrorHttpRequestCreateError,
+		"HttpRequestSendError":                ClientErrorHttpRequestSendError,
+		"HttpRequestParseError":               ClientErrorHttpRequestParseError,
+		"CallbackNotRegistered":               ClientErrorCallbackNotRegistered,
+		"NetModuleNotInit":                    ClientErrorNetModuleNotInit,
+		"InvalidConfig":                       ClientErrorInvalidConfig,
+		"CannotCreateRuntime":                 ClientErrorCannotCreateRuntime,
+		"InvalidContextHandle":                ClientErrorInvalidContextHandle,
+		"CannotSerializeResult":               ClientErrorCannotSerializeResult,
+		"CannotSerializeError":                ClientErrorCannotSerializeError,
+		"CannotConvertJsValueToJson":          ClientErrorCannotConvertJsValueToJson,
+		"CannotReceiveSpawnedResult":          ClientErrorCannotReceiveSpawnedResult,
+		"SetTimerError":                       ClientErrorSetTimerError,
+		"InvalidParams":                       ClientErrorInvalidParams,
+		"ContractsAddressConversionFailed":    ClientErrorContractsAddressConversionFailed,
+		"UnknownFunction":                     ClientErrorUnknownFunction,
+		"AppRequestError":                     ClientErrorAppRequestError,
+		"NoSuchRequest":                       ClientErrorNoSuchRequest,
+		"CanNotSendRequestResult":             ClientErrorCanNotSendRequestResult,
+		"CanNotReceiveRequestResult":          ClientErrorCanNotReceiveRequestResult,
+		"CanNotParseRequestResult":            ClientErrorCanNotParseRequestResult,
+		"UnexpectedCallbackResponse":          ClientErrorUnexpectedCallbackResponse,
+		"CanNotParseNumber":                   ClientErrorCanNotParseNumber,
+		"InternalError":                       ClientErrorInternalError,
+		"InvalidHandle":                       ClientErrorInvalidHandle,
+		"LocalStorageError":                   ClientErrorLocalStorageError,
+		"InvalidData":                         ClientErrorInvalidData,
 	}
 }
 
@@ -373,28 +453,13 @@ func DynBufferForResponses(in <-chan *ClientResponse) <-chan *ClientResponse {
 
 // HandleEvents ...
 func HandleEvents(responses <-chan *ClientResponse, callback EventCallback, result interface{}) error {
-	for r := range responses {
-		switch r.Code {
-		case 100:
-			event := &ProcessingEvent{}
-			if err := json.Unmarshal(r.Data, event); err != nil {
-				panic(err)
-			}
-			callback(event)
-		case 1:
-			return r.Error
-		case 0:
-			if err := json.Unmarshal(r.Data, result); err != nil {
-				panic(err)
-			}
-
-			return nil
-		default:
-			panic(fmt.Errorf("unknown response type code %v", r.Code))
-		}
-	}
+	return HandleEventsContext(context.Background(), responses, callback, result)
+}
 
-	return nil
+// HandleEventsContext - HandleEvents for responses of a request made with RequestContext, see Stream.Wait.
+// Returns ctx.Err() if the responses channel was closed by the cancelled context before the result arrived.
+func HandleEventsContext(ctx context.Context, responses <-chan *ClientResponse, callback EventCallback, result interface{}) error {
+	return NewStream(responses, nil).Wait(ctx, result, ProcessingEvents(callback))
 }
 
 func (aRR *AppRequestResult) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// durationFields - config fields in milliseconds, which also accept duration strings such as "40s".
+// Fields of other structs are marked with the `config:"duration"` tag.
+var durationFields = map[string]bool{
+	"network.max_reconnect_timeout":      true,
+	"network.reconnect_timeout":          true,
+	"network.message_processing_timeout": true,
+	"network.wait_for_timeout":           true,
+	"network.out_of_sync_threshold":      true,
+	"network.latency_detection_interval": true,
+	"network.max_latency":                true,
+	"network.query_timeout":              true,
+	"network.first_remp_status_timeout":  true,
+	"network.next_remp_status_timeout":   true,
+	"abi.message_expiration_timeout":     true,
+}
+
+// LoadConfig - NewDefaultConfig overridden by the config file at path. The format is chosen by the extension:
+// .toml, .yaml, .yml or .json. Keys are the JSON names of the fields, grouped by section:
+//
+//	[network]
+//	endpoints = ["https://devnet.evercloud.dev"]
+//	wait_for_timeout = "40s"
+//
+// Fields in milliseconds accept duration strings, endpoints accept a comma separated string.
+// Precedence, lowest first: defaults, the file, the environment applied by MergeConfigEnv.
+// The networks section is skipped, so one file can also hold the profiles read by NetworkRegistry.Load.
+func LoadConfig(path string) (ClientConfig, error) {
+	config := NewDefaultConfig("", nil, "")
+	values, err := readConfigFile(path)
+	if err != nil {
+		return config, err
+	}
+	delete(values, "networks")
+	if err := mergeConfig(&config, values); err != nil {
+		return config, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// readConfigFile decodes the .toml, .yaml, .yml or .json file at path.
+func readConfigFile(path string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		values, err = parseTOML(data)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	default:
+		err = fmt.Errorf("unsupported config format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// ConfigFromEnv - NewDefaultConfig overridden by the environment, see MergeConfigEnv.
+func ConfigFromEnv(prefix string) (ClientConfig, error) {
+	config := NewDefaultConfig("", nil, "")
+	err := MergeConfigEnv(&config, prefix)
+
+	return config, err
+}
+
+// MergeConfigEnv - overrides config with the environment variables named PREFIX_SECTION_FIELD,
+// e.g. EVER_NETWORK_ENDPOINTS="https://a,https://b", EVER_NETWORK_WAIT_FOR_TIMEOUT=40s or EVER_LOCAL_STORAGE_PATH.
+func MergeConfigEnv(config *ClientConfig, prefix string) error {
+	values := make(map[string]interface{})
+	envConfig(reflect.TypeOf(ClientConfig{}), strings.TrimSuffix(strings.ToUpper(prefix), "_"), values)
+
+	return mergeConfig(config, values)
+}
+
+// envConfig collects the variables of the fields of t into values.
+func envConfig(t reflect.Type, prefix string, values map[string]interface{}) {
+	for name, field := range configFields(t) {
+		env := strings.ToUpper(name)
+		if prefix != "" {
+			env = prefix + "_" + env
+		}
+		if ft := indirect(field.Type); ft.Kind() == reflect.Struct {
+			section := make(map[string]interface{})
+			envConfig(ft, env, section)
+			if len(section) != 0 {
+				values[name] = section
+			}
+			continue
+		}
+		if value, ok := os.LookupEnv(env); ok {
+			values[name] = value
+		}
+	}
+}
+
+// mergeConfig overrides config with values, checking the keys and converting the values to the field types.
+func mergeConfig(config *ClientConfig, values map[string]interface{}) error {
+	normalized, err := normalizeConfig(reflect.TypeOf(ClientConfig{}), "", values)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, config)
+}
+
+func normalizeConfig(t reflect.Type, section string, values map[string]interface{}) (map[string]interface{}, error) {
+	fields := configFields(t)
+	normalized := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		path := key
+		if section != "" {
+			path = section + "." + key
+		}
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown config key %q", path)
+		}
+
+		ft := indirect(field.Type)
+		if ft.Kind() != reflect.Struct {
+			converted, err := configValue(ft, path, durationFields[path] || field.Tag.Get("config") == "duration", value)
+			if err != nil {
+				return nil, err
+			}
+			normalized[key] = converted
+			continue
+		}
+		sub, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config key %q must be a section", path)
+		}
+		converted, err := normalizeConfig(ft, path, sub)
+		if err != nil {
+			return nil, err
+		}
+		normalized[key] = converted
+	}
+
+	return normalized, nil
+}
+
+// configValue converts the string values of files and variables to the kind of the field at path.
+// Duration strings are converted to milliseconds if duration is set.
+func configValue(t reflect.Type, path string, duration bool, value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	var (
+		converted interface{}
+		err       error
+	)
+	switch t.Kind() {
+	case reflect.Int:
+		if d, durationErr := time.ParseDuration(s); durationErr == nil && duration {
+			return int(d / time.Millisecond), nil
+		}
+		converted, err = strconv.Atoi(s)
+	case reflect.Float32, reflect.Float64:
+		converted, err = strconv.ParseFloat(s, 64)
+	case reflect.Bool:
+		converted, err = strconv.ParseBool(s)
+	case reflect.Slice:
+		items := make([]string, 0)
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		converted = items
+	default:
+		converted = s
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config key %q: invalid value %q", path, s)
+	}
+
+	return converted, nil
+}
+
+// configFields returns the fields of t by JSON name.
+func configFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field
+	}
+
+	return fields
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.Equal(t, nil, ioutil.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+	check := func(t *testing.T, config ClientConfig) {
+		assert.Equal(t, []string{"https://a.dev", "https://b.dev"}, config.Network.Endpoints)
+		assert.Equal(t, 30000, *config.Network.WaitForTimeout)
+		assert.Equal(t, 3, *config.Network.NetworkRetriesCount)
+		assert.Equal(t, NetworkQueriesProtocolWS, config.Network.QueriesProtocol)
+		assert.Equal(t, 24, *config.Crypto.MnemonicWordCount)
+		assert.Equal(t, float32(2), *config.Abi.MessageExpirationTimeoutGrowFactor)
+		assert.Equal(t, true, *config.ProofsConfig.CacheInLocalStorage)
+		assert.Equal(t, "/var/lib/ever", config.LocalStoragePath)
+		// Defaults which are not overridden are kept.
+		assert.Equal(t, 5, *config.Network.MessageRetriesCount)
+		assert.Equal(t, 1, *config.Crypto.MnemonicDictionary)
+	}
+
+	t.Run("TestTOML", func(t *testing.T) {
+		config, err := LoadConfig(write("config.toml", `
+local_storage_path = "/var/lib/ever" # comment
+
+[network]
+endpoints = [
+	"https://a.dev",
+	"https://b.dev", # trailing comma
+]
+wait_for_timeout = "30s"
+network_retries_count = 3
+queries_protocol = 'WS'
+
+[crypto]
+mnemonic_word_count = 24
+
+[abi]
+message_expiration_timeout_grow_factor = 2.0
+
+[proofs]
+cache_in_local_storage = true
+`))
+		assert.Equal(t, nil, err)
+		check(t, config)
+	})
+
+	t.Run("TestYAML", func(t *testing.T) {
+		config, err := LoadConfig(write("config.yaml", `
+local_storage_path: /var/lib/ever
+network:
+  endpoints: https://a.dev, https://b.dev
+  wait_for_timeout: 30s
+  network_retries_count: 3
+  queries_protocol: WS
+crypto:
+  mnemonic_word_count: 24
+abi:
+  message_expiration_timeout_grow_factor: 2
+proofs:
+  cache_in_local_storage: true
+`))
+		assert.Equal(t, nil, err)
+		check(t, config)
+	})
+
+	t.Run("TestJSON", func(t *testing.T) {
+		config, err := LoadConfig(write("config.json", `{
+	"local_storage_path": "/var/lib/ever",
+	"network": {"endpoints": ["https://a.dev", "https://b.dev"], "wait_for_timeout": 30000, "network_retries_count": 3, "queries_protocol": "WS"},
+	"crypto": {"mnemonic_word_count": 24},
+	"abi": {"message_expiration_timeout_grow_factor": 2},
+	"proofs": {"cache_in_local_storage": true}
+}`))
+		assert.Equal(t, nil, err)
+		check(t, config)
+	})
+
+	t.Run("TestEnv", func(t *testing.T) {
+		path := write("env.toml", "[network]\nwait_for_timeout = \"10s\"\nnetwork_retries_count = 7\n")
+		vars := map[string]string{
+			"TEST_EVER_NETWORK_ENDPOINTS":                          "https://a.dev,https://b.dev",
+			"TEST_EVER_NETWORK_WAIT_FOR_TIMEOUT":                   "30s",
+			"TEST_EVER_NETWORK_NETWORK_RETRIES_COUNT":              "3",
+			"TEST_EVER_NETWORK_QUERIES_PROTOCOL":                   "WS",
+			"TEST_EVER_CRYPTO_MNEMONIC_WORD_COUNT":                 "24",
+			"TEST_EVER_ABI_MESSAGE_EXPIRATION_TIMEOUT_GROW_FACTOR": "2",
+			"TEST_EVER_PROOFS_CACHE_IN_LOCAL_STORAGE":              "true",
+			"TEST_EVER_LOCAL_STORAGE_PATH":                         "/var/lib/ever",
+		}
+		for name, value := range vars {
+			os.Setenv(name, value)
+			defer os.Unsetenv(name)
+		}
+
+		config, err := ConfigFromEnv("TEST_EVER")
+		assert.Equal(t, nil, err)
+		check(t, config)
+
+		config, err = LoadConfig(path)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 10000, *config.Network.WaitForTimeout)
+		assert.Equal(t, nil, MergeConfigEnv(&config, "TEST_EVER_"))
+		check(t, config)
+	})
+
+	t.Run("TestNetworksSection", func(t *testing.T) {
+		path := write("both.toml", `
+[network]
+wait_for_timeout = "30s"
+
+[networks.private]
+endpoints = "https://a.private/graphql"
+`)
+		config, err := LoadConfig(path)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 30000, *config.Network.WaitForTimeout)
+
+		networks := NewNetworkRegistry()
+		assert.Equal(t, nil, networks.Load(path))
+		assert.Equal(t, []string{"private"}, networks.Names())
+	})
+
+	t.Run("TestErrors", func(t *testing.T) {
+		_, err := LoadConfig(write("unknown.toml", "[network]\nwait_for = 1\n"))
+		assert.EqualError(t, err, filepath.Join(dir, "unknown.toml")+`: unknown config key "network.wait_for"`)
+		_, err = LoadConfig(write("retries.yaml", "network:\n  network_retries_count: 3s\n"))
+		assert.EqualError(t, err, filepath.Join(dir, "retries.yaml")+`: config key "network.network_retries_count": invalid value "3s"`)
+		_, err = LoadConfig(write("config.ini", ""))
+		assert.NotEqual(t, nil, err)
+		_, err = LoadConfig(write("broken.toml", "[network\n"))
+		assert.NotEqual(t, nil, err)
+
+		os.Setenv("TEST_EVER_BOC_CACHE_MAX_SIZE", "big")
+		defer os.Unsetenv("TEST_EVER_BOC_CACHE_MAX_SIZE")
+		_, err = ConfigFromEnv("TEST_EVER")
+		assert.EqualError(t, err, `config key "boc.cache_max_size": invalid value "big"`)
+	})
+}
+
+func TestParseTOML(t *testing.T) {
+	values, err := parseTOML([]byte(`
+title = "a # b"
+a.b = 'c'
+n = 1_000
+neg = -2
+hex = 0x10
+[x.y]
+list = [[1, 2], ["3"]]
+`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, map[string]interface{}{
+		"title": "a # b",
+		"a":     map[string]interface{}{"b": "c"},
+		"n":     int64(1000),
+		"neg":   int64(-2),
+		"hex":   int64(16),
+		"x": map[string]interface{}{"y": map[string]interface{}{
+			"list": []interface{}{[]interface{}{int64(1), int64(2)}, []interface{}{"3"}},
+		}},
+	}, values)
+
+	_, err = parseTOML([]byte("a = 1\na = 2\n"))
+	assert.EqualError(t, err, `toml: line 2: duplicate key "a"`)
+
+	for source, message := range map[string]string{
+		"a = {b = 1}":              `toml: line 1: inline tables are not supported: {b = 1}`,
+		"a = [{b = 1}]":            `toml: line 1: inline tables are not supported: {b = 1}`,
+		"[[a]]\nb = 1":             `toml: line 1: arrays of tables are not supported: [[a]]`,
+		"a = \"\"\"b\"\"\"":        `toml: line 1: multi-line strings are not supported: """b"""`,
+		"\n\na = '''\nb\n'''":      `toml: line 3: multi-line strings are not supported: '''`,
+		"a = 1979-05-27T07:32:00Z": `toml: line 1: dates and times are not supported: 1979-05-27T07:32:00Z`,
+		"a = 1979-05-27":           `toml: line 1: dates and times are not supported: 1979-05-27`,
+		"a = 07:32:00":             `toml: line 1: dates and times are not supported: 07:32:00`,
+	} {
+		_, err = parseTOML([]byte(source))
+		assert.EqualError(t, err, message, source)
+	}
+}
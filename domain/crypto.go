@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/move-ton/ever-client-go/util"
@@ -29,6 +30,42 @@ const (
 	CipherModeOFB CipherMode = "OFB"
 )
 
+// Error codes of the crypto module.
+const (
+	CryptoErrorInvalidPublicKey                    = 100
+	CryptoErrorInvalidSecretKey                    = 101
+	CryptoErrorInvalidKey                          = 102
+	CryptoErrorInvalidFactorizeChallenge           = 106
+	CryptoErrorInvalidBigInt                       = 107
+	CryptoErrorScryptFailed                        = 108
+	CryptoErrorInvalidKeySize                      = 109
+	CryptoErrorNaclSecretBoxFailed                 = 110
+	CryptoErrorNaclBoxFailed                       = 111
+	CryptoErrorNaclSignFailed                      = 112
+	CryptoErrorBip39InvalidEntropy                 = 113
+	CryptoErrorBip39InvalidPhrase                  = 114
+	CryptoErrorBip32InvalidKey                     = 115
+	CryptoErrorBip32InvalidDerivePath              = 116
+	CryptoErrorBip39InvalidDictionary              = 117
+	CryptoErrorBip39InvalidWordCount               = 118
+	CryptoErrorMnemonicGenerationFailed            = 119
+	CryptoErrorMnemonicFromEntropyFailed           = 120
+	CryptoErrorSigningBoxNotRegistered             = 121
+	CryptoErrorInvalidSignature                    = 122
+	CryptoErrorEncryptionBoxNotRegistered          = 123
+	CryptoErrorInvalidIvSize                       = 124
+	CryptoErrorUnsupportedCipherMode               = 125
+	CryptoErrorCannotCreateCipher                  = 126
+	CryptoErrorEncryptDataError                    = 127
+	CryptoErrorDecryptDataError                    = 128
+	CryptoErrorIvRequired                          = 129
+	CryptoErrorCryptoBoxNotRegistered              = 130
+	CryptoErrorInvalidCryptoBoxType                = 131
+	CryptoErrorCryptoBoxSecretSerializationError   = 132
+	CryptoErrorCryptoBoxSecretDeserializationError = 133
+	CryptoErrorInvalidNonceSize                    = 134
+)
+
 // CryptoErrorCode ...
 var CryptoErrorCode map[string]int
 
@@ -688,94 +725,145 @@ type (
 	// CryptoUseCase ...
 	CryptoUseCase interface {
 		Factorize(*ParamsOfFactorize) (*ResultOfFactorize, error)
+		FactorizeCtx(context.Context, *ParamsOfFactorize) (*ResultOfFactorize, error)
 		ModularPower(*ParamsOfModularPower) (*ResultOfModularPower, error)
+		ModularPowerCtx(context.Context, *ParamsOfModularPower) (*ResultOfModularPower, error)
 		TonCrc16(*ParamsOfTonCrc16) (*ResultOfTonCrc16, error)
+		TonCrc16Ctx(context.Context, *ParamsOfTonCrc16) (*ResultOfTonCrc16, error)
 		GenerateRandomBytes(*ParamsOfGenerateRandomBytes) (*ResultOfGenerateRandomBytes, error)
+		GenerateRandomBytesCtx(context.Context, *ParamsOfGenerateRandomBytes) (*ResultOfGenerateRandomBytes, error)
 		ConvertPublicKeyString(*ParamsOfConvertPublicKeyToTonSafeFormat) (*ResultOfConvertPublicKeyToTonSafeFormat, error)
+		ConvertPublicKeyStringCtx(context.Context, *ParamsOfConvertPublicKeyToTonSafeFormat) (*ResultOfConvertPublicKeyToTonSafeFormat, error)
 		GenerateRandomSignKeys() (*KeyPair, error)
+		GenerateRandomSignKeysCtx(context.Context) (*KeyPair, error)
 		Sign(*ParamsOfSign) (*ResultOfSign, error)
+		SignCtx(context.Context, *ParamsOfSign) (*ResultOfSign, error)
 		VerifySignature(*ParamsOfVerifySignature) (*ResultOfVerifySignature, error)
+		VerifySignatureCtx(context.Context, *ParamsOfVerifySignature) (*ResultOfVerifySignature, error)
 		Sha256(*ParamsOfHash) (*ResultOfHash, error)
+		Sha256Ctx(context.Context, *ParamsOfHash) (*ResultOfHash, error)
 		Sha512(*ParamsOfHash) (*ResultOfHash, error)
+		Sha512Ctx(context.Context, *ParamsOfHash) (*ResultOfHash, error)
 		Scrypt(*ParamsOfScrypt) (*ResultOfScrypt, error)
+		ScryptCtx(context.Context, *ParamsOfScrypt) (*ResultOfScrypt, error)
 		NaclSignKeypairFromSecretKey(*ParamsOfNaclSignKeyPairFromSecret) (*KeyPair, error)
+		NaclSignKeypairFromSecretKeyCtx(context.Context, *ParamsOfNaclSignKeyPairFromSecret) (*KeyPair, error)
 		NaclSign(*ParamsOfNaclSign) (*ResultOfNaclSign, error)
+		NaclSignCtx(context.Context, *ParamsOfNaclSign) (*ResultOfNaclSign, error)
 		NaclSignOpen(*ParamsOfNaclSignOpen) (*ResultOfNaclSignOpen, error)
+		NaclSignOpenCtx(context.Context, *ParamsOfNaclSignOpen) (*ResultOfNaclSignOpen, error)
 		NaclSignDetached(*ParamsOfNaclSign) (*ResultOfNaclSignDetached, error)
+		NaclSignDetachedCtx(context.Context, *ParamsOfNaclSign) (*ResultOfNaclSignDetached, error)
 		NaclSignDetachedVerify(*ParamsOfNaclSignDetachedVerify) (*ResultOfNaclSignDetachedVerify, error)
+		NaclSignDetachedVerifyCtx(context.Context, *ParamsOfNaclSignDetachedVerify) (*ResultOfNaclSignDetachedVerify, error)
 		NaclBoxKeypair() (*KeyPair, error)
+		NaclBoxKeypairCtx(context.Context) (*KeyPair, error)
 		NaclBoxKeypairFromSecretKey(*ParamsOfNaclBoxKeyPairFromSecret) (*KeyPair, error)
+		NaclBoxKeypairFromSecretKeyCtx(context.Context, *ParamsOfNaclBoxKeyPairFromSecret) (*KeyPair, error)
 		NaclBox(*ParamsOfNaclBox) (*ResultOfNaclBox, error)
+		NaclBoxCtx(context.Context, *ParamsOfNaclBox) (*ResultOfNaclBox, error)
 		NaclBoxOpen(*ParamsOfNaclBoxOpen) (*ResultOfNaclBoxOpen, error)
+		NaclBoxOpenCtx(context.Context, *ParamsOfNaclBoxOpen) (*ResultOfNaclBoxOpen, error)
 		NaclSecretBox(*ParamsOfNaclSecretBox) (*ResultOfNaclBox, error)
+		NaclSecretBoxCtx(context.Context, *ParamsOfNaclSecretBox) (*ResultOfNaclBox, error)
 		NaclSecretBoxOpen(*ParamsOfNaclSecretBoxOpen) (*ResultOfNaclBoxOpen, error)
+		NaclSecretBoxOpenCtx(context.Context, *ParamsOfNaclSecretBoxOpen) (*ResultOfNaclBoxOpen, error)
 		MnemonicWords(*ParamsOfMnemonicWords) (*ResultOfMnemonicWords, error)
+		MnemonicWordsCtx(context.Context, *ParamsOfMnemonicWords) (*ResultOfMnemonicWords, error)
 		MnemonicFromRandom(*ParamsOfMnemonicFromRandom) (*ResultOfMnemonicFromRandom, error)
+		MnemonicFromRandomCtx(context.Context, *ParamsOfMnemonicFromRandom) (*ResultOfMnemonicFromRandom, error)
 		MnemonicFromEntropy(*ParamsOfMnemonicFromEntropy) (*ResultOfMnemonicFromEntropy, error)
+		MnemonicFromEntropyCtx(context.Context, *ParamsOfMnemonicFromEntropy) (*ResultOfMnemonicFromEntropy, error)
 		MnemonicVerify(*ParamsOfMnemonicVerify) (*ResultOfMnemonicVerify, error)
+		MnemonicVerifyCtx(context.Context, *ParamsOfMnemonicVerify) (*ResultOfMnemonicVerify, error)
 		MnemonicDeriveSignKeys(*ParamsOfMnemonicDeriveSignKeys) (*KeyPair, error)
+		MnemonicDeriveSignKeysCtx(context.Context, *ParamsOfMnemonicDeriveSignKeys) (*KeyPair, error)
 		HDKeyXprvFromMnemonic(*ParamsOfHDKeyXPrvFromMnemonic) (*ResultOfHDKeyXPrvFromMnemonic, error)
+		HDKeyXprvFromMnemonicCtx(context.Context, *ParamsOfHDKeyXPrvFromMnemonic) (*ResultOfHDKeyXPrvFromMnemonic, error)
 		HDKeyDeriveFromXprv(*ParamsOfHDKeyDeriveFromXPrv) (*ResultOfHDKeyDeriveFromXPrv, error)
+		HDKeyDeriveFromXprvCtx(context.Context, *ParamsOfHDKeyDeriveFromXPrv) (*ResultOfHDKeyDeriveFromXPrv, error)
 		HDKeyDeriveFromXprvPath(*ParamsOfHDKeyDeriveFromXPrvPath) (*ResultOfHDKeyDeriveFromXPrvPath, error)
+		HDKeyDeriveFromXprvPathCtx(context.Context, *ParamsOfHDKeyDeriveFromXPrvPath) (*ResultOfHDKeyDeriveFromXPrvPath, error)
 		HDKeySecretFromXprv(*ParamsOfHDKeySecretFromXPrv) (*ResultOfHDKeySecretFromXPrv, error)
+		HDKeySecretFromXprvCtx(context.Context, *ParamsOfHDKeySecretFromXPrv) (*ResultOfHDKeySecretFromXPrv, error)
 		HDKeyPublicFromXprv(*ParamsOfHDKeyPublicFromXPrv) (*ResultOfHDKeyPublicFromXPrv, error)
+		HDKeyPublicFromXprvCtx(context.Context, *ParamsOfHDKeyPublicFromXPrv) (*ResultOfHDKeyPublicFromXPrv, error)
 		Chacha20(*ParamsOfChaCha20) (*ResultOfChaCha20, error)
+		Chacha20Ctx(context.Context, *ParamsOfChaCha20) (*ResultOfChaCha20, error)
 		CreateCryptoBox(*ParamsOfCreateCryptoBox, AppPasswordProvider) (*RegisteredCryptoBox, error)
+		CreateCryptoBoxCtx(context.Context, *ParamsOfCreateCryptoBox, AppPasswordProvider) (*RegisteredCryptoBox, error)
 		RemoveCryptoBox(*RegisteredCryptoBox) error
+		RemoveCryptoBoxCtx(context.Context, *RegisteredCryptoBox) error
 		GetCryptoBoxInfo(*RegisteredCryptoBox) (*ResultOfGetCryptoBoxInfo, error)
+		GetCryptoBoxInfoCtx(context.Context, *RegisteredCryptoBox) (*ResultOfGetCryptoBoxInfo, error)
 		GetCryptoBoxSeedPhrase(*RegisteredCryptoBox) (*ResultOfGetCryptoBoxSeedPhrase, error)
+		GetCryptoBoxSeedPhraseCtx(context.Context, *RegisteredCryptoBox) (*ResultOfGetCryptoBoxSeedPhrase, error)
 		GetSigningBoxFromCryptoBox(*ParamsOfGetSigningBoxFromCryptoBox) (*RegisteredSigningBox, error)
+		GetSigningBoxFromCryptoBoxCtx(context.Context, *ParamsOfGetSigningBoxFromCryptoBox) (*RegisteredSigningBox, error)
 		GetEncryptionBoxFromCryptoBox(box *ParamsOfGetEncryptionBoxFromCryptoBox) (*RegisteredEncryptionBox, error)
+		GetEncryptionBoxFromCryptoBoxCtx(ctx context.Context, box *ParamsOfGetEncryptionBoxFromCryptoBox) (*RegisteredEncryptionBox, error)
 		ClearCryptoBoxSecretCache(*RegisteredCryptoBox) error
+		ClearCryptoBoxSecretCacheCtx(context.Context, *RegisteredCryptoBox) error
 		RegisterSigningBox(AppSigningBox) (*RegisteredSigningBox, error)
+		RegisterSigningBoxCtx(context.Context, AppSigningBox) (*RegisteredSigningBox, error)
 		GetSigningBox(*KeyPair) (*RegisteredSigningBox, error)
+		GetSigningBoxCtx(context.Context, *KeyPair) (*RegisteredSigningBox, error)
 		SigningBoxGetPublicKey(*RegisteredSigningBox) (*ResultOfSigningBoxGetPublicKey, error)
+		SigningBoxGetPublicKeyCtx(context.Context, *RegisteredSigningBox) (*ResultOfSigningBoxGetPublicKey, error)
 		SigningBoxSign(*ParamsOfSigningBoxSign) (*ResultOfSigningBoxSign, error)
+		SigningBoxSignCtx(context.Context, *ParamsOfSigningBoxSign) (*ResultOfSigningBoxSign, error)
 		RemoveSigningBox(*RegisteredSigningBox) error
+		RemoveSigningBoxCtx(context.Context, *RegisteredSigningBox) error
 		RegisterEncryptionBox(AppEncryptionBox) (*RegisteredEncryptionBox, error)
+		RegisterEncryptionBoxCtx(context.Context, AppEncryptionBox) (*RegisteredEncryptionBox, error)
 		RemoveEncryptionBox(*RegisteredEncryptionBox) error
+		RemoveEncryptionBoxCtx(context.Context, *RegisteredEncryptionBox) error
 		EncryptionBoxGetInfo(*ParamsOfEncryptionBoxGetInfo) (*ResultOfEncryptionBoxGetInfo, error)
+		EncryptionBoxGetInfoCtx(context.Context, *ParamsOfEncryptionBoxGetInfo) (*ResultOfEncryptionBoxGetInfo, error)
 		EncryptionBoxEncrypt(*ParamsOfEncryptionBoxEncrypt) (*ResultOfEncryptionBoxEncrypt, error)
+		EncryptionBoxEncryptCtx(context.Context, *ParamsOfEncryptionBoxEncrypt) (*ResultOfEncryptionBoxEncrypt, error)
 		EncryptionBoxDecrypt(*ParamsOfEncryptionBoxDecrypt) (*ResultOfEncryptionBoxDecrypt, error)
+		EncryptionBoxDecryptCtx(context.Context, *ParamsOfEncryptionBoxDecrypt) (*ResultOfEncryptionBoxDecrypt, error)
 		CreateEncryptionBox(*ParamsOfCreateEncryptionBox) (*RegisteredEncryptionBox, error)
+		CreateEncryptionBoxCtx(context.Context, *ParamsOfCreateEncryptionBox) (*RegisteredEncryptionBox, error)
 	}
 )
 
 func init() {
 	// List errors crypto module
 	CryptoErrorCode = map[string]int{
-		"InvalidPublicKey":                    100,
-		"InvalidSecretKey":                    101,
-		"InvalidKey":                          102,
-		"InvalidFactorizeChallenge":           106,
-		"InvalidBigInt":                       107,
-		"ScryptFailed":                        108,
-		"InvalidKeySize":                      109,
-		"NaclSecretBoxFailed":                 110,
-		"NaclBoxFailed":                       111,
-		"NaclSignFailed":                      112,
-		"Bip39InvalidEntropy":                 113,
-		"Bip39InvalidPhrase":                  114,
-		"Bip32InvalidKey":                     115,
-		"Bip32InvalidDerivePath":              116,
-		"Bip39InvalidDictionary":              117,
-		"Bip39InvalidWordCount":               118,
-		"MnemonicGenerationFailed":            119,
-		"MnemonicFromEntropyFailed":           120,
-		"SigningBoxNotRegistered":             121,
-		"InvalidSignature":                    122,
-		"EncryptionBoxNotRegistered":          123,
-		"InvalidIvSize":                       124,
-		"UnsupportedCipherMode":               125,
-		"CannotCreateCipher":                  126,
-		"EncryptDataError":                    127,
-		"DecryptDataError":                    128,
-		"IvRequired":                          129,
-		"CryptoBoxNotRegistered":              130,
-		"InvalidCryptoBoxType":                131,
-		"CryptoBoxSecretSerializationError":   132,
-		"CryptoBoxSecretDeserializationError": 133,
-		"InvalidNonceSize":                    134,
+		"InvalidPublicKey":                    CryptoErrorInvalidPublicKey,
+		"InvalidSecretKey":                    CryptoErrorInvalidSecretKey,
+		"InvalidKey":                          CryptoErrorInvalidKey,
+		"InvalidFactorizeChallenge":           CryptoErrorInvalidFactorizeChallenge,
+		"InvalidBigInt":                       CryptoErrorInvalidBigInt,
+		"ScryptFailed":                        CryptoErrorScryptFailed,
+		"InvalidKeySize":                      CryptoErrorInvalidKeySize,
+		"NaclSecretBoxFailed":                 CryptoErrorNaclSecretBoxFailed,
+		"NaclBoxFailed":                       CryptoErrorNaclBoxFailed,
+		"NaclSignFailed":                      CryptoErrorNaclSignFailed,
+		"Bip39InvalidEntropy":                 CryptoErrorBip39InvalidEntropy,
+		"Bip39InvalidPhrase":                  CryptoErrorBip39InvalidPhrase,
+		"Bip32InvalidKey":                     CryptoErrorBip32InvalidKey,
+		"Bip32InvalidDerivePath":              CryptoErrorBip32InvalidDerivePath,
+		"Bip39InvalidDictionary":              CryptoErrorBip39InvalidDictionary,
+		"Bip39InvalidWordCount":               CryptoErrorBip39InvalidWordCount,
+		"MnemonicGenerationFailed":            CryptoErrorMnemonicGenerationFailed,
+		"MnemonicFromEntropyFailed":           CryptoErrorMnemonicFromEntropyFailed,
+		"SigningBoxNotRegistered":             CryptoErrorSigningBoxNotRegistered,
+		"InvalidSignature":                    CryptoErrorInvalidSignature,
+		"EncryptionBoxNotRegistered":          CryptoErrorEncryptionBoxNotRegistered,
+		"InvalidIvSize":                       CryptoErrorInvalidIvSize,
+		"UnsupportedCipherMode":               CryptoErrorUnsupportedCipherMode,
+		"CannotCreateCipher":                  CryptoErrorCannotCreateCipher,
+		"EncryptDataError":                    CryptoErrorEncryptDataError,
+		"DecryptDataError":                    CryptoErrorDecryptDataError,
+		"IvRequired":                          CryptoErrorIvRequired,
+		"CryptoBoxNotRegistered":              CryptoErrorCryptoBoxNotRegistered,
+		"InvalidCryptoBoxType":                CryptoErrorInvalidCryptoBoxType,
+		"CryptoBoxSecretSerializationError":   CryptoErrorCryptoBoxSecretSerializationError,
+		"CryptoBoxSecretDeserializationError": CryptoErrorCryptoBoxSecretDeserializationError,
+		"InvalidNonceSize":                    CryptoErrorInvalidNonceSize,
 	}
 }
 
@@ -1,6 +1,26 @@
 package domain
 
-import "math/big"
+import (
+	"context"
+	"math/big"
+)
+
+// Error codes of the debot module.
+const (
+	DebotErrorDebotStartFailed           = 801
+	DebotErrorDebotFetchFailed           = 802
+	DebotErrorDebotExecutionFailed       = 803
+	DebotErrorDebotInvalidHandle         = 804
+	DebotErrorDebotInvalidJsonParams     = 805
+	DebotErrorDebotInvalidFunctionId     = 806
+	DebotErrorDebotInvalidAbi            = 807
+	DebotErrorDebotGetMethodFailed       = 808
+	DebotErrorDebotInvalidMsg            = 809
+	DebotErrorDebotExternalCallFailed    = 810
+	DebotErrorDebotBrowserCallbackFailed = 811
+	DebotErrorDebotOperationRejected     = 812
+	DebotErrorDebotNoCode                = 813
+)
 
 // DebotErrorCode ...
 var DebotErrorCode map[string]int
@@ -173,28 +193,34 @@ type (
 	// DebotUseCase ...
 	DebotUseCase interface {
 		Init(*ParamsOfInit, AppDebotBrowser) (*RegisteredDebot, error)
+		InitCtx(context.Context, *ParamsOfInit, AppDebotBrowser) (*RegisteredDebot, error)
 		Start(*ParamsOfStart) error
+		StartCtx(context.Context, *ParamsOfStart) error
 		Fetch(*ParamsOfFetch) (*ResultOfFetch, error)
+		FetchCtx(context.Context, *ParamsOfFetch) (*ResultOfFetch, error)
 		Execute(*ParamsOfExecute) error
+		ExecuteCtx(context.Context, *ParamsOfExecute) error
 		Send(*ParamsOfSend) error
+		SendCtx(context.Context, *ParamsOfSend) error
 		Remove(*ParamsOfRemove) error
+		RemoveCtx(context.Context, *ParamsOfRemove) error
 	}
 )
 
 func init() {
 	DebotErrorCode = map[string]int{
-		"DebotStartFailed":           801,
-		"DebotFetchFailed":           802,
-		"DebotExecutionFailed":       803,
-		"DebotInvalidHandle":         804,
-		"DebotInvalidJsonParams":     805,
-		"DebotInvalidFunctionId":     806,
-		"DebotInvalidAbi":            807,
-		"DebotGetMethodFailed":       808,
-		"DebotInvalidMsg":            809,
-		"DebotExternalCallFailed":    810,
-		"DebotBrowserCallbackFailed": 811,
-		"DebotOperationRejected":     812,
-		"DebotNoCode":                813,
+		"DebotStartFailed":           DebotErrorDebotStartFailed,
+		"DebotFetchFailed":           DebotErrorDebotFetchFailed,
+		"DebotExecutionFailed":       DebotErrorDebotExecutionFailed,
+		"DebotInvalidHandle":         DebotErrorDebotInvalidHandle,
+		"DebotInvalidJsonParams":     DebotErrorDebotInvalidJsonParams,
+		"DebotInvalidFunctionId":     DebotErrorDebotInvalidFunctionId,
+		"DebotInvalidAbi":            DebotErrorDebotInvalidAbi,
+		"DebotGetMethodFailed":       DebotErrorDebotGetMethodFailed,
+		"DebotInvalidMsg":            DebotErrorDebotInvalidMsg,
+		"DebotExternalCallFailed":    DebotErrorDebotExternalCallFailed,
+		"DebotBrowserCallbackFailed": DebotErrorDebotBrowserCallbackFailed,
+		"DebotOperationRejected":     DebotErrorDebotOperationRejected,
+		"DebotNoCode":                DebotErrorDebotNoCode,
 	}
 }
@@ -0,0 +1,344 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrAppObjectTimeout - an app object did not answer within the timeout of the dispatcher.
+	ErrAppObjectTimeout = errors.New("app object timed out")
+	// ErrAppObjectUnregistered - an app request arrived after the app object was unregistered.
+	ErrAppObjectUnregistered = errors.New("app object unregistered")
+)
+
+type (
+	// AppObject - object implemented by the application which the library calls back, e.g. a signing box.
+	// New kinds of app objects are served by implementing AppObject and passing it to AppObjectDispatcher.Register.
+	AppObject interface {
+		// Request answers an app request; the result is passed to the library in AppRequestResultOk.
+		Request(ctx context.Context, requestData json.RawMessage) (interface{}, error)
+		// Notify receives an app notification.
+		Notify(ctx context.Context, data json.RawMessage) error
+	}
+
+	// AppObjectFuncs - AppObject made of functions. Requests fail if OnRequest is nil, notifications are dropped if OnNotify is nil.
+	AppObjectFuncs struct {
+		OnRequest func(ctx context.Context, requestData json.RawMessage) (interface{}, error)
+		OnNotify  func(ctx context.Context, data json.RawMessage) error
+	}
+
+	// AppObjectOption - configures an AppObjectDispatcher.
+	AppObjectOption func(*AppObjectDispatcher)
+
+	// AppObjectDispatcher - serves the app requests and notifications of the app objects registered through a gateway.
+	// Every gateway has one, see ClientGateway.AppObjects.
+	AppObjectDispatcher struct {
+		client      ClientGateway
+		concurrency int
+		timeout     time.Duration
+
+		mu      sync.Mutex
+		next    int
+		objects map[*AppObjectRegistration]struct{}
+	}
+
+	// AppObjectRegistration - app object served by a dispatcher.
+	AppObjectRegistration struct {
+		// Method - function which registered the app object, e.g. crypto.register_signing_box.
+		Method string
+		// Kind - name of the HandleKind of the app object, empty if method is not a known constructor.
+		Kind string
+		// Handle - handle of the app object in the library, 0 if Kind is empty.
+		Handle int
+
+		dispatcher   *AppObjectDispatcher
+		object       AppObject
+		seq          int
+		ctx          context.Context
+		mu           sync.Mutex
+		unregistered bool
+		done         chan struct{}
+	}
+
+	// settlement - result or error of the call which registered an app object.
+	settlement struct {
+		data json.RawMessage
+		err  error
+	}
+)
+
+// WithAppObjectConcurrency - number of callbacks of one app object which may run at once, 1 by default.
+// With 1 the callbacks of an app object run in the order the library sent them.
+func WithAppObjectConcurrency(n int) AppObjectOption {
+	return func(d *AppObjectDispatcher) {
+		if n > 0 {
+			d.concurrency = n
+		}
+	}
+}
+
+// WithAppObjectTimeout - time an app object has to answer a callback; the request is then resolved with
+// ErrAppObjectTimeout. The context passed to the app object is cancelled too. No timeout by default.
+func WithAppObjectTimeout(timeout time.Duration) AppObjectOption {
+	return func(d *AppObjectDispatcher) {
+		d.timeout = timeout
+	}
+}
+
+// NewAppObjectDispatcher creates the dispatcher of client.
+func NewAppObjectDispatcher(client ClientGateway, opts ...AppObjectOption) *AppObjectDispatcher {
+	d := &AppObjectDispatcher{
+		client:      client,
+		concurrency: 1,
+		objects:     make(map[*AppObjectRegistration]struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Register - calls method, which registers an app object in the library, and unmarshals its result into result.
+// The app requests and notifications of the object are served by object until the library finishes the object
+// or ctx is done.
+func (d *AppObjectDispatcher) Register(ctx context.Context, method string, params interface{}, object AppObject, result interface{}) (*AppObjectRegistration, error) {
+	stream, err := OpenStream(ctx, d.client, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &AppObjectRegistration{
+		Method:     method,
+		dispatcher: d,
+		object:     object,
+		ctx:        ctx,
+		done:       make(chan struct{}),
+	}
+	first := make(chan settlement, 1)
+	go d.serve(reg, stream, first)
+
+	settled, ok := <-first
+	if !ok {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: responses ended before the result", method)
+	}
+	if settled.err != nil {
+		return nil, settled.err
+	}
+	if err := json.Unmarshal(settled.data, result); err != nil {
+		reg.Unregister()
+		return nil, err
+	}
+	if kind, ok := HandleKindOf(method); ok {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(settled.data, &fields) == nil && json.Unmarshal(fields[kind.ResultField], &reg.Handle) == nil {
+			reg.Kind = kind.Name
+		}
+	}
+	d.add(reg)
+
+	return reg, nil
+}
+
+// Lookup returns the registration of the app object with handle of kind, e.g. ("signing_box", 1).
+func (d *AppObjectDispatcher) Lookup(kind string, handle int) (*AppObjectRegistration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for reg := range d.objects {
+		if reg.Kind == kind && reg.Handle == handle {
+			return reg, true
+		}
+	}
+
+	return nil, false
+}
+
+// Registrations lists the app objects being served, in the order they were registered.
+func (d *AppObjectDispatcher) Registrations() []*AppObjectRegistration {
+	d.mu.Lock()
+	registrations := make([]*AppObjectRegistration, 0, len(d.objects))
+	for reg := range d.objects {
+		registrations = append(registrations, reg)
+	}
+	d.mu.Unlock()
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].seq < registrations[j].seq
+	})
+
+	return registrations
+}
+
+func (d *AppObjectDispatcher) add(reg *AppObjectRegistration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	select {
+	case <-reg.done:
+		return
+	default:
+	}
+	if reg.isUnregistered() {
+		return
+	}
+	d.next++
+	reg.seq = d.next
+	d.objects[reg] = struct{}{}
+}
+
+func (d *AppObjectDispatcher) remove(reg *AppObjectRegistration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.objects, reg)
+}
+
+// serve reads the stream of reg until the library finishes the app object. The result or the error of the
+// registering call is passed to first, which is closed without a value if neither arrived.
+func (d *AppObjectDispatcher) serve(reg *AppObjectRegistration, stream *Stream, first chan<- settlement) {
+	var (
+		settled bool
+		wg      sync.WaitGroup
+	)
+	slots := make(chan struct{}, d.concurrency)
+	for {
+		frame, err := stream.Next(reg.ctx)
+		if err == io.EOF || reg.ctx.Err() != nil {
+			break
+		}
+		if err != nil && !settled {
+			settled = true
+			first <- settlement{err: err}
+			break
+		}
+		if err != nil {
+			d.client.ReportError(&AppObjectError{Method: reg.Method, Err: err})
+			continue
+		}
+
+		switch f := frame.(type) {
+		case Result:
+			if !settled {
+				settled = true
+				first <- settlement{data: f.Data}
+			}
+		case AppRequest, AppNotify:
+			slots <- struct{}{}
+			wg.Add(1)
+			go func(frame Frame) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				d.dispatch(reg, frame)
+			}(f)
+		}
+	}
+	stream.Close()
+	if !settled {
+		close(first)
+	}
+	wg.Wait()
+
+	d.mu.Lock()
+	close(reg.done)
+	delete(d.objects, reg)
+	d.mu.Unlock()
+}
+
+func (d *AppObjectDispatcher) dispatch(reg *AppObjectRegistration, frame Frame) {
+	switch f := frame.(type) {
+	case AppNotify:
+		ServeAppNotify(d.client, reg.Method, f.Data, func(data json.RawMessage) error {
+			if reg.isUnregistered() {
+				return nil
+			}
+			_, err := d.call(reg.ctx, func(ctx context.Context) (interface{}, error) {
+				return nil, reg.object.Notify(ctx, data)
+			})
+			return err
+		})
+	case AppRequest:
+		ResolveAppObject(d.client, reg.Method, f, func(requestData json.RawMessage) (interface{}, error) {
+			if reg.isUnregistered() {
+				return nil, ErrAppObjectUnregistered
+			}
+			return d.call(reg.ctx, func(ctx context.Context) (interface{}, error) {
+				return reg.object.Request(ctx, requestData)
+			})
+		})
+	}
+}
+
+// call runs a callback of an app object within the timeout of the dispatcher.
+// A callback which outlives the timeout is left running; its answer is dropped.
+func (d *AppObjectDispatcher) call(ctx context.Context, callback func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if d.timeout <= 0 {
+		return callback(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	type answer struct {
+		result interface{}
+		err    error
+	}
+	answers := make(chan answer, 1)
+	go func() {
+		result, err := callAppObject(func() (interface{}, error) {
+			return callback(ctx)
+		})
+		answers <- answer{result: result, err: err}
+	}()
+
+	select {
+	case a := <-answers:
+		return a.result, a.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w after %v", ErrAppObjectTimeout, d.timeout)
+	}
+}
+
+// Unregister stops serving the app object: later app requests are resolved with ErrAppObjectUnregistered and
+// notifications are dropped. The object stays in the library until it is removed, e.g. by crypto.remove_signing_box.
+func (r *AppObjectRegistration) Unregister() {
+	r.mu.Lock()
+	r.unregistered = true
+	r.mu.Unlock()
+	r.dispatcher.remove(r)
+}
+
+// Done is closed when the library finished the app object or the context it was registered with is done.
+func (r *AppObjectRegistration) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *AppObjectRegistration) isUnregistered() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.unregistered
+}
+
+// Request calls OnRequest.
+func (f AppObjectFuncs) Request(ctx context.Context, requestData json.RawMessage) (interface{}, error) {
+	if f.OnRequest == nil {
+		return nil, errors.New("app object does not answer requests")
+	}
+
+	return f.OnRequest(ctx, requestData)
+}
+
+// Notify calls OnNotify.
+func (f AppObjectFuncs) Notify(ctx context.Context, data json.RawMessage) error {
+	if f.OnNotify == nil {
+		return nil
+	}
+
+	return f.OnNotify(ctx, data)
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// appObjectGateway - gateway whose app object stream is fed by the test.
+type appObjectGateway struct {
+	BaseGateway
+	stream   chan *ClientResponse
+	resolved chan *ParamsOfResolveAppRequest
+
+	mu       sync.Mutex
+	reported []error
+}
+
+func newAppObjectGateway(opts ...AppObjectOption) *appObjectGateway {
+	gw := &appObjectGateway{
+		stream:   make(chan *ClientResponse, 10),
+		resolved: make(chan *ParamsOfResolveAppRequest, 10),
+	}
+	gw.Requester = func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+		if method != "client.resolve_app_request" {
+			return gw.stream, nil
+		}
+		gw.resolved <- paramIn.(*ParamsOfResolveAppRequest)
+		out := make(chan *ClientResponse, 1)
+		out <- &ClientResponse{Code: 0, Data: []byte("{}")}
+		close(out)
+		return out, nil
+	}
+	gw.ErrorHandler = func(err error) {
+		gw.mu.Lock()
+		defer gw.mu.Unlock()
+		gw.reported = append(gw.reported, err)
+	}
+	gw.Dispatcher = NewAppObjectDispatcher(gw, opts...)
+
+	return gw
+}
+
+func (gw *appObjectGateway) Destroy() {}
+
+func (gw *appObjectGateway) Close(ctx context.Context) error {
+	return nil
+}
+
+func (gw *appObjectGateway) appRequest(id int) {
+	gw.stream <- &ClientResponse{Code: 3, Data: []byte(`{"app_request_id":` + strconv.Itoa(id) + `,"request_data":{}}`)}
+}
+
+func (gw *appObjectGateway) nextResolved(t *testing.T) *ParamsOfResolveAppRequest {
+	select {
+	case resolved := <-gw.resolved:
+		return resolved
+	case <-time.After(5 * time.Second):
+		t.Fatal("app request was not resolved")
+		return nil
+	}
+}
+
+func TestAppObjectDispatcher(t *testing.T) {
+	register := func(t *testing.T, gw *appObjectGateway, object AppObject) *AppObjectRegistration {
+		gw.stream <- &ClientResponse{Code: 0, Data: []byte(`{"handle":5}`)}
+		result := new(RegisteredSigningBox)
+		reg, err := gw.AppObjects().Register(context.Background(), "crypto.register_signing_box", nil, object, result)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, SigningBoxHandle(5), result.Handle)
+		return reg
+	}
+
+	t.Run("TestRegister", func(t *testing.T) {
+		gw := newAppObjectGateway()
+		var notified int32
+		reg := register(t, gw, AppObjectFuncs{
+			OnRequest: func(ctx context.Context, requestData json.RawMessage) (interface{}, error) {
+				return "key", nil
+			},
+			OnNotify: func(ctx context.Context, data json.RawMessage) error {
+				atomic.AddInt32(&notified, 1)
+				return nil
+			},
+		})
+		assert.Equal(t, "signing_box", reg.Kind)
+		assert.Equal(t, 5, reg.Handle)
+		found, ok := gw.AppObjects().Lookup("signing_box", 5)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, reg, found)
+
+		gw.stream <- &ClientResponse{Code: 4, Data: []byte(`{}`)}
+		gw.appRequest(1)
+		resolved := gw.nextResolved(t)
+		assert.Equal(t, 1, resolved.AppRequestID)
+		assert.Equal(t, AppRequestResultOk{Result: json.RawMessage(`"key"`)}, resolved.Result.ValueEnumType)
+
+		close(gw.stream)
+		select {
+		case <-reg.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("registration is not done after the stream finished")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&notified))
+		assert.Equal(t, 0, len(gw.AppObjects().Registrations()))
+	})
+
+	t.Run("TestRegisterError", func(t *testing.T) {
+		gw := newAppObjectGateway()
+		gw.stream <- &ClientResponse{Code: 1, Error: &ClientError{Code: ClientErrorInvalidParams}}
+		close(gw.stream)
+		_, err := gw.AppObjects().Register(context.Background(), "crypto.register_signing_box", nil, AppObjectFuncs{}, new(RegisteredSigningBox))
+		assert.Equal(t, true, errors.Is(err, ErrInvalidParams))
+		assert.Equal(t, 0, len(gw.AppObjects().Registrations()))
+	})
+
+	t.Run("TestConcurrency", func(t *testing.T) {
+		gw := newAppObjectGateway(WithAppObjectConcurrency(2))
+		var running, peak int32
+		release := make(chan struct{})
+		register(t, gw, AppObjectFuncs{
+			OnRequest: func(ctx context.Context, requestData json.RawMessage) (interface{}, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				<-release
+				atomic.AddInt32(&running, -1)
+				return nil, nil
+			},
+		})
+		for id := 1; id <= 4; id++ {
+			gw.appRequest(id)
+		}
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		for i := 0; i < 4; i++ {
+			gw.nextResolved(t)
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&peak))
+		close(gw.stream)
+	})
+
+	t.Run("TestTimeout", func(t *testing.T) {
+		gw := newAppObjectGateway(WithAppObjectTimeout(50 * time.Millisecond))
+		register(t, gw, AppObjectFuncs{
+			OnRequest: func(ctx context.Context, requestData json.RawMessage) (interface{}, error) {
+				<-ctx.Done()
+				time.Sleep(50 * time.Millisecond)
+				return "late", nil
+			},
+		})
+		gw.appRequest(1)
+		resolved := gw.nextResolved(t)
+		assert.Equal(t, AppRequestResultError{Text: "app object timed out after 50ms"}, resolved.Result.ValueEnumType)
+		gw.mu.Lock()
+		assert.Equal(t, 1, len(gw.reported))
+		assert.Equal(t, true, errors.Is(gw.reported[0], ErrAppObjectTimeout))
+		gw.mu.Unlock()
+		close(gw.stream)
+	})
+
+	t.Run("TestUnregister", func(t *testing.T) {
+		gw := newAppObjectGateway()
+		reg := register(t, gw, AppObjectFuncs{
+			OnRequest: func(ctx context.Context, requestData json.RawMessage) (interface{}, error) {
+				return "key", nil
+			},
+		})
+		reg.Unregister()
+		_, ok := gw.AppObjects().Lookup("signing_box", 5)
+		assert.Equal(t, false, ok)
+		gw.appRequest(1)
+		resolved := gw.nextResolved(t)
+		assert.Equal(t, AppRequestResultError{Text: ErrAppObjectUnregistered.Error()}, resolved.Result.ValueEnumType)
+		close(gw.stream)
+	})
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for errors.Is. They match any *ClientError with the same code.
+var (
+	ErrInvalidConfig          = &ClientError{Code: ClientErrorInvalidConfig}
+	ErrUnknownFunction        = &ClientError{Code: ClientErrorUnknownFunction}
+	ErrInvalidParams          = &ClientError{Code: ClientErrorInvalidParams}
+	ErrWebsocketConnect       = &ClientError{Code: ClientErrorWebsocketConnectError}
+	ErrHTTPRequestSend        = &ClientError{Code: ClientErrorHttpRequestSendError}
+	ErrQueryFailed            = &ClientError{Code: NetErrorQueryFailed}
+	ErrWaitForFailed          = &ClientError{Code: NetErrorWaitForFailed}
+	ErrWaitForTimeout         = &ClientError{Code: NetErrorWaitForTimeout}
+	ErrNetworkModuleSuspended = &ClientError{Code: NetErrorNetworkModuleSuspended}
+	ErrWebsocketDisconnected  = &ClientError{Code: NetErrorWebsocketDisconnected}
+	ErrUnauthorized           = &ClientError{Code: NetErrorUnauthorized}
+	ErrGraphqlConnection      = &ClientError{Code: NetErrorGraphqlConnectionError}
+	ErrMessageAlreadyExpired  = &ClientError{Code: ProcessingErrorMessageAlreadyExpired}
+	ErrSendMessageFailed      = &ClientError{Code: ProcessingErrorSendMessageFailed}
+	ErrMessageExpired         = &ClientError{Code: ProcessingErrorMessageExpired}
+	ErrTransactionWaitTimeout = &ClientError{Code: ProcessingErrorTransactionWaitTimeout}
+	ErrMessageRejected        = &ClientError{Code: ProcessingErrorMessageRejected}
+	ErrTransactionAborted     = &ClientError{Code: TVMErrorTransactionAborted}
+	ErrLowBalance             = &ClientError{Code: TVMErrorLowBalance}
+	ErrAccountMissing         = &ClientError{Code: TVMErrorAccountMissing}
+	ErrContractExecution      = &ClientError{Code: TVMErrorContractExecutionError}
+	ErrInvalidAbi             = &ClientError{Code: AbiErrorInvalidAbi}
+	ErrInvalidBoc             = &ClientError{Code: BocErrorInvalidBoc}
+	ErrInvalidSignature       = &ClientError{Code: CryptoErrorInvalidSignature}
+)
+
+// ErrClientClosed - matches the errors of requests to a closed gateway, see ClientClosedError.
+var ErrClientClosed = errors.New("client closed")
+
+// ClientClosedError - error of a request which was sent to a closed gateway or was still waiting when the gateway was closed.
+type ClientClosedError struct {
+	Method string
+}
+
+func (e *ClientClosedError) Error() string {
+	return e.Method + ": client closed"
+}
+
+// Is reports whether target is ErrClientClosed.
+func (e *ClientClosedError) Is(target error) bool {
+	return target == ErrClientClosed
+}
+
+// ClientErrorData - well-known fields of ClientError.Data. Every field is optional.
+type ClientErrorData struct {
+	CoreVersion    string       `json:"core_version,omitempty"`
+	ExitCode       *int         `json:"exit_code,omitempty"`
+	ExitArg        interface{}  `json:"exit_arg,omitempty"`
+	Phase          string       `json:"phase,omitempty"`
+	AccountAddress string       `json:"account_address,omitempty"`
+	MessageID      string       `json:"message_id,omitempty"`
+	TransactionID  string       `json:"transaction_id,omitempty"`
+	Description    string       `json:"description,omitempty"`
+	LocalError     *ClientError `json:"local_error,omitempty"`
+}
+
+// NewClientError decodes an SDK error payload.
+// If the payload is not a ClientError object the raw text is returned as a plain error.
+func NewClientError(raw []byte) error {
+	clientErr := &ClientError{}
+	if err := json.Unmarshal(raw, clientErr); err != nil || (clientErr.Code == 0 && clientErr.Message == "") {
+		return errors.New(string(raw))
+	}
+
+	return clientErr
+}
+
+func (cE *ClientError) Error() string {
+	return fmt.Sprintf("%s (code %d)", cE.Message, cE.Code)
+}
+
+// Is reports whether target is a *ClientError with the same code, so sentinels like ErrMessageExpired work with errors.Is.
+func (cE *ClientError) Is(target error) bool {
+	t, ok := target.(*ClientError)
+	if !ok {
+		return false
+	}
+
+	return t.Code == cE.Code
+}
+
+// Unwrap returns the local error reported by the SDK (e.g. the TVM error behind a processing error), if any.
+func (cE *ClientError) Unwrap() error {
+	if local := cE.Details().LocalError; local != nil {
+		return local
+	}
+
+	return nil
+}
+
+// Details decodes Data. Unknown or malformed data yields an empty struct.
+func (cE *ClientError) Details() ClientErrorData {
+	var data ClientErrorData
+	if len(cE.Data) != 0 {
+		_ = json.Unmarshal(cE.Data, &data)
+	}
+
+	return data
+}
+
+// Module returns the name of the SDK module which produced the error, derived from the code range.
+func (cE *ClientError) Module() string {
+	switch {
+	case cE.Code < 100:
+		return "client"
+	case cE.Code < 200:
+		return "crypto"
+	case cE.Code < 300:
+		return "boc"
+	case cE.Code < 400:
+		return "abi"
+	case cE.Code < 500:
+		return "tvm"
+	case cE.Code < 600:
+		return "processing"
+	case cE.Code < 700:
+		return "net"
+	case cE.Code < 800:
+		return "utils"
+	case cE.Code < 900:
+		return "debot"
+	default:
+		return "proofs"
+	}
+}
+
+// ErrorCode returns the code of the first *ClientError in err's chain.
+func ErrorCode(err error) (int, bool) {
+	var clientErr *ClientError
+	if !errors.As(err, &clientErr) {
+		return 0, false
+	}
+
+	return clientErr.Code, true
+}
+
+// ExitCode returns the TVM exit code reported anywhere in err's chain, including local errors.
+func ExitCode(err error) (int, bool) {
+	for err != nil {
+		var clientErr *ClientError
+		if !errors.As(err, &clientErr) {
+			return 0, false
+		}
+		details := clientErr.Details()
+		if details.ExitCode != nil {
+			return *details.ExitCode, true
+		}
+		if details.LocalError == nil {
+			return 0, false
+		}
+		err = details.LocalError
+	}
+
+	return 0, false
+}
+
+// IsNetworkError reports whether err was caused by transport or net module failures,
+// i.e. it is worth retrying an idempotent request.
+func IsNetworkError(err error) bool {
+	code, ok := ErrorCode(err)
+	if !ok {
+		return false
+	}
+	switch code {
+	case ClientErrorWebsocketConnectError,
+		ClientErrorWebsocketReceiveError,
+		ClientErrorWebsocketSendError,
+		ClientErrorHttpClientCreateError,
+		ClientErrorHttpRequestCreateError,
+		ClientErrorHttpRequestSendError,
+		ClientErrorHttpRequestParseError,
+		NetErrorQueryFailed,
+		NetErrorWaitForFailed,
+		NetErrorGetSubscriptionResultFailed,
+		NetErrorInvalidServerResponse,
+		NetErrorNetworkModuleSuspended,
+		NetErrorWebsocketDisconnected,
+		NetErrorGraphqlWebsocketInitError,
+		NetErrorNetworkModuleResumed,
+		NetErrorGraphqlConnectionError:
+		return true
+	}
+
+	return false
+}
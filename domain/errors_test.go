@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientError(t *testing.T) {
+	t.Run("TestNewClientError", func(t *testing.T) {
+		err := NewClientError([]byte(`{"code":507,"message":"Message expired","data":{"message_id":"abc","core_version":"1.40.0"}}`))
+		var clientErr *ClientError
+		assert.True(t, errors.As(err, &clientErr))
+		assert.Equal(t, ProcessingErrorMessageExpired, clientErr.Code)
+		assert.Equal(t, "abc", clientErr.Details().MessageID)
+		assert.Equal(t, "processing", clientErr.Module())
+		assert.True(t, errors.Is(err, ErrMessageExpired))
+		assert.False(t, errors.Is(err, ErrQueryFailed))
+		assert.Equal(t, "Message expired (code 507)", err.Error())
+
+		err = NewClientError([]byte("not a json"))
+		assert.False(t, errors.As(err, &clientErr))
+		assert.Equal(t, "not a json", err.Error())
+	})
+
+	t.Run("TestLocalError", func(t *testing.T) {
+		err := NewClientError([]byte(`{"code":504,"message":"Fetch block failed","data":{"local_error":{"code":414,"message":"Contract execution was terminated with error","data":{"exit_code":101,"account_address":"0:00"}}}}`))
+		err = fmt.Errorf("deploy: %w", err)
+		assert.True(t, errors.Is(err, ErrContractExecution))
+		code, ok := ErrorCode(err)
+		assert.True(t, ok)
+		assert.Equal(t, ProcessingErrorFetchBlockFailed, code)
+		exitCode, ok := ExitCode(err)
+		assert.True(t, ok)
+		assert.Equal(t, 101, exitCode)
+
+		_, ok = ExitCode(errors.New("plain"))
+		assert.False(t, ok)
+	})
+
+	t.Run("TestIsNetworkError", func(t *testing.T) {
+		assert.True(t, IsNetworkError(NewClientError([]byte(`{"code":610,"message":"Websocket disconnected"}`))))
+		assert.False(t, IsNetworkError(NewClientError([]byte(`{"code":507,"message":"Message expired"}`))))
+		assert.False(t, IsNetworkError(errors.New("plain")))
+	})
+
+	t.Run("TestErrorCodeMaps", func(t *testing.T) {
+		assert.Equal(t, ProcessingErrorMessageExpired, ProcessingErrorCode["MessageExpired"])
+		assert.Equal(t, TVMErrorContractExecutionError, TVMErrorCode["ContractExecutionError"])
+		assert.Equal(t, AbiErrorInvalidAbi, AbiErrorCode["InvalidAbi"])
+		assert.Equal(t, ProofsErrorInvalidData, ProofsErrorCode["InvalidData"])
+	})
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type (
+	// RequestFunc - sends a request and returns the stream of its responses.
+	RequestFunc func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error)
+
+	// BaseGateway implements every ClientGateway method except Destroy and Close on top of Requester.
+	// Gateways that are not backed by the linked library embed it and provide the transport only.
+	// Capabilities caches the API reference for Supports; the embedding gateway creates it over itself.
+	// ErrorHandler receives the errors of ReportError, DefaultErrorHandler if nil.
+	// Dispatcher serves the app objects registered through the gateway; the embedding gateway creates it over itself.
+	BaseGateway struct {
+		Requester    RequestFunc
+		Capabilities *Capabilities
+		ErrorHandler ErrorHandler
+		Dispatcher   *AppObjectDispatcher
+	}
+
+	// StreamHandler - receives the responses of a streamed call: events (100), app requests (3),
+	// app notifications (4) and the result (0). App requests must be answered with ResolveAppRequest.
+	StreamHandler func(code uint32, data json.RawMessage)
+)
+
+// ReadResponse - collects a response stream into its result payload or its first error.
+// Returns ctx.Err() if the stream was closed by the cancelled context before the result arrived.
+func ReadResponse(ctx context.Context, responses <-chan *ClientResponse) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	for r := range responses {
+		if r.Error != nil && err == nil {
+			err = r.Error
+		}
+		if r.Data != nil && data == nil && r.Code == 0 {
+			data = r.Data
+		}
+	}
+	if data == nil && err == nil {
+		err = ctx.Err()
+	}
+
+	return data, err
+}
+
+// ReadStream - passes every response to handler until the stream is closed and returns the result.
+// Streams which stay open after the result (subscriptions, app objects) end when ctx is done;
+// the result received before that is returned without error. The responses after an error are drained
+// in the background, so the gateway is not blocked by an unread stream.
+func ReadStream(ctx context.Context, responses <-chan *ClientResponse, handler StreamHandler) (json.RawMessage, error) {
+	var result json.RawMessage
+	for r := range responses {
+		if r.Error != nil {
+			go func() {
+				for range responses {
+				}
+			}()
+			return result, r.Error
+		}
+		if r.Code == 0 && result == nil {
+			result = r.Data
+		}
+		if handler != nil {
+			handler(r.Code, r.Data)
+		}
+	}
+	if result == nil {
+		return nil, ctx.Err()
+	}
+
+	return result, nil
+}
+
+// Request ...
+func (b BaseGateway) Request(method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+	return b.Requester(context.Background(), method, paramIn)
+}
+
+// RequestContext ...
+func (b BaseGateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+	return b.Requester(ctx, method, paramIn)
+}
+
+// GetResponse ...
+func (b BaseGateway) GetResponse(method string, paramIn interface{}) ([]byte, error) {
+	return b.GetResponseContext(context.Background(), method, paramIn)
+}
+
+// GetResponseContext ...
+func (b BaseGateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	responses, err := b.Requester(ctx, method, paramIn)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadResponse(ctx, responses)
+}
+
+// GetResult ...
+func (b BaseGateway) GetResult(method string, paramIn interface{}, resultStruct interface{}) error {
+	return b.GetResultContext(context.Background(), method, paramIn, resultStruct)
+}
+
+// GetResultContext ...
+func (b BaseGateway) GetResultContext(ctx context.Context, method string, paramIn interface{}, resultStruct interface{}) error {
+	rawData, err := b.GetResponseContext(ctx, method, paramIn)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawData, resultStruct)
+}
+
+// GetAPIReference - Returns Core Library API reference.
+func (b BaseGateway) GetAPIReference() (*ResultOfGetAPIReference, error) {
+	result := new(ResultOfGetAPIReference)
+	err := b.GetResult("client.get_api_reference", nil, result)
+	return result, err
+}
+
+// Version - Returns Core Library version.
+func (b BaseGateway) Version() (*ResultOfVersion, error) {
+	result := new(ResultOfVersion)
+	err := b.GetResult("client.version", nil, result)
+	return result, err
+}
+
+// Config - Returns Core Library API reference.
+func (b BaseGateway) Config() (*ClientConfig, error) {
+	result := new(ClientConfig)
+	err := b.GetResult("client.config", nil, result)
+	return result, err
+}
+
+// GetBuildInfo - Returns detailed information about this build.
+func (b BaseGateway) GetBuildInfo() (*ResultOfBuildInfo, error) {
+	result := new(ResultOfBuildInfo)
+	err := b.GetResult("client.build_info", nil, result)
+	return result, err
+}
+
+// ResolveAppRequest - Resolves application request processing result.
+func (b BaseGateway) ResolveAppRequest(pORAR *ParamsOfResolveAppRequest) error {
+	_, err := b.GetResponse("client.resolve_app_request", pORAR)
+	return err
+}
+
+// Supports reports whether the library has method, see Capabilities. Without Capabilities every function
+// is reported as supported, as when the API reference cannot be loaded.
+func (b BaseGateway) Supports(method string) bool {
+	if b.Capabilities == nil {
+		return true
+	}
+
+	return b.Capabilities.Supports(method)
+}
+
+// ReportError passes err to ErrorHandler.
+func (b BaseGateway) ReportError(err error) {
+	if b.ErrorHandler == nil {
+		DefaultErrorHandler(err)
+		return
+	}
+	b.ErrorHandler(err)
+}
+
+// AppObjects returns Dispatcher.
+func (b BaseGateway) AppObjects() *AppObjectDispatcher {
+	return b.Dispatcher
+}
+
+// Call - calls any SDK function and returns its raw result, e.g. before it has a typed wrapper.
+func (b BaseGateway) Call(method string, params interface{}) (json.RawMessage, error) {
+	return b.CallContext(context.Background(), method, params)
+}
+
+// CallContext - Call bounded by ctx.
+func (b BaseGateway) CallContext(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	return b.GetResponseContext(ctx, method, params)
+}
+
+// CallStream - streaming counterpart of Call, see ReadStream.
+func (b BaseGateway) CallStream(ctx context.Context, method string, params interface{}, handler StreamHandler) (json.RawMessage, error) {
+	responses, err := b.Requester(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadStream(ctx, responses, handler)
+}
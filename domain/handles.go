@@ -0,0 +1,124 @@
+package domain
+
+type (
+	// HandleKind - kind of stateful object which lives inside one client context and is referred to by a handle.
+	// Requests which use such a handle must be sent to the context which created it.
+	HandleKind struct {
+		Name string
+		// Constructors - functions whose result field ResultField holds a new handle.
+		Constructors []string
+		ResultField  string
+		// ParamFields - params fields holding a handle of the kind in any function, at any depth.
+		ParamFields []string
+		// HandleIn - functions whose "handle" params field holds a handle of the kind.
+		HandleIn []string
+		// TaggedAs - "type" of tagged params objects (e.g. Signer) whose "handle" field holds a handle of the kind.
+		TaggedAs []string
+		// Release - function which frees a handle, called with {"handle": handle} or {ReleaseField: handle}.
+		Release      string
+		ReleaseField string
+	}
+)
+
+// HandleKinds - stateful objects of the SDK, except boc cache references which are content addressed.
+var HandleKinds = []*HandleKind{
+	{
+		Name:         "signing_box",
+		Constructors: []string{"crypto.get_signing_box", "crypto.register_signing_box", "crypto.get_signing_box_from_crypto_box"},
+		ResultField:  "handle",
+		ParamFields:  []string{"signing_box"},
+		HandleIn:     []string{"crypto.signing_box_get_public_key", "crypto.remove_signing_box"},
+		TaggedAs:     []string{"SigningBox"},
+		Release:      "crypto.remove_signing_box",
+		ReleaseField: "handle",
+	},
+	{
+		Name:         "encryption_box",
+		Constructors: []string{"crypto.register_encryption_box", "crypto.create_encryption_box", "crypto.get_encryption_box_from_crypto_box"},
+		ResultField:  "handle",
+		ParamFields:  []string{"encryption_box"},
+		HandleIn:     []string{"crypto.remove_encryption_box"},
+		Release:      "crypto.remove_encryption_box",
+		ReleaseField: "handle",
+	},
+	{
+		Name:         "crypto_box",
+		Constructors: []string{"crypto.create_crypto_box"},
+		ResultField:  "handle",
+		HandleIn: []string{
+			"crypto.remove_crypto_box",
+			"crypto.get_crypto_box_info",
+			"crypto.get_crypto_box_seed_phrase",
+			"crypto.get_signing_box_from_crypto_box",
+			"crypto.get_encryption_box_from_crypto_box",
+			"crypto.clear_crypto_box_secret_cache",
+		},
+		Release:      "crypto.remove_crypto_box",
+		ReleaseField: "handle",
+	},
+	{
+		Name:         "subscription",
+		Constructors: []string{"net.subscribe_collection", "net.subscribe"},
+		ResultField:  "handle",
+		HandleIn:     []string{"net.unsubscribe"},
+		Release:      "net.unsubscribe",
+		ReleaseField: "handle",
+	},
+	{
+		Name:         "iterator",
+		Constructors: []string{"net.create_block_iterator", "net.resume_block_iterator", "net.create_transaction_iterator", "net.resume_transaction_iterator"},
+		ResultField:  "handle",
+		ParamFields:  []string{"iterator"},
+		HandleIn:     []string{"net.remove_iterator"},
+		Release:      "net.remove_iterator",
+		ReleaseField: "handle",
+	},
+	{
+		Name:         "debot",
+		Constructors: []string{"debot.init"},
+		ResultField:  "debot_handle",
+		ParamFields:  []string{"debot_handle"},
+		Release:      "debot.remove",
+		ReleaseField: "debot_handle",
+	},
+}
+
+// HandleKindOf returns the kind of the handles created by method, if it is a constructor.
+func HandleKindOf(method string) (*HandleKind, bool) {
+	for _, kind := range HandleKinds {
+		for _, constructor := range kind.Constructors {
+			if constructor == method {
+				return kind, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// ParamKind returns the kind of the handle held by params field of method, if any.
+// tag is the "type" of the object holding the field, if it is tagged.
+func ParamKind(method, field, tag string) (*HandleKind, bool) {
+	for _, kind := range HandleKinds {
+		for _, name := range kind.ParamFields {
+			if name == field {
+				return kind, true
+			}
+		}
+		if field != "handle" {
+			continue
+		}
+		for _, name := range kind.TaggedAs {
+			if name == tag {
+				return kind, true
+			}
+		}
+		for _, name := range kind.HandleIn {
+			if name == method && tag == "" {
+				return kind, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// maxCallCodes - response codes kept in CallInfo.Codes.
+const maxCallCodes = 64
+
+// RedactedValue replaces secret values in observed params and responses.
+const RedactedValue = "[REDACTED]"
+
+// secretFields - JSON fields whose string values are never shown to observers:
+// key pair secrets, mnemonic phrases, scrypt passwords, extended private keys, symmetric keys and access keys.
+var secretFields = map[string]bool{
+	"secret":     true,
+	"phrase":     true,
+	"password":   true,
+	"xprv":       true,
+	"key":        true,
+	"signkey":    true,
+	"access_key": true,
+}
+
+type (
+	// Interceptor wraps a gateway call. It must call next (possibly with other arguments) and return its stream,
+	// or a stream of its own which is closed once next's stream is closed.
+	Interceptor func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error)
+
+	// CallInfo - what an observer sees of a gateway call. Secrets in Params are redacted.
+	CallInfo struct {
+		Method string
+		Params json.RawMessage
+		Start  time.Time
+		// Duration, Codes and Err are complete in OnFinish. Codes keeps the first maxCallCodes response codes,
+		// so long subscriptions do not grow it.
+		Duration time.Duration
+		Codes    []uint32
+		Err      error
+	}
+
+	// CallObserver - callbacks of Observe. All are optional.
+	CallObserver struct {
+		// OnStart is called before the call is passed on; Duration, Codes and Err are not set yet.
+		OnStart func(ctx context.Context, call *CallInfo)
+		// OnResponse is called for every response of the call: result (0), error (1), app request (3),
+		// app notification (4) and event (100). Secrets in response.Data are redacted.
+		OnResponse func(ctx context.Context, call *CallInfo, response *ClientResponse)
+		// OnFinish is called once, after the last response or when the call failed to start.
+		OnFinish func(ctx context.Context, call *CallInfo)
+	}
+)
+
+// ChainInterceptors - wraps requester with interceptors. The first interceptor is the outermost one.
+func ChainInterceptors(requester RequestFunc, interceptors ...Interceptor) RequestFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], requester
+		requester = func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			return interceptor(ctx, method, paramIn, next)
+		}
+	}
+
+	return requester
+}
+
+// Observe - interceptor which reports every call and its responses to observer without changing them.
+func Observe(observer CallObserver) Interceptor {
+	return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+		call := &CallInfo{Method: method, Start: time.Now()}
+		if paramIn != nil {
+			if raw, err := json.Marshal(paramIn); err == nil {
+				call.Params = RedactSecrets(raw)
+			}
+		}
+		if observer.OnStart != nil {
+			observer.OnStart(ctx, call)
+		}
+
+		responses, err := next(ctx, method, paramIn)
+		if err != nil {
+			call.Duration = time.Since(call.Start)
+			call.Err = err
+			if observer.OnFinish != nil {
+				observer.OnFinish(ctx, call)
+			}
+			return nil, err
+		}
+
+		return watch(ctx, responses, func(r *ClientResponse) {
+			if len(call.Codes) < maxCallCodes {
+				call.Codes = append(call.Codes, r.Code)
+			}
+			if r.Error != nil && call.Err == nil {
+				call.Err = r.Error
+			}
+			if observer.OnResponse != nil {
+				observed := *r
+				observed.Data = RedactSecrets(r.Data)
+				observer.OnResponse(ctx, call, &observed)
+			}
+		}, func(err error) {
+			call.Duration = time.Since(call.Start)
+			if call.Err == nil {
+				call.Err = err
+			}
+			if observer.OnFinish != nil {
+				observer.OnFinish(ctx, call)
+			}
+		}), nil
+	}
+}
+
+// watch passes responses on through the returned stream. It calls onResponse for every response and onFinish once,
+// after the last one, with the first error of the call, or ctx.Err() if the call ended without a result.
+// Interceptors which only follow the progress of calls use it instead of Observe, which marshals and redacts.
+func watch(ctx context.Context, responses <-chan *ClientResponse, onResponse func(*ClientResponse), onFinish func(error)) <-chan *ClientResponse {
+	out := make(chan *ClientResponse, 1)
+	go func() {
+		var (
+			result bool
+			err    error
+		)
+		defer close(out)
+		defer func() {
+			if err == nil && !result {
+				err = ctx.Err()
+			}
+			onFinish(err)
+		}()
+		for r := range responses {
+			result = result || r.Code == ResponseResult || r.Code == ResponseError
+			if r.Error != nil && err == nil {
+				err = r.Error
+			}
+			onResponse(r)
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				// Nobody reads out any more; let the stream finish without blocking the gateway.
+				for range responses {
+				}
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// RedactSecrets - returns a copy of the JSON document raw with string values of secret fields replaced by RedactedValue.
+// Documents that are not JSON objects or arrays are returned unchanged.
+func RedactSecrets(raw []byte) json.RawMessage {
+	if len(raw) == 0 || (raw[0] != '{' && raw[0] != '[') {
+		return raw
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return raw
+	}
+	if !redact(doc) {
+		return raw
+	}
+	redacted, err := json.Marshal(doc)
+	if err != nil {
+		return raw
+	}
+
+	return redacted
+}
+
+func redact(doc interface{}) bool {
+	changed := false
+	switch value := doc.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if _, isString := v.(string); isString && secretFields[k] {
+				value[k] = RedactedValue
+				changed = true
+				continue
+			}
+			changed = redact(v) || changed
+		}
+	case []interface{}:
+		for _, v := range value {
+			changed = redact(v) || changed
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptors(t *testing.T) {
+	stream := func(responses ...*ClientResponse) RequestFunc {
+		return func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			out := make(chan *ClientResponse, len(responses))
+			for _, r := range responses {
+				out <- r
+			}
+			close(out)
+			return out, nil
+		}
+	}
+
+	t.Run("TestChainOrder", func(t *testing.T) {
+		var order []string
+		tag := func(name string) Interceptor {
+			return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+				order = append(order, name)
+				return next(ctx, method+"."+name, paramIn)
+			}
+		}
+		var seen string
+		inner := func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			seen = method
+			return stream()(ctx, method, paramIn)
+		}
+		_, err := ChainInterceptors(inner, tag("a"), tag("b"))(context.Background(), "m", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"a", "b"}, order)
+		assert.Equal(t, "m.a.b", seen)
+	})
+
+	t.Run("TestObserve", func(t *testing.T) {
+		var (
+			observed []*ClientResponse
+			finished *CallInfo
+		)
+		requester := ChainInterceptors(stream(
+			&ClientResponse{Code: 100, Data: []byte(`{"type":"WillSend"}`)},
+			&ClientResponse{Code: 3, Data: []byte(`{"app_request_id":1,"request_data":{"type":"Sign","secret":"s1"}}`)},
+			&ClientResponse{Code: 0, Data: []byte(`{"keys":{"public":"p","secret":"s2"}}`)},
+		), Observe(CallObserver{
+			OnResponse: func(ctx context.Context, call *CallInfo, r *ClientResponse) {
+				observed = append(observed, r)
+			},
+			OnFinish: func(ctx context.Context, call *CallInfo) {
+				finished = call
+			},
+		}))
+
+		responses, err := requester(context.Background(), "crypto.nacl_sign", &KeyPair{Public: "p", Secret: "s0"})
+		assert.Equal(t, nil, err)
+		data, err := ReadResponse(context.Background(), responses)
+		assert.Equal(t, nil, err)
+		assert.Contains(t, string(data), `"secret":"s2"`)
+
+		assert.Equal(t, 3, len(observed))
+		assert.Contains(t, string(observed[1].Data), `"secret":"[REDACTED]"`)
+		assert.NotContains(t, string(observed[2].Data), "s2")
+		assert.Equal(t, "crypto.nacl_sign", finished.Method)
+		assert.Equal(t, `{"public":"p","secret":"[REDACTED]"}`, string(finished.Params))
+		assert.Equal(t, []uint32{100, 3, 0}, finished.Codes)
+		assert.Equal(t, nil, finished.Err)
+	})
+
+	t.Run("TestObserveError", func(t *testing.T) {
+		var finished *CallInfo
+		requester := ChainInterceptors(stream(&ClientResponse{Code: 1, Error: ErrInvalidParams}), Observe(CallObserver{
+			OnFinish: func(ctx context.Context, call *CallInfo) {
+				finished = call
+			},
+		}))
+		_, err := ReadResponse(context.Background(), mustRequest(t, requester))
+		assert.True(t, errors.Is(err, ErrInvalidParams))
+		assert.True(t, errors.Is(finished.Err, ErrInvalidParams))
+	})
+
+	t.Run("TestObserveLongStream", func(t *testing.T) {
+		events := make([]*ClientResponse, 0, 2*maxCallCodes+1)
+		for i := 0; i < 2*maxCallCodes; i++ {
+			events = append(events, &ClientResponse{Code: 100, Data: []byte(`{}`)})
+		}
+		events = append(events, &ClientResponse{Code: 0, Data: []byte(`{}`)})
+		var finished *CallInfo
+		requester := ChainInterceptors(stream(events...), Observe(CallObserver{
+			OnFinish: func(ctx context.Context, call *CallInfo) {
+				finished = call
+			},
+		}))
+		_, err := ReadResponse(context.Background(), mustRequest(t, requester))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, maxCallCodes, len(finished.Codes))
+		// The result past the kept codes still counts.
+		assert.Equal(t, nil, finished.Err)
+	})
+
+	t.Run("TestRedactSecrets", func(t *testing.T) {
+		assert.Equal(t, `[{"phrase":"[REDACTED]","word_count":12}]`, string(RedactSecrets([]byte(`[{"phrase":"a b c","word_count":12}]`))))
+		assert.Equal(t, `{"key":1}`, string(RedactSecrets([]byte(`{"key":1}`))))
+		assert.Equal(t, `"secret"`, string(RedactSecrets([]byte(`"secret"`))))
+	})
+}
+
+func mustRequest(t *testing.T, requester RequestFunc) <-chan *ClientResponse {
+	responses, err := requester(context.Background(), "m", nil)
+	assert.Equal(t, nil, err)
+	return responses
+}
@@ -0,0 +1,244 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited - the queue of a RateLimit is full, see RateLimit.MaxQueue.
+var ErrRateLimited = errors.New("rate limited")
+
+type (
+	// RateLimit - limits the calls of the SDK functions matching Pattern: "net.query_collection",
+	// "net.*" for a module or "*" for every function. Zero fields do not limit.
+	RateLimit struct {
+		Pattern string
+		// Rate - calls per second, refilled into a bucket of Burst tokens, max(1, Rate) if Burst is 0.
+		Rate  float64
+		Burst int
+		// MaxInFlight - calls waiting for their result at once.
+		MaxInFlight int
+		// MaxQueue - calls waiting for a token or an in-flight slot; more calls fail with ErrRateLimited.
+		MaxQueue int
+	}
+
+	// LimiterStats - counters of a RateLimit.
+	LimiterStats struct {
+		Pattern  string
+		InFlight int
+		Queued   int
+		// Allowed - calls passed on, Rejected - calls failed with ErrRateLimited,
+		// Canceled - calls whose context was done while they waited.
+		Allowed  uint64
+		Rejected uint64
+		Canceled uint64
+		// Waited - total time the allowed calls spent in the queue.
+		Waited time.Duration
+	}
+
+	// Limiter - client-side rate and concurrency limits of SDK functions, safe for concurrent use.
+	// Functions matching no limit are not limited, so local functions such as crypto.*, abi.* and boc.*
+	// are only limited if configured. A call is limited by the first matching limit.
+	// client.resolve_app_request is never limited, it answers calls which are already in flight.
+	Limiter struct {
+		limits []*limit
+	}
+
+	limit struct {
+		RateLimit
+		slots chan struct{}
+
+		mu     sync.Mutex
+		tokens float64
+		last   time.Time
+		stats  LimiterStats
+	}
+)
+
+// NewLimiter creates a limiter of limits. Rates and sizes must not be negative.
+func NewLimiter(limits ...RateLimit) (*Limiter, error) {
+	l := &Limiter{}
+	for _, rateLimit := range limits {
+		if rateLimit.Pattern == "" {
+			return nil, fmt.Errorf("rate limit has no pattern")
+		}
+		if rateLimit.Rate < 0 || rateLimit.Burst < 0 || rateLimit.MaxInFlight < 0 || rateLimit.MaxQueue < 0 {
+			return nil, fmt.Errorf("rate limit %q: negative value", rateLimit.Pattern)
+		}
+		if rateLimit.Burst == 0 {
+			rateLimit.Burst = 1
+			if rateLimit.Rate > 1 {
+				rateLimit.Burst = int(rateLimit.Rate)
+			}
+		}
+		lim := &limit{RateLimit: rateLimit, tokens: float64(rateLimit.Burst), last: time.Now()}
+		lim.stats.Pattern = rateLimit.Pattern
+		if rateLimit.MaxInFlight > 0 {
+			lim.slots = make(chan struct{}, rateLimit.MaxInFlight)
+		}
+		l.limits = append(l.limits, lim)
+	}
+
+	return l, nil
+}
+
+// Interceptor - interceptor which holds the calls until their limit lets them through. The in-flight slot
+// of a call is freed with its result; subscriptions and app objects do not hold it after that.
+func (l *Limiter) Interceptor() Interceptor {
+	return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+		release, err := l.Acquire(ctx, method)
+		if err != nil {
+			return nil, err
+		}
+
+		var once sync.Once
+		done := func() { once.Do(release) }
+		responses, err := next(ctx, method, paramIn)
+		if err != nil {
+			done()
+			return nil, err
+		}
+
+		return watch(ctx, responses, func(response *ClientResponse) {
+			if response.Code == ResponseResult || response.Code == ResponseError {
+				done()
+			}
+		}, func(error) {
+			done()
+		}), nil
+	}
+}
+
+// Acquire waits until a call of method may be sent and returns the function which ends it; release must be
+// called once. It returns ctx.Err() if ctx is done first and an error matching ErrRateLimited if the queue is full.
+func (l *Limiter) Acquire(ctx context.Context, method string) (release func(), err error) {
+	lim := l.match(method)
+	if lim == nil {
+		return func() {}, nil
+	}
+
+	release, err = lim.acquire(ctx)
+	if errors.Is(err, ErrRateLimited) {
+		err = fmt.Errorf("%s: %w", method, err)
+	}
+
+	return release, err
+}
+
+// Stats returns the counters of the limits in the order they were given.
+func (l *Limiter) Stats() []LimiterStats {
+	stats := make([]LimiterStats, len(l.limits))
+	for i, lim := range l.limits {
+		lim.mu.Lock()
+		stats[i] = lim.stats
+		lim.mu.Unlock()
+	}
+
+	return stats
+}
+
+func (l *Limiter) match(method string) *limit {
+	if method == "client.resolve_app_request" {
+		return nil
+	}
+	for _, lim := range l.limits {
+		if matchMethod(lim.Pattern, method) {
+			return lim
+		}
+	}
+
+	return nil
+}
+
+// matchMethod reports whether method matches pattern: the function itself, "module.*" or "*".
+func matchMethod(pattern, method string) bool {
+	if pattern == "*" || pattern == method {
+		return true
+	}
+
+	return strings.HasSuffix(pattern, ".*") && strings.HasPrefix(method, pattern[:len(pattern)-1])
+}
+
+func (lim *limit) acquire(ctx context.Context) (func(), error) {
+	lim.mu.Lock()
+	if lim.MaxQueue > 0 && lim.stats.Queued >= lim.MaxQueue {
+		lim.stats.Rejected++
+		lim.mu.Unlock()
+		return nil, ErrRateLimited
+	}
+	lim.stats.Queued++
+	lim.mu.Unlock()
+
+	start := time.Now()
+	err := lim.wait(ctx)
+
+	lim.mu.Lock()
+	defer lim.mu.Unlock()
+	lim.stats.Queued--
+	if err != nil {
+		lim.stats.Canceled++
+		return nil, err
+	}
+	lim.stats.Allowed++
+	lim.stats.InFlight++
+	lim.stats.Waited += time.Since(start)
+
+	return lim.release, nil
+}
+
+// wait takes an in-flight slot and then a token, giving the slot back if ctx is done before the token is due.
+func (lim *limit) wait(ctx context.Context) error {
+	if lim.slots != nil {
+		select {
+		case lim.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if lim.Rate == 0 {
+		return nil
+	}
+
+	lim.mu.Lock()
+	now := time.Now()
+	lim.tokens += now.Sub(lim.last).Seconds() * lim.Rate
+	if lim.tokens > float64(lim.Burst) {
+		lim.tokens = float64(lim.Burst)
+	}
+	lim.last = now
+	// The token is reserved now; the call waits until the bucket would have refilled it.
+	lim.tokens--
+	delay := time.Duration(-lim.tokens / lim.Rate * float64(time.Second))
+	lim.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		lim.mu.Lock()
+		lim.tokens++
+		lim.mu.Unlock()
+		if lim.slots != nil {
+			<-lim.slots
+		}
+		return ctx.Err()
+	}
+}
+
+func (lim *limit) release() {
+	lim.mu.Lock()
+	lim.stats.InFlight--
+	lim.mu.Unlock()
+	if lim.slots != nil {
+		<-lim.slots
+	}
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// marshalCounter counts how many times it is marshaled.
+type marshalCounter struct {
+	calls int
+}
+
+func (m *marshalCounter) MarshalJSON() ([]byte, error) {
+	m.calls++
+	return []byte(`{}`), nil
+}
+
+func TestLimiter(t *testing.T) {
+	t.Run("TestRate", func(t *testing.T) {
+		limiter, err := NewLimiter(RateLimit{Pattern: "net.*", Rate: 20, Burst: 2})
+		assert.Equal(t, nil, err)
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			release, err := limiter.Acquire(context.Background(), "net.query_collection")
+			assert.Equal(t, nil, err)
+			release()
+		}
+		// Two calls use the burst, the other two wait 50ms each.
+		assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+		start = time.Now()
+		for i := 0; i < 100; i++ {
+			release, err := limiter.Acquire(context.Background(), "crypto.sha256")
+			assert.Equal(t, nil, err)
+			release()
+		}
+		assert.True(t, time.Since(start) < 50*time.Millisecond)
+		stats := limiter.Stats()
+		assert.Equal(t, uint64(4), stats[0].Allowed)
+		assert.Equal(t, 0, stats[0].InFlight)
+	})
+
+	t.Run("TestInFlightAndQueue", func(t *testing.T) {
+		limiter, err := NewLimiter(RateLimit{Pattern: "net.query_collection", MaxInFlight: 1, MaxQueue: 1})
+		assert.Equal(t, nil, err)
+		release, err := limiter.Acquire(context.Background(), "net.query_collection")
+		assert.Equal(t, nil, err)
+
+		acquired := make(chan func())
+		go func() {
+			release, err := limiter.Acquire(context.Background(), "net.query_collection")
+			assert.Equal(t, nil, err)
+			acquired <- release
+		}()
+		for limiter.Stats()[0].Queued != 1 {
+			time.Sleep(time.Millisecond)
+		}
+		_, err = limiter.Acquire(context.Background(), "net.query_collection")
+		assert.True(t, errors.Is(err, ErrRateLimited))
+
+		release()
+		(<-acquired)()
+		stats := limiter.Stats()[0]
+		assert.Equal(t, uint64(2), stats.Allowed)
+		assert.Equal(t, uint64(1), stats.Rejected)
+		assert.Equal(t, 0, stats.InFlight)
+	})
+
+	t.Run("TestCanceled", func(t *testing.T) {
+		limiter, err := NewLimiter(RateLimit{Pattern: "*", Rate: 0.001})
+		assert.Equal(t, nil, err)
+		release, err := limiter.Acquire(context.Background(), "net.query")
+		assert.Equal(t, nil, err)
+		release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = limiter.Acquire(ctx, "net.query")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, uint64(1), limiter.Stats()[0].Canceled)
+
+		release, err = limiter.Acquire(context.Background(), "client.resolve_app_request")
+		assert.Equal(t, nil, err)
+		release()
+	})
+
+	t.Run("TestInterceptor", func(t *testing.T) {
+		limiter, err := NewLimiter(RateLimit{Pattern: "net.*", MaxInFlight: 1})
+		assert.Equal(t, nil, err)
+		frames := make(chan *ClientResponse, 1)
+		requester := ChainInterceptors(func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			return frames, nil
+		}, limiter.Interceptor())
+
+		params := &marshalCounter{}
+		responses, err := requester(context.Background(), "net.subscribe_collection", params)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, limiter.Stats()[0].InFlight)
+		frames <- &ClientResponse{Code: 0, Data: []byte(`{"handle":1}`)}
+		<-responses
+		// The subscription stays open but frees its slot with the result.
+		assert.Equal(t, 0, limiter.Stats()[0].InFlight)
+		close(frames)
+		for range responses {
+		}
+		// The limiter does not look at the params.
+		assert.Equal(t, 0, params.calls)
+	})
+
+	t.Run("TestInvalid", func(t *testing.T) {
+		_, err := NewLimiter(RateLimit{Rate: 1})
+		assert.NotEqual(t, nil, err)
+		_, err = NewLimiter(RateLimit{Pattern: "net.*", MaxInFlight: -1})
+		assert.NotEqual(t, nil, err)
+	})
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Levels of log records.
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+type (
+	// LogLevel - severity of a log record.
+	LogLevel int
+
+	// LogField - key/value of a log record.
+	LogField struct {
+		Key   string
+		Value interface{}
+	}
+
+	// Logger - receives the structured records of a gateway, see LoggingInterceptor. Adapters of other logging
+	// libraries implement it; NewStdLogger and NopLogger are provided.
+	Logger interface {
+		Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+	}
+
+	nopLogger struct{}
+
+	stdLogger struct {
+		logger *log.Logger
+		level  LogLevel
+	}
+)
+
+// NopLogger - discards every record.
+var NopLogger Logger = nopLogger{}
+
+// DefaultLogger - receives the records of gateways without a Logger: the errors of DefaultErrorHandler and
+// library version warnings. It writes warnings and errors to stderr; set it to NopLogger to drop them.
+var DefaultLogger Logger = NewStdLogger(nil, LogWarn)
+
+// KV - field of a log record.
+func KV(key string, value interface{}) LogField {
+	return LogField{Key: key, Value: value}
+}
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	default:
+		return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+func (nopLogger) Log(context.Context, LogLevel, string, ...LogField) {}
+
+// NewStdLogger - writes the records of level and above to logger as lines such as
+//
+//	INFO subscription opened method=net.subscribe_collection handle=1
+//
+// A nil logger writes to stderr with the flags of the standard logger.
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	return &stdLogger{logger: logger, level: level}
+}
+
+func (l *stdLogger) Log(_ context.Context, level LogLevel, msg string, fields ...LogField) {
+	if level < l.level {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for _, field := range fields {
+		b.WriteByte(' ')
+		b.WriteString(field.Key)
+		b.WriteByte('=')
+		b.WriteString(formatLogValue(field.Value))
+	}
+	_ = l.logger.Output(2, b.String())
+}
+
+// formatLogValue quotes the values which contain spaces, quotes or equal signs.
+func formatLogValue(value interface{}) string {
+	s := logValueString(value)
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+func logValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.RawMessage:
+		return string(v)
+	case []byte:
+		return string(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// LogErrors - ErrorHandler which logs the errors of app object callbacks and subscriptions at LogError,
+// see ClientGateway.ReportError.
+func LogErrors(logger Logger) ErrorHandler {
+	return func(err error) {
+		fields := []LogField{KV("error", err)}
+		var appObjectErr *AppObjectError
+		if errors.As(err, &appObjectErr) {
+			fields = append(fields, KV("method", appObjectErr.Method))
+			if appObjectErr.AppRequestID != 0 {
+				fields = append(fields, KV("app_request_id", appObjectErr.AppRequestID))
+			}
+		}
+		logger.Log(context.Background(), LogError, "error reported", fields...)
+	}
+}
+
+// LoggingInterceptor - interceptor which logs every call with secrets redacted:
+// the start and finish of requests and their frames at LogDebug, failed requests at LogWarn,
+// and the opening and closing of subscriptions at LogInfo. App requests and notifications are frames
+// which carry the app_request_id; their resolutions are client.resolve_app_request requests.
+func LoggingInterceptor(logger Logger) Interceptor {
+	return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+		var handle int
+		kind, _ := HandleKindOf(method)
+		subscription := kind != nil && kind.Name == "subscription"
+
+		return Observe(CallObserver{
+			OnStart: func(ctx context.Context, call *CallInfo) {
+				logger.Log(ctx, LogDebug, "request started", KV("method", call.Method), KV("params", call.Params))
+			},
+			OnResponse: func(ctx context.Context, call *CallInfo, response *ClientResponse) {
+				switch response.Code {
+				case ResponseResult:
+					logger.Log(ctx, LogDebug, "result", KV("method", call.Method), KV("data", json.RawMessage(response.Data)))
+					if subscription {
+						var result ResultOfSubscribeCollection
+						if err := json.Unmarshal(response.Data, &result); err == nil {
+							handle = result.Handle
+						}
+						logger.Log(ctx, LogInfo, "subscription opened", KV("method", call.Method), KV("handle", handle))
+					}
+				case ResponseError:
+					logger.Log(ctx, LogDebug, "error", KV("method", call.Method), KV("error", response.Error))
+				case ResponseAppRequest:
+					var appRequest ParamsOfAppRequest
+					_ = json.Unmarshal(response.Data, &appRequest)
+					logger.Log(ctx, LogDebug, "app request", KV("method", call.Method),
+						KV("app_request_id", appRequest.AppRequestID), KV("data", appRequest.RequestData))
+				case ResponseAppNotify:
+					logger.Log(ctx, LogDebug, "app notification", KV("method", call.Method), KV("data", json.RawMessage(response.Data)))
+				case ResponseEvent:
+					logger.Log(ctx, LogDebug, "event", KV("method", call.Method), KV("data", json.RawMessage(response.Data)))
+				}
+			},
+			OnFinish: func(ctx context.Context, call *CallInfo) {
+				fields := []LogField{KV("method", call.Method), KV("duration", call.Duration.Round(time.Microsecond))}
+				if subscription && handle != 0 {
+					fields = append(fields, KV("handle", handle))
+					if call.Err != nil {
+						fields = append(fields, KV("error", call.Err))
+					}
+					logger.Log(ctx, LogInfo, "subscription closed", fields...)
+					return
+				}
+				if call.Err != nil {
+					logger.Log(ctx, LogWarn, "request failed", append(fields, KV("error", call.Err))...)
+					return
+				}
+				logger.Log(ctx, LogDebug, "request finished", fields...)
+			},
+		})(ctx, method, paramIn, next)
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	logRecord struct {
+		level  LogLevel
+		msg    string
+		fields map[string]string
+	}
+
+	recordingLogger struct {
+		mu      sync.Mutex
+		records []logRecord
+	}
+)
+
+func (l *recordingLogger) Log(_ context.Context, level LogLevel, msg string, fields ...LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	record := logRecord{level: level, msg: msg, fields: make(map[string]string)}
+	for _, field := range fields {
+		record.fields[field.Key] = logValueString(field.Value)
+	}
+	l.records = append(l.records, record)
+}
+
+func (l *recordingLogger) find(msg string) (logRecord, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, record := range l.records {
+		if record.msg == msg {
+			return record, true
+		}
+	}
+
+	return logRecord{}, false
+}
+
+func TestLogger(t *testing.T) {
+	stream := func(responses ...*ClientResponse) RequestFunc {
+		return func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			out := make(chan *ClientResponse, len(responses))
+			for _, r := range responses {
+				out <- r
+			}
+			close(out)
+			return out, nil
+		}
+	}
+
+	t.Run("TestStdLogger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewStdLogger(log.New(&buf, "", 0), LogInfo)
+		logger.Log(context.Background(), LogDebug, "hidden")
+		logger.Log(context.Background(), LogWarn, "request failed", KV("method", "net.query"), KV("error", errors.New("no endpoint")))
+		assert.Equal(t, "WARN request failed method=net.query error=\"no endpoint\"\n", buf.String())
+		NopLogger.Log(context.Background(), LogError, "dropped")
+	})
+
+	t.Run("TestLoggingInterceptor", func(t *testing.T) {
+		logger := &recordingLogger{}
+		requester := ChainInterceptors(stream(
+			&ClientResponse{Code: 3, Data: []byte(`{"app_request_id":7,"request_data":{"type":"GetPassword","password":"p1"}}`)},
+			&ClientResponse{Code: 0, Data: []byte(`{"phrase":"p2"}`)},
+		), LoggingInterceptor(logger))
+
+		responses, err := requester(context.Background(), "crypto.mnemonic_from_random", &KeyPair{Public: "p", Secret: "s0"})
+		assert.Equal(t, nil, err)
+		data, err := ReadResponse(context.Background(), responses)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"phrase":"p2"}`, string(data))
+
+		started, ok := logger.find("request started")
+		assert.True(t, ok)
+		assert.Equal(t, LogDebug, started.level)
+		assert.Equal(t, `{"public":"p","secret":"[REDACTED]"}`, started.fields["params"])
+		appRequest, ok := logger.find("app request")
+		assert.True(t, ok)
+		assert.Equal(t, "7", appRequest.fields["app_request_id"])
+		assert.NotContains(t, appRequest.fields["data"], "p1")
+		result, _ := logger.find("result")
+		assert.NotContains(t, result.fields["data"], "p2")
+		finished, ok := logger.find("request finished")
+		assert.True(t, ok)
+		assert.Equal(t, "crypto.mnemonic_from_random", finished.fields["method"])
+	})
+
+	t.Run("TestLoggingInterceptorStdLogger", func(t *testing.T) {
+		var buf bytes.Buffer
+		requester := ChainInterceptors(stream(
+			&ClientResponse{Code: 100, Data: []byte(`{"type":"WillSend"}`)},
+			&ClientResponse{Code: 0, Data: []byte(`{"id":"m1"}`)},
+		), LoggingInterceptor(NewStdLogger(log.New(&buf, "", 0), LogDebug)))
+		responses, err := requester(context.Background(), "processing.send_message", nil)
+		assert.Equal(t, nil, err)
+		_, err = ReadResponse(context.Background(), responses)
+		assert.Equal(t, nil, err)
+
+		lines := strings.Split(buf.String(), "\n")
+		assert.Contains(t, lines, `DEBUG event method=processing.send_message data="{\"type\":\"WillSend\"}"`)
+		assert.Contains(t, lines, `DEBUG result method=processing.send_message data="{\"id\":\"m1\"}"`)
+		assert.Equal(t, `{"id":"m1"}`, logValueString([]byte(`{"id":"m1"}`)))
+	})
+
+	t.Run("TestLoggingInterceptorFailure", func(t *testing.T) {
+		logger := &recordingLogger{}
+		requester := ChainInterceptors(stream(&ClientResponse{Code: 1, Error: ErrInvalidParams}), LoggingInterceptor(logger))
+		responses, err := requester(context.Background(), "net.query", nil)
+		assert.Equal(t, nil, err)
+		_, err = ReadResponse(context.Background(), responses)
+		assert.NotEqual(t, nil, err)
+
+		failed, ok := logger.find("request failed")
+		assert.True(t, ok)
+		assert.Equal(t, LogWarn, failed.level)
+		assert.Equal(t, "net.query", failed.fields["method"])
+	})
+
+	t.Run("TestLoggingInterceptorSubscription", func(t *testing.T) {
+		logger := &recordingLogger{}
+		requester := ChainInterceptors(stream(
+			&ClientResponse{Code: 0, Data: []byte(`{"handle":5}`)},
+			&ClientResponse{Code: 100, Data: []byte(`{"result":{"id":"1"}}`)},
+		), LoggingInterceptor(logger))
+		responses, err := requester(context.Background(), "net.subscribe_collection", nil)
+		assert.Equal(t, nil, err)
+		for range responses {
+		}
+
+		opened, ok := logger.find("subscription opened")
+		assert.True(t, ok)
+		assert.Equal(t, LogInfo, opened.level)
+		assert.Equal(t, "5", opened.fields["handle"])
+		_, ok = logger.find("event")
+		assert.True(t, ok)
+		closed, ok := logger.find("subscription closed")
+		assert.True(t, ok)
+		assert.Equal(t, "5", closed.fields["handle"])
+	})
+
+	t.Run("TestLogErrors", func(t *testing.T) {
+		var buf bytes.Buffer
+		LogErrors(NewStdLogger(log.New(&buf, "", 0), LogDebug))(&AppObjectError{
+			Method:       "crypto.register_signing_box",
+			AppRequestID: 2,
+			Err:          errors.New("boom"),
+		})
+		line := buf.String()
+		assert.True(t, strings.HasPrefix(line, "ERROR error reported "))
+		assert.Contains(t, line, "method=crypto.register_signing_box app_request_id=2")
+	})
+	t.Run("TestDefaultErrorHandler", func(t *testing.T) {
+		logger := &recordingLogger{}
+		defer func(previous Logger) { DefaultLogger = previous }(DefaultLogger)
+		DefaultLogger = logger
+		DefaultErrorHandler(&AppObjectError{Method: "crypto.register_signing_box", Err: errors.New("boom")})
+
+		reported, ok := logger.find("error reported")
+		assert.True(t, ok)
+		assert.Equal(t, LogError, reported.level)
+		assert.Equal(t, "crypto.register_signing_box", reported.fields["method"])
+	})
+}
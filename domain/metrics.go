@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	// Metrics - receives the measurements of a gateway, see MetricsInterceptor. Methods are SDK functions,
+	// e.g. net.query_collection; they are called concurrently. metrics.Registry is an in-memory implementation.
+	Metrics interface {
+		// RequestStarted - a call of method was sent; it is in flight until RequestFinished.
+		RequestStarted(method string)
+		// RequestFinished - the call got its result or failed with err, e.g. a *ClientError.
+		// Subscriptions and app objects finish with their result, their streams go on.
+		RequestFinished(method string, duration time.Duration, err error)
+		// SubscriptionOpened and SubscriptionClosed - the stream of a subscription made by method started and ended.
+		SubscriptionOpened(method string)
+		SubscriptionClosed(method string)
+		// AppRequestHandled - an app request of the app object registered by method was resolved after duration,
+		// with an error if the callback failed.
+		AppRequestHandled(method string, duration time.Duration, err error)
+	}
+
+	pendingAppRequest struct {
+		call  *CallInfo
+		start time.Time
+	}
+)
+
+// MetricsInterceptor - interceptor which reports every call of the gateway to metrics. App request handling time
+// runs from the app request frame to the client.resolve_app_request call answering it.
+func MetricsInterceptor(metrics Metrics) Interceptor {
+	var (
+		mu      sync.Mutex
+		pending = make(map[int]pendingAppRequest)
+	)
+	resolved := func(params json.RawMessage) {
+		var resolve struct {
+			AppRequestID int `json:"app_request_id"`
+			Result       struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(params, &resolve); err != nil {
+			return
+		}
+		mu.Lock()
+		appRequest, ok := pending[resolve.AppRequestID]
+		delete(pending, resolve.AppRequestID)
+		mu.Unlock()
+		if !ok {
+			return
+		}
+		var err error
+		if resolve.Result.Type == "Error" {
+			err = errors.New(resolve.Result.Text)
+		}
+		metrics.AppRequestHandled(appRequest.call.Method, time.Since(appRequest.start), err)
+	}
+
+	return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+		var finished, subscribed bool
+		kind, _ := HandleKindOf(method)
+		subscription := kind != nil && kind.Name == "subscription"
+		finish := func(call *CallInfo, err error) {
+			if finished {
+				return
+			}
+			finished = true
+			metrics.RequestFinished(method, time.Since(call.Start), err)
+		}
+
+		return Observe(CallObserver{
+			OnStart: func(ctx context.Context, call *CallInfo) {
+				if method == "client.resolve_app_request" {
+					resolved(call.Params)
+				}
+				metrics.RequestStarted(method)
+			},
+			OnResponse: func(ctx context.Context, call *CallInfo, response *ClientResponse) {
+				switch response.Code {
+				case ResponseResult:
+					finish(call, nil)
+					if subscription && !subscribed {
+						subscribed = true
+						metrics.SubscriptionOpened(method)
+					}
+				case ResponseError:
+					finish(call, response.Error)
+				case ResponseAppRequest:
+					var appRequest ParamsOfAppRequest
+					if err := json.Unmarshal(response.Data, &appRequest); err == nil {
+						mu.Lock()
+						pending[appRequest.AppRequestID] = pendingAppRequest{call: call, start: time.Now()}
+						mu.Unlock()
+					}
+				}
+			},
+			OnFinish: func(ctx context.Context, call *CallInfo) {
+				finish(call, call.Err)
+				if subscribed {
+					metrics.SubscriptionClosed(method)
+				}
+				mu.Lock()
+				for id, appRequest := range pending {
+					if appRequest.call == call {
+						delete(pending, id)
+					}
+				}
+				mu.Unlock()
+			},
+		})(ctx, method, paramIn, next)
+	}
+}
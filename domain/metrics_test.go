@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingMetrics struct {
+	mu            sync.Mutex
+	started       []string
+	finished      map[string]error
+	subscriptions map[string]int
+	appRequests   map[string]error
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		finished:      make(map[string]error),
+		subscriptions: make(map[string]int),
+		appRequests:   make(map[string]error),
+	}
+}
+
+func (m *recordingMetrics) RequestStarted(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, method)
+}
+
+func (m *recordingMetrics) RequestFinished(method string, _ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished[method] = err
+}
+
+func (m *recordingMetrics) SubscriptionOpened(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[method]++
+}
+
+func (m *recordingMetrics) SubscriptionClosed(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[method]--
+}
+
+func (m *recordingMetrics) AppRequestHandled(method string, _ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.appRequests[method] = err
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	t.Run("TestRequests", func(t *testing.T) {
+		metrics := newRecordingMetrics()
+		requester := ChainInterceptors(func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			out := make(chan *ClientResponse, 1)
+			if method == "net.query" {
+				out <- &ClientResponse{Code: 1, Error: &ClientError{Code: 603, Message: "failed"}}
+			} else {
+				out <- &ClientResponse{Code: 0, Data: []byte(`{}`)}
+			}
+			close(out)
+			return out, nil
+		}, MetricsInterceptor(metrics))
+
+		for _, method := range []string{"client.version", "net.query"} {
+			responses, err := requester(context.Background(), method, nil)
+			assert.Equal(t, nil, err)
+			_, _ = ReadResponse(context.Background(), responses)
+		}
+
+		assert.Equal(t, []string{"client.version", "net.query"}, metrics.started)
+		assert.Equal(t, nil, metrics.finished["client.version"])
+		assert.Equal(t, 603, metrics.finished["net.query"].(*ClientError).Code)
+	})
+
+	t.Run("TestSubscriptionAndAppRequests", func(t *testing.T) {
+		metrics := newRecordingMetrics()
+		frames := make(chan *ClientResponse, 2)
+		requester := ChainInterceptors(func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			if method == "net.subscribe_collection" || method == "crypto.register_signing_box" {
+				return frames, nil
+			}
+			out := make(chan *ClientResponse, 1)
+			out <- &ClientResponse{Code: 0, Data: []byte(`{}`)}
+			close(out)
+			return out, nil
+		}, MetricsInterceptor(metrics))
+
+		responses, err := requester(context.Background(), "crypto.register_signing_box", nil)
+		assert.Equal(t, nil, err)
+		frames <- &ClientResponse{Code: 0, Data: []byte(`{"handle":1}`)}
+		frames <- &ClientResponse{Code: 3, Data: []byte(`{"app_request_id":9,"request_data":{"type":"GetPublicKey"}}`)}
+		<-responses
+		<-responses
+		_, err = requester(context.Background(), "client.resolve_app_request", &ParamsOfResolveAppRequest{
+			AppRequestID: 9,
+			Result:       &AppRequestResult{ValueEnumType: AppRequestResultError{Text: "no key"}},
+		})
+		assert.Equal(t, nil, err)
+		close(frames)
+		for range responses {
+		}
+		assert.Equal(t, "no key", metrics.appRequests["crypto.register_signing_box"].Error())
+
+		frames = make(chan *ClientResponse, 1)
+		responses, err = requester(context.Background(), "net.subscribe_collection", nil)
+		assert.Equal(t, nil, err)
+		frames <- &ClientResponse{Code: 0, Data: []byte(`{"handle":2}`)}
+		<-responses
+		metrics.mu.Lock()
+		assert.Equal(t, 1, metrics.subscriptions["net.subscribe_collection"])
+		metrics.mu.Unlock()
+		close(frames)
+		for range responses {
+		}
+		assert.Equal(t, 0, metrics.subscriptions["net.subscribe_collection"])
+	})
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -28,6 +29,27 @@ const (
 	AggregationFnTypeAverage AggregationFnType = "AVERAGE"
 )
 
+// Error codes of the net module.
+const (
+	NetErrorQueryFailed                 = 601
+	NetErrorSubscribeFailed             = 602
+	NetErrorWaitForFailed               = 603
+	NetErrorGetSubscriptionResultFailed = 604
+	NetErrorInvalidServerResponse       = 605
+	NetErrorClockOutOfSync              = 606
+	NetErrorWaitForTimeout              = 607
+	NetErrorGraphqlError                = 608
+	NetErrorNetworkModuleSuspended      = 609
+	NetErrorWebsocketDisconnected       = 610
+	NetErrorNotSupported                = 611
+	NetErrorNoEndpointsProvided         = 612
+	NetErrorGraphqlWebsocketInitError   = 613
+	NetErrorNetworkModuleResumed        = 614
+	NetErrorUnauthorized                = 615
+	NetErrorQueryTransactionTreeTimeout = 616
+	NetErrorGraphqlConnectionError      = 617
+)
+
 // NetErrorCode ...
 var NetErrorCode map[string]int
 
@@ -250,50 +272,72 @@ type (
 	// NetUseCase ...
 	NetUseCase interface {
 		Query(*ParamsOfQuery) (*ResultOfQuery, error)
+		QueryCtx(context.Context, *ParamsOfQuery) (*ResultOfQuery, error)
 		BatchQuery(*ParamsOfBatchQuery) (*ResultOfBatchQuery, error)
+		BatchQueryCtx(context.Context, *ParamsOfBatchQuery) (*ResultOfBatchQuery, error)
 		QueryCollection(*ParamsOfQueryCollection) (*ResultOfQueryCollection, error)
+		QueryCollectionCtx(context.Context, *ParamsOfQueryCollection) (*ResultOfQueryCollection, error)
 		AggregateCollection(*ParamsOfAggregateCollection) (*ResultOfAggregateCollection, error)
+		AggregateCollectionCtx(context.Context, *ParamsOfAggregateCollection) (*ResultOfAggregateCollection, error)
 		WaitForCollection(*ParamsOfWaitForCollection) (*ResultOfWaitForCollection, error)
+		WaitForCollectionCtx(context.Context, *ParamsOfWaitForCollection) (*ResultOfWaitForCollection, error)
 		Unsubscribe(*ResultOfSubscribeCollection) error
+		UnsubscribeCtx(context.Context, *ResultOfSubscribeCollection) error
 		SubscribeCollection(*ParamsOfSubscribeCollection) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
+		SubscribeCollectionCtx(context.Context, *ParamsOfSubscribeCollection) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
 		Subscribe(*ParamsOfSubscribe) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
+		SubscribeCtx(context.Context, *ParamsOfSubscribe) (<-chan json.RawMessage, *ResultOfSubscribeCollection, error)
 		Suspend() error
+		SuspendCtx(context.Context) error
 		Resume() error
+		ResumeCtx(context.Context) error
 		FindLastShardBlock(*ParamsOfFindLastShardBlock) (*ResultOfFindLastShardBlock, error)
+		FindLastShardBlockCtx(context.Context, *ParamsOfFindLastShardBlock) (*ResultOfFindLastShardBlock, error)
 		FetchEndpoints() (*EndpointsSet, error)
+		FetchEndpointsCtx(context.Context) (*EndpointsSet, error)
 		SetEndpoints(*EndpointsSet) error
+		SetEndpointsCtx(context.Context, *EndpointsSet) error
 		GetEndpoints() (*ResultOfGetEndpoints, error)
+		GetEndpointsCtx(context.Context) (*ResultOfGetEndpoints, error)
 		QueryCounterparties(*ParamsOfQueryCounterparties) (*ResultOfQueryCollection, error)
+		QueryCounterpartiesCtx(context.Context, *ParamsOfQueryCounterparties) (*ResultOfQueryCollection, error)
 		QueryTransactionTree(*ParamsOfQueryTransactionTree) (*ResultOfQueryTransactionTree, error)
+		QueryTransactionTreeCtx(context.Context, *ParamsOfQueryTransactionTree) (*ResultOfQueryTransactionTree, error)
 		CreateBlockIterator(*ParamsOfCreateBlockIterator) (*RegisteredIterator, error)
+		CreateBlockIteratorCtx(context.Context, *ParamsOfCreateBlockIterator) (*RegisteredIterator, error)
 		ResumeBlockIterator(*ParamsOfResumeBlockIterator) (*RegisteredIterator, error)
+		ResumeBlockIteratorCtx(context.Context, *ParamsOfResumeBlockIterator) (*RegisteredIterator, error)
 		CreateTransactionIterator(*ParamsOfCreateTransactionIterator) (*RegisteredIterator, error)
+		CreateTransactionIteratorCtx(context.Context, *ParamsOfCreateTransactionIterator) (*RegisteredIterator, error)
 		ResumeTransactionIterator(*ParamsOfResumeTransactionIterator) (*RegisteredIterator, error)
+		ResumeTransactionIteratorCtx(context.Context, *ParamsOfResumeTransactionIterator) (*RegisteredIterator, error)
 		IteratorNext(*ParamsOfIteratorNext) (*ResultOfIteratorNext, error)
+		IteratorNextCtx(context.Context, *ParamsOfIteratorNext) (*ResultOfIteratorNext, error)
 		RemoveIterator(*RegisteredIterator) error
+		RemoveIteratorCtx(context.Context, *RegisteredIterator) error
 	}
 )
 
 func init() {
 
 	NetErrorCode = map[string]int{
-		"QueryFailed":                 601,
-		"SubscribeFailed":             602,
-		"WaitForFailed":               603,
-		"GetSubscriptionResultFailed": 604,
-		"InvalidServerResponse":       605,
-		"ClockOutOfSync":              606,
-		"WaitForTimeout":              607,
-		"GraphqlError":                608,
-		"NetworkModuleSuspended":      609,
-		"WebsocketDisconnected":       610,
-		"NotSupported":                611,
-		"NoEndpointsProvided":         612,
-		"GraphqlWebsocketInitError":   613,
-		"NetworkModuleResumed":        614,
-		"Unauthorized":                615,
-		"QueryTransactionTreeTimeout": 616,
-		"GraphqlConnectionError":      617,
+		"QueryFailed":                 NetErrorQueryFailed,
+		"SubscribeFailed":             NetErrorSubscribeFailed,
+		"WaitForFailed":               NetErrorWaitForFailed,
+		"GetSubscriptionResultFailed": NetErrorGetSubscriptionResultFailed,
+		"InvalidServerResponse":       NetErrorInvalidServerResponse,
+		"ClockOutOfSync":              NetErrorClockOutOfSync,
+		"WaitForTimeout":              NetErrorWaitForTimeout,
+		"GraphqlError":                NetErrorGraphqlError,
+		"NetworkModuleSuspended":      NetErrorNetworkModuleSuspended,
+		"WebsocketDisconnected":       NetErrorWebsocketDisconnected,
+		"NotSupported":                NetErrorNotSupported,
+		"NoEndpointsProvided":         NetErrorNoEndpointsProvided,
+		"GraphqlWebsocketInitError":   NetErrorGraphqlWebsocketInitError,
+		"NetworkModuleResumed":        NetErrorNetworkModuleResumed,
+		"Unauthorized":                NetErrorUnauthorized,
+		"QueryTransactionTreeTimeout": NetErrorQueryTransactionTreeTimeout,
+		"GraphqlConnectionError":      NetErrorGraphqlConnectionError,
 	}
 }
 
@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/util"
+)
+
+// ProjectPlaceholder - part of the endpoints of a NetworkProfile which is replaced with its project ID.
+const ProjectPlaceholder = "{project}"
+
+type (
+	// NetworkProfile - named network: its endpoints, where its credentials come from and its default timeouts.
+	// Secrets are read from the environment when the config is built, so profiles can be shared:
+	//
+	//	domain.Networks.Register(domain.NetworkProfile{
+	//		Name:         "staging",
+	//		Endpoints:    []string{"https://staging.example.com/graphql"},
+	//		AccessKeyEnv: "STAGING_ACCESS_KEY",
+	//	})
+	NetworkProfile struct {
+		Name string `json:"-"`
+		// Endpoints - GraphQL endpoints; ProjectPlaceholder is replaced with the project ID.
+		Endpoints []string `json:"endpoints,omitempty"`
+		// ProjectIDEnv - environment variable holding the project ID, ProjectID is used if it is not set.
+		ProjectIDEnv string `json:"project_id_env,omitempty"`
+		ProjectID    string `json:"project_id,omitempty"`
+		// AccessKeyEnv - environment variable holding the access key, AccessKey is used if it is not set.
+		AccessKeyEnv string `json:"access_key_env,omitempty"`
+		AccessKey    string `json:"access_key,omitempty"`
+		// Timeouts in milliseconds, the defaults of NewDefaultConfig if nil.
+		MessageProcessingTimeout *int `json:"message_processing_timeout,omitempty" config:"duration"`
+		WaitForTimeout           *int `json:"wait_for_timeout,omitempty" config:"duration"`
+		QueryTimeout             *int `json:"query_timeout,omitempty" config:"duration"`
+		// WorkChain - default workchain of the deployed contracts, 0 if nil.
+		WorkChain *int `json:"workchain,omitempty"`
+	}
+
+	// NetworkRegistry - network profiles by name, safe for concurrent use.
+	NetworkRegistry struct {
+		mu       sync.RWMutex
+		profiles map[string]NetworkProfile
+	}
+)
+
+// Networks - the registry of goever.NewEverForNetwork. It starts with the mainnet, devnet, local and custom profiles;
+// mainnet and devnet take the project ID from EVER_PROJECT_ID and the access key from EVER_ACCESS_KEY.
+var Networks = NewNetworkRegistry(
+	NetworkProfile{
+		Name:         "mainnet",
+		Endpoints:    []string{"https://mainnet.evercloud.dev/" + ProjectPlaceholder + "/graphql"},
+		ProjectIDEnv: "EVER_PROJECT_ID",
+		AccessKeyEnv: "EVER_ACCESS_KEY",
+	},
+	NetworkProfile{
+		Name:         "devnet",
+		Endpoints:    []string{"https://devnet.evercloud.dev/" + ProjectPlaceholder + "/graphql"},
+		ProjectIDEnv: "EVER_PROJECT_ID",
+		AccessKeyEnv: "EVER_ACCESS_KEY",
+	},
+	NetworkProfile{
+		Name:      "local",
+		Endpoints: GetLocalNetBaseUrls(),
+	},
+	NetworkProfile{
+		Name:      "custom",
+		Endpoints: []string{BaseCustomUrl},
+	},
+)
+
+// NewNetworkRegistry creates a registry of profiles.
+func NewNetworkRegistry(profiles ...NetworkProfile) *NetworkRegistry {
+	r := &NetworkRegistry{profiles: make(map[string]NetworkProfile)}
+	for _, profile := range profiles {
+		r.profiles[profile.Name] = profile
+	}
+
+	return r
+}
+
+// Register adds profile, replacing the profile with the same name.
+func (r *NetworkRegistry) Register(profile NetworkProfile) error {
+	if profile.Name == "" {
+		return fmt.Errorf("network profile has no name")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[profile.Name] = profile
+
+	return nil
+}
+
+// Lookup returns the profile called name.
+func (r *NetworkRegistry) Lookup(name string) (NetworkProfile, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	profile, ok := r.profiles[name]
+
+	return profile, ok
+}
+
+// Names lists the registered profiles in alphabetical order.
+func (r *NetworkRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.profiles))
+	for name := range r.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Config builds the config of the profile called name, see NetworkProfile.Config.
+func (r *NetworkRegistry) Config(name string) (ClientConfig, error) {
+	profile, ok := r.Lookup(name)
+	if !ok {
+		return ClientConfig{}, fmt.Errorf("unknown network %q, registered: %s", name, strings.Join(r.Names(), ", "))
+	}
+
+	return profile.Config()
+}
+
+// Load registers the profiles of the networks section of a config file, see LoadConfig for the formats.
+// Fields of a file profile override the fields of the registered profile with the same name:
+//
+//	[networks.devnet]
+//	project_id_env = "DEVNET_PROJECT"
+//	wait_for_timeout = "60s"
+func (r *NetworkRegistry) Load(path string) error {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	sections, ok := values["networks"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: no networks section", path)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	loaded := make(map[string]NetworkProfile, len(sections))
+	for name, section := range sections {
+		fields, ok := section.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: config key %q must be a section", path, "networks."+name)
+		}
+		normalized, err := normalizeConfig(reflect.TypeOf(NetworkProfile{}), "networks."+name, fields)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		data, err := json.Marshal(normalized)
+		if err != nil {
+			return err
+		}
+		profile := r.profiles[name]
+		profile.Name = name
+		if err := json.Unmarshal(data, &profile); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		loaded[name] = profile
+	}
+	for name, profile := range loaded {
+		r.profiles[name] = profile
+	}
+
+	return nil
+}
+
+// Config builds a NewDefaultConfig for the profile and validates it. It fails if the endpoints need
+// a project ID which is not set.
+func (p NetworkProfile) Config() (ClientConfig, error) {
+	projectID := lookupEnv(p.ProjectIDEnv, p.ProjectID)
+	endpoints := make([]string, len(p.Endpoints))
+	for i, endpoint := range p.Endpoints {
+		if strings.Contains(endpoint, ProjectPlaceholder) {
+			if projectID == "" {
+				return ClientConfig{}, fmt.Errorf("network %q needs a project ID, set %s", p.Name, p.projectSource())
+			}
+			endpoint = strings.ReplaceAll(endpoint, ProjectPlaceholder, projectID)
+		}
+		endpoints[i] = endpoint
+	}
+
+	config := NewDefaultConfig("", endpoints, lookupEnv(p.AccessKeyEnv, p.AccessKey))
+	if p.MessageProcessingTimeout != nil {
+		config.Network.MessageProcessingTimeout = util.IntToPointerInt(*p.MessageProcessingTimeout)
+	}
+	if p.WaitForTimeout != nil {
+		config.Network.WaitForTimeout = util.IntToPointerInt(*p.WaitForTimeout)
+	}
+	if p.QueryTimeout != nil {
+		config.Network.QueryTimeout = util.IntToPointerInt(*p.QueryTimeout)
+	}
+	if p.WorkChain != nil {
+		config.Abi.WorkChain = util.IntToPointerInt(*p.WorkChain)
+	}
+
+	return config, config.Validate()
+}
+
+func (p NetworkProfile) projectSource() string {
+	if p.ProjectIDEnv != "" {
+		return p.ProjectIDEnv
+	}
+
+	return "project_id"
+}
+
+// networkEndpoints returns the endpoints of the profile called name, nil if its config cannot be built.
+func networkEndpoints(name string) []string {
+	config, err := Networks.Config(name)
+	if err != nil {
+		return nil
+	}
+
+	return config.Network.Endpoints
+}
+
+// lookupEnv returns the variable env if it is set and not empty, fallback otherwise.
+func lookupEnv(env, fallback string) string {
+	if env != "" {
+		if value := os.Getenv(env); value != "" {
+			return value
+		}
+	}
+
+	return fallback
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworks(t *testing.T) {
+	t.Run("TestBuiltIn", func(t *testing.T) {
+		assert.Equal(t, []string{"custom", "devnet", "local", "mainnet"}, Networks.Names())
+
+		os.Unsetenv("EVER_PROJECT_ID")
+		_, err := Networks.Config("devnet")
+		assert.EqualError(t, err, `network "devnet" needs a project ID, set EVER_PROJECT_ID`)
+		assert.Equal(t, []string(nil), GetDevNetBaseUrls())
+
+		os.Setenv("EVER_PROJECT_ID", "p1")
+		os.Setenv("EVER_ACCESS_KEY", "k1")
+		defer os.Unsetenv("EVER_PROJECT_ID")
+		defer os.Unsetenv("EVER_ACCESS_KEY")
+		config, err := Networks.Config("devnet")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"https://devnet.evercloud.dev/p1/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, "k1", config.Network.AccessKey)
+		assert.Equal(t, config.Network.Endpoints, GetDevNetBaseUrls())
+		assert.Equal(t, []string{"https://mainnet.evercloud.dev/p1/graphql"}, GetMainNetBaseUrls())
+
+		config, err = Networks.Config("local")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, GetLocalNetBaseUrls(), config.Network.Endpoints)
+
+		_, err = Networks.Config("testnet")
+		assert.EqualError(t, err, `unknown network "testnet", registered: custom, devnet, local, mainnet`)
+	})
+
+	t.Run("TestRegister", func(t *testing.T) {
+		networks := NewNetworkRegistry()
+		assert.NotEqual(t, nil, networks.Register(NetworkProfile{}))
+		assert.Equal(t, nil, networks.Register(NetworkProfile{
+			Name:           "staging",
+			Endpoints:      []string{"https://staging.dev/graphql"},
+			AccessKeyEnv:   "TEST_STAGING_KEY",
+			AccessKey:      "fallback",
+			WaitForTimeout: util.IntToPointerInt(1000),
+			WorkChain:      util.IntToPointerInt(-1),
+		}))
+		config, err := networks.Config("staging")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "fallback", config.Network.AccessKey)
+		assert.Equal(t, 1000, *config.Network.WaitForTimeout)
+		assert.Equal(t, 40000, *config.Network.MessageProcessingTimeout)
+		assert.Equal(t, -1, *config.Abi.WorkChain)
+
+		os.Setenv("TEST_STAGING_KEY", "secret")
+		defer os.Unsetenv("TEST_STAGING_KEY")
+		config, err = networks.Config("staging")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "secret", config.Network.AccessKey)
+	})
+
+	t.Run("TestLoad", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "networks")
+		assert.Equal(t, nil, err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "networks.toml")
+		assert.Equal(t, nil, ioutil.WriteFile(path, []byte(`
+[networks.devnet]
+project_id = "p2"
+wait_for_timeout = "1m"
+
+[networks.private]
+endpoints = "https://a.private/graphql, https://b.private/graphql"
+workchain = 0
+`), 0600))
+
+		networks := NewNetworkRegistry(NetworkProfile{
+			Name:         "devnet",
+			Endpoints:    []string{"https://devnet.evercloud.dev/" + ProjectPlaceholder + "/graphql"},
+			ProjectIDEnv: "TEST_DEVNET_PROJECT",
+		})
+		assert.Equal(t, nil, networks.Load(path))
+		assert.Equal(t, []string{"devnet", "private"}, networks.Names())
+
+		config, err := networks.Config("devnet")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"https://devnet.evercloud.dev/p2/graphql"}, config.Network.Endpoints)
+		assert.Equal(t, 60000, *config.Network.WaitForTimeout)
+
+		config, err = networks.Config("private")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"https://a.private/graphql", "https://b.private/graphql"}, config.Network.Endpoints)
+
+		assert.Equal(t, nil, ioutil.WriteFile(path, []byte("[networks.devnet]\nproject = \"p3\"\n"), 0600))
+		assert.EqualError(t, networks.Load(path), path+`: unknown config key "networks.devnet.project"`)
+	})
+}
@@ -1,11 +1,32 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
 )
 
+// Error codes of the processing module.
+const (
+	ProcessingErrorMessageAlreadyExpired           = 501
+	ProcessingErrorMessageHasNotDestinationAddress = 502
+	ProcessingErrorCanNotBuildMessageCell          = 503
+	ProcessingErrorFetchBlockFailed                = 504
+	ProcessingErrorSendMessageFailed               = 505
+	ProcessingErrorInvalidMessageBoc               = 506
+	ProcessingErrorMessageExpired                  = 507
+	ProcessingErrorTransactionWaitTimeout          = 508
+	ProcessingErrorInvalidBlockReceived            = 509
+	ProcessingErrorCanNotCheckBlockShard           = 510
+	ProcessingErrorBlockNotFound                   = 511
+	ProcessingErrorInvalidData                     = 512
+	ProcessingErrorExternalSignerMustNotBeUsed     = 513
+	ProcessingErrorMessageRejected                 = 514
+	ProcessingErrorInvalidRempStatus               = 515
+	ProcessingErrorNextRempStatusTimeout           = 516
+)
+
 // ProcessingErrorCode ...
 var ProcessingErrorCode map[string]int
 
@@ -148,29 +169,32 @@ type (
 	// ProcessingUseCase ...
 	ProcessingUseCase interface {
 		SendMessage(*ParamsOfSendMessage, EventCallback) (*ResultOfSendMessage, error)
+		SendMessageCtx(context.Context, *ParamsOfSendMessage, EventCallback) (*ResultOfSendMessage, error)
 		WaitForTransaction(*ParamsOfWaitForTransaction, EventCallback) (*ResultOfProcessMessage, error)
+		WaitForTransactionCtx(context.Context, *ParamsOfWaitForTransaction, EventCallback) (*ResultOfProcessMessage, error)
 		ProcessMessage(*ParamsOfProcessMessage, EventCallback) (*ResultOfProcessMessage, error)
+		ProcessMessageCtx(context.Context, *ParamsOfProcessMessage, EventCallback) (*ResultOfProcessMessage, error)
 	}
 )
 
 func init() {
 	ProcessingErrorCode = map[string]int{
-		"MessageAlreadyExpired          ": 501,
-		"MessageHasNotDestinationAddress": 502,
-		"CanNotBuildMessageCell         ": 503,
-		"FetchBlockFailed               ": 504,
-		"SendMessageFailed              ": 505,
-		"InvalidMessageBoc              ": 506,
-		"MessageExpired                 ": 507,
-		"TransactionWaitTimeout         ": 508,
-		"InvalidBlockReceived           ": 509,
-		"CanNotCheckBlockShard          ": 510,
-		"BlockNotFound                  ": 511,
-		"InvalidData                    ": 512,
-		"ExternalSignerMustNotBeUsed    ": 513,
-		"MessageRejected				": 514,
-		"InvalidRempStatus				": 515,
-		"NextRempStatusTimeout			": 516,
+		"MessageAlreadyExpired":           ProcessingErrorMessageAlreadyExpired,
+		"MessageHasNotDestinationAddress": ProcessingErrorMessageHasNotDestinationAddress,
+		"CanNotBuildMessageCell":          ProcessingErrorCanNotBuildMessageCell,
+		"FetchBlockFailed":                ProcessingErrorFetchBlockFailed,
+		"SendMessageFailed":               ProcessingErrorSendMessageFailed,
+		"InvalidMessageBoc":               ProcessingErrorInvalidMessageBoc,
+		"MessageExpired":                  ProcessingErrorMessageExpired,
+		"TransactionWaitTimeout":          ProcessingErrorTransactionWaitTimeout,
+		"InvalidBlockReceived":            ProcessingErrorInvalidBlockReceived,
+		"CanNotCheckBlockShard":           ProcessingErrorCanNotCheckBlockShard,
+		"BlockNotFound":                   ProcessingErrorBlockNotFound,
+		"InvalidData":                     ProcessingErrorInvalidData,
+		"ExternalSignerMustNotBeUsed":     ProcessingErrorExternalSignerMustNotBeUsed,
+		"MessageRejected":                 ProcessingErrorMessageRejected,
+		"InvalidRempStatus":               ProcessingErrorInvalidRempStatus,
+		"NextRempStatusTimeout":           ProcessingErrorNextRempStatusTimeout,
 	}
 }
 
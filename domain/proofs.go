@@ -1,6 +1,17 @@
 package domain
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+)
+
+// Error codes of the proofs module.
+const (
+	ProofsErrorInvalidData           = 901
+	ProofsErrorProofCheckFailed      = 902
+	ProofsErrorInternalError         = 903
+	ProofsErrorDataDiffersFromProven = 904
+)
 
 // ProofsErrorCode ...
 var ProofsErrorCode map[string]int
@@ -25,16 +36,19 @@ type (
 	// ProofsUseCase ...
 	ProofsUseCase interface {
 		ProofBlockData(*ParamsOfProofBlockData) error
+		ProofBlockDataCtx(context.Context, *ParamsOfProofBlockData) error
 		ProofTransactionData(*ParamsOfProofTransactionData) error
+		ProofTransactionDataCtx(context.Context, *ParamsOfProofTransactionData) error
 		ParamsMessageData(*ParamsOfProofMessageData) error
+		ParamsMessageDataCtx(context.Context, *ParamsOfProofMessageData) error
 	}
 )
 
 func init() {
-	AbiErrorCode = map[string]int{
-		"InvalidData":           901,
-		"ProofCheckFailed":      902,
-		"InternalError":         903,
-		"DataDiffersFromProven": 904,
+	ProofsErrorCode = map[string]int{
+		"InvalidData":           ProofsErrorInvalidData,
+		"ProofCheckFailed":      ProofsErrorProofCheckFailed,
+		"InternalError":         ProofsErrorInternalError,
+		"DataDiffersFromProven": ProofsErrorDataDiffersFromProven,
 	}
 }
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy - how idempotent calls are retried after application-level network failures, on top of
+// the transport retries of NetworkConfig.NetworkRetriesCount. Zero fields take the values of DefaultRetryPolicy,
+// except Jitter. Calls which change state, such as processing.send_message, must never be retried with it.
+type RetryPolicy struct {
+	// MaxAttempts - attempts including the first one; 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; each next one is Multiplier times longer, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter - fraction of each delay, from 0 to 1, by which it is randomly shortened or lengthened.
+	// Values outside the range are clamped to it.
+	Jitter float64
+	// RetryableCodes - ClientError codes which are retried; IsNetworkError decides if nil.
+	RetryableCodes []int
+	// OnRetry, if set, is called before each retry with the failed attempt (from 1), its error and the delay.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// DefaultRetryPolicy - 3 attempts, 200ms backoff doubling up to 5s, 20% jitter, network errors.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// Retryable reports whether a call which failed with err is retried. Context errors never are.
+func (p RetryPolicy) Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if p.RetryableCodes == nil {
+		return IsNetworkError(err)
+	}
+	code, ok := ErrorCode(err)
+	if !ok {
+		return false
+	}
+	for _, retryable := range p.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Backoff returns the delay after the failed attempt, counted from 1, jitter included. It never exceeds MaxBackoff.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	p = p.withDefaults()
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt && delay < float64(p.MaxBackoff); i++ {
+		delay *= p.Multiplier
+	}
+	if delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay)
+}
+
+// Do calls call until it succeeds, fails with an error which is not retryable or runs out of attempts,
+// and returns its last error. It stops waiting and returns ctx.Err() when ctx is done.
+func (p RetryPolicy) Do(ctx context.Context, call func(ctx context.Context) error) error {
+	p = p.withDefaults()
+	for attempt := 1; ; attempt++ {
+		err := call(ctx)
+		if err == nil || attempt >= p.MaxAttempts || !p.Retryable(err) {
+			return err
+		}
+
+		delay := p.Backoff(attempt)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	defaults := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaults.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaults.MaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaults.Multiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+
+	return p
+}
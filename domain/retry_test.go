@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("TestRetryable", func(t *testing.T) {
+		policy := DefaultRetryPolicy()
+		assert.True(t, policy.Retryable(&ClientError{Code: NetErrorQueryFailed}))
+		assert.True(t, policy.Retryable(&ClientError{Code: NetErrorNetworkModuleSuspended}))
+		assert.False(t, policy.Retryable(&ClientError{Code: ProcessingErrorMessageExpired}))
+		assert.False(t, policy.Retryable(context.DeadlineExceeded))
+		assert.False(t, policy.Retryable(errors.New("boom")))
+
+		policy.RetryableCodes = []int{NetErrorWaitForTimeout}
+		assert.True(t, policy.Retryable(&ClientError{Code: NetErrorWaitForTimeout}))
+		assert.False(t, policy.Retryable(&ClientError{Code: NetErrorQueryFailed}))
+	})
+
+	t.Run("TestBackoff", func(t *testing.T) {
+		policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+		assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+		assert.Equal(t, 300*time.Millisecond, policy.Backoff(2))
+		assert.Equal(t, 900*time.Millisecond, policy.Backoff(3))
+		assert.Equal(t, time.Second, policy.Backoff(4))
+
+		policy.Jitter = 0.5
+		for i := 0; i < 100; i++ {
+			delay := policy.Backoff(1)
+			assert.True(t, delay >= 50*time.Millisecond && delay <= 150*time.Millisecond)
+		}
+	})
+
+	t.Run("TestBackoffClamped", func(t *testing.T) {
+		// Jitter above 1 is taken as 1, so delays stay between 0 and MaxBackoff.
+		policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3, Jitter: 5}
+		for i := 0; i < 100; i++ {
+			delay := policy.Backoff(1)
+			assert.True(t, delay >= 0 && delay <= 200*time.Millisecond)
+			delay = policy.Backoff(4)
+			assert.True(t, delay >= 0 && delay <= time.Second)
+		}
+
+		policy.Jitter = -1
+		assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+		assert.Equal(t, time.Second, policy.Backoff(4))
+	})
+
+	t.Run("TestDo", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+		attempts := 0
+		err := policy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			return &ClientError{Code: NetErrorWebsocketDisconnected}
+		})
+		assert.True(t, errors.Is(err, ErrWebsocketDisconnected))
+		assert.Equal(t, 3, attempts)
+
+		attempts = 0
+		err = policy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if attempts == 1 {
+				return &ClientError{Code: NetErrorQueryFailed}
+			}
+			return nil
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("TestDoCanceled", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := policy.Do(ctx, func(ctx context.Context) error {
+			return &ClientError{Code: NetErrorQueryFailed}
+		})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Response codes of the library.
+const (
+	ResponseResult     = 0
+	ResponseError      = 1
+	ResponseNop        = 2
+	ResponseAppRequest = 3
+	ResponseAppNotify  = 4
+	ResponseEvent      = 100
+)
+
+type (
+	// Frame - response of a Stream: Result, Event, AppRequest or AppNotify.
+	Frame interface {
+		frame()
+	}
+
+	// Result - result of the function. Subscriptions and app objects keep streaming after it.
+	Result struct {
+		Data json.RawMessage
+	}
+
+	// Event - intermediate event, e.g. a ProcessingEvent or a subscription notification.
+	Event struct {
+		Data json.RawMessage
+	}
+
+	// AppRequest - call of an app object which must be answered with ResolveAppRequest.
+	AppRequest struct {
+		AppRequestID int
+		RequestData  json.RawMessage
+	}
+
+	// AppNotify - notification of an app object.
+	AppNotify struct {
+		Data json.RawMessage
+	}
+
+	// Stream - typed reader of the responses of a request:
+	//
+	//	stream, err := domain.OpenStream(ctx, client, "net.subscribe", params)
+	//	defer stream.Close()
+	//	for {
+	//		frame, err := stream.Next(ctx)
+	//		if err == io.EOF {
+	//			break
+	//		}
+	//		...
+	//	}
+	Stream struct {
+		responses <-chan *ClientResponse
+		cancel    context.CancelFunc
+
+		mu     sync.Mutex
+		err    error
+		closed bool
+	}
+)
+
+func (Result) frame()     {}
+func (Event) frame()      {}
+func (AppRequest) frame() {}
+func (AppNotify) frame()  {}
+
+// OpenStream - calls method and returns the stream of its responses. The stream ends when the library finishes
+// the request, ctx is done or the stream is closed. Frames are buffered, a slow reader does not hold up the gateway.
+func OpenStream(ctx context.Context, client ClientGateway, method string, params interface{}) (*Stream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	responses, err := client.RequestContext(ctx, method, params)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return NewStream(DynBufferForResponses(responses), cancel), nil
+}
+
+// NewStream - stream of responses, e.g. of a RequestContext call. cancel, if not nil, is called when the stream
+// ends or is closed and must make the gateway close responses.
+func NewStream(responses <-chan *ClientResponse, cancel context.CancelFunc) *Stream {
+	return &Stream{responses: responses, cancel: cancel}
+}
+
+// Next returns the next frame. Error responses are returned as errors; the stream goes on after them,
+// e.g. a subscription reports a lost connection and resumes. Next returns io.EOF after the last frame
+// and ctx.Err() if ctx is done first.
+func (s *Stream) Next(ctx context.Context) (Frame, error) {
+	for {
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return nil, io.EOF
+		}
+
+		var (
+			r  *ClientResponse
+			ok bool
+		)
+		select {
+		case r, ok = <-s.responses:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if !ok {
+			if s.cancel != nil {
+				s.cancel()
+			}
+			return nil, io.EOF
+		}
+
+		switch r.Code {
+		case ResponseResult:
+			return Result{Data: r.Data}, nil
+		case ResponseError:
+			s.fail(r.Error)
+			return nil, r.Error
+		case ResponseNop:
+			continue
+		case ResponseAppRequest:
+			var appRequest ParamsOfAppRequest
+			if err := json.Unmarshal(r.Data, &appRequest); err != nil {
+				return nil, err
+			}
+			return AppRequest{AppRequestID: appRequest.AppRequestID, RequestData: appRequest.RequestData}, nil
+		case ResponseAppNotify:
+			return AppNotify{Data: r.Data}, nil
+		case ResponseEvent:
+			return Event{Data: r.Data}, nil
+		default:
+			return nil, fmt.Errorf("unknown response type code %v", r.Code)
+		}
+	}
+}
+
+// Wait reads the stream until the result, unmarshals it into result and returns. The frames before the result
+// are passed to handle, if it is not nil; an error of handle ends the wait.
+func (s *Stream) Wait(ctx context.Context, result interface{}, handle func(Frame) error) error {
+	for {
+		frame, err := s.Next(ctx)
+		if err == io.EOF {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+		if r, ok := frame.(Result); ok {
+			return json.Unmarshal(r.Data, result)
+		}
+		if handle != nil {
+			if err := handle(frame); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close stops the stream. The request is cancelled if the stream was opened with a cancel function.
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.cancel != nil {
+		s.cancel()
+	}
+	go func() {
+		for range s.responses {
+		}
+	}()
+}
+
+// Drain reads the remaining frames in the background until the library finishes the request,
+// e.g. after Wait returned the result of a function which does not stream after it.
+func (s *Stream) Drain() {
+	go func() {
+		for {
+			if _, err := s.Next(context.Background()); err == io.EOF {
+				return
+			}
+		}
+	}()
+}
+
+// Err returns the first error response of the stream, if any.
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *Stream) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+// ProcessingEvents - handler for Stream.Wait which passes the Event frames to callback as ProcessingEvent.
+func ProcessingEvents(callback EventCallback) func(Frame) error {
+	return func(frame Frame) error {
+		event, ok := frame.(Event)
+		if !ok || callback == nil {
+			return nil
+		}
+		processingEvent := &ProcessingEvent{}
+		if err := json.Unmarshal(event.Data, processingEvent); err != nil {
+			return err
+		}
+		callback(processingEvent)
+
+		return nil
+	}
+}
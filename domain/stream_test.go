@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	responses := func(frames ...*ClientResponse) chan *ClientResponse {
+		out := make(chan *ClientResponse, len(frames))
+		for _, r := range frames {
+			out <- r
+		}
+		return out
+	}
+
+	t.Run("TestFrames", func(t *testing.T) {
+		in := responses(
+			&ClientResponse{Code: ResponseEvent, Data: []byte(`{"type":"WillSend"}`)},
+			&ClientResponse{Code: ResponseNop},
+			&ClientResponse{Code: ResponseAppRequest, Data: []byte(`{"app_request_id":3,"request_data":{"type":"GetPublicKey"}}`)},
+			&ClientResponse{Code: ResponseAppNotify, Data: []byte(`{"type":"Log"}`)},
+			&ClientResponse{Code: ResponseResult, Data: []byte(`{"handle":1}`)},
+		)
+		close(in)
+		stream := NewStream(in, nil)
+		var frames []Frame
+		for {
+			frame, err := stream.Next(context.Background())
+			if err == io.EOF {
+				break
+			}
+			assert.Equal(t, nil, err)
+			frames = append(frames, frame)
+		}
+		assert.Equal(t, []Frame{
+			Event{Data: json.RawMessage(`{"type":"WillSend"}`)},
+			AppRequest{AppRequestID: 3, RequestData: json.RawMessage(`{"type":"GetPublicKey"}`)},
+			AppNotify{Data: json.RawMessage(`{"type":"Log"}`)},
+			Result{Data: json.RawMessage(`{"handle":1}`)},
+		}, frames)
+		assert.Equal(t, nil, stream.Err())
+	})
+
+	t.Run("TestError", func(t *testing.T) {
+		in := responses(
+			&ClientResponse{Code: ResponseError, Error: &ClientError{Code: ClientErrorInvalidParams}},
+			&ClientResponse{Code: ResponseEvent, Data: []byte(`{}`)},
+		)
+		close(in)
+		stream := NewStream(in, nil)
+		_, err := stream.Next(context.Background())
+		assert.Equal(t, true, errors.Is(err, ErrInvalidParams))
+		frame, err := stream.Next(context.Background())
+		assert.Equal(t, nil, err)
+		assert.Equal(t, Event{Data: json.RawMessage(`{}`)}, frame)
+		assert.Equal(t, true, errors.Is(stream.Err(), ErrInvalidParams))
+	})
+
+	t.Run("TestWait", func(t *testing.T) {
+		in := responses(
+			&ClientResponse{Code: ResponseEvent, Data: []byte(`{"type":"WillSend","shard_block_id":"1","message_id":"2","message":"3"}`)},
+			&ClientResponse{Code: ResponseResult, Data: []byte(`{"shard_block_id":"4"}`)},
+		)
+		close(in)
+		var events []*ProcessingEvent
+		result := &ResultOfSendMessage{}
+		err := NewStream(in, nil).Wait(context.Background(), result, ProcessingEvents(func(event *ProcessingEvent) {
+			events = append(events, event)
+		}))
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "4", result.ShardBlockID)
+		assert.Equal(t, 1, len(events))
+	})
+
+	t.Run("TestWaitEnded", func(t *testing.T) {
+		in := responses()
+		close(in)
+		err := NewStream(in, nil).Wait(context.Background(), &ResultOfSendMessage{}, nil)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("TestNextContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := NewStream(responses(), nil).Next(ctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("TestClose", func(t *testing.T) {
+		in := responses(&ClientResponse{Code: ResponseEvent, Data: []byte(`{}`)})
+		cancelled := false
+		stream := NewStream(in, func() {
+			cancelled = true
+			close(in)
+		})
+		stream.Close()
+		stream.Close()
+		assert.Equal(t, true, cancelled)
+		_, err := stream.Next(context.Background())
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func TestReadStream(t *testing.T) {
+	in := make(chan *ClientResponse)
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		in <- &ClientResponse{Code: ResponseError, Error: &ClientError{Code: ClientErrorInvalidParams}}
+		in <- &ClientResponse{Code: ResponseEvent, Data: []byte(`{}`)}
+		in <- &ClientResponse{Code: ResponseEvent, Data: []byte(`{}`)}
+		close(in)
+	}()
+
+	_, err := ReadStream(context.Background(), in, nil)
+	assert.Equal(t, true, errors.Is(err, ErrInvalidParams))
+	// The responses after the error are drained, the sender is not blocked.
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not drained")
+	}
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tomlDateTime matches the start of a TOML date, date-time or time.
+var tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}|\d{2}:\d{2})`)
+
+// parseTOML - parses the subset of TOML used by config files: tables, dotted keys, comments, basic and literal
+// strings, integers, floats, booleans and arrays of them, which may span lines.
+// Inline tables, arrays of tables, multi-line strings and dates and times are not supported and fail with an error.
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	table := root
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("toml: line %d: arrays of tables are not supported: %s", i+1, line)
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("toml: line %d: invalid table %q", i+1, line)
+			}
+			var err error
+			if table, err = tomlTable(root, strings.TrimSpace(line[1:len(line)-1])); err != nil {
+				return nil, fmt.Errorf("toml: line %d: %v", i+1, err)
+			}
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("toml: line %d: expected key = value", i+1)
+		}
+		key, raw := strings.TrimSpace(line[:eq]), strings.TrimSpace(line[eq+1:])
+		start := i
+		for strings.HasPrefix(raw, "[") && strings.Count(raw, "[") > strings.Count(raw, "]") && i+1 < len(lines) {
+			i++
+			raw += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+		value, err := tomlValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("toml: line %d: %v", start+1, err)
+		}
+		path := strings.Split(key, ".")
+		parent, err := tomlTable(table, strings.Join(path[:len(path)-1], "."))
+		if err != nil {
+			return nil, fmt.Errorf("toml: line %d: %v", start+1, err)
+		}
+		name := unquoteKey(path[len(path)-1])
+		if _, ok := parent[name]; ok {
+			return nil, fmt.Errorf("toml: line %d: duplicate key %q", start+1, key)
+		}
+		parent[name] = value
+	}
+
+	return root, nil
+}
+
+// tomlTable returns the table at the dotted path below root, creating it if needed.
+func tomlTable(root map[string]interface{}, path string) (map[string]interface{}, error) {
+	table := root
+	if path == "" {
+		return table, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		name := unquoteKey(part)
+		if name == "" {
+			return nil, fmt.Errorf("empty key in %q", path)
+		}
+		next, ok := table[name]
+		if !ok {
+			created := make(map[string]interface{})
+			table[name] = created
+			table = created
+			continue
+		}
+		if table, ok = next.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%q is not a table", path)
+		}
+	}
+
+	return table, nil
+}
+
+func unquoteKey(key string) string {
+	key = strings.TrimSpace(key)
+	if len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		return key[1 : len(key)-1]
+	}
+
+	return key
+}
+
+// stripComment cuts the comment of line, if it is not inside a string.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\' && quote == '"':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+func tomlValue(raw string) (interface{}, error) {
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	case strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, "'''"):
+		return nil, fmt.Errorf("multi-line strings are not supported: %s", raw)
+	case raw[0] == '{':
+		return nil, fmt.Errorf("inline tables are not supported: %s", raw)
+	case tomlDateTime.MatchString(raw):
+		return nil, fmt.Errorf("dates and times are not supported: %s", raw)
+	case raw[0] == '"':
+		if len(raw) < 2 || raw[len(raw)-1] != '"' {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return strconv.Unquote(raw)
+	case raw[0] == '\'':
+		if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+			return nil, fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	case raw[0] == '[':
+		return tomlArray(raw)
+	}
+
+	number := strings.ReplaceAll(raw, "_", "")
+	if i, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(number, 64); err == nil {
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %s", raw)
+}
+
+func tomlArray(raw string) ([]interface{}, error) {
+	if raw[len(raw)-1] != ']' {
+		return nil, fmt.Errorf("unterminated array %s", raw)
+	}
+	items := make([]interface{}, 0)
+	body := raw[1 : len(raw)-1]
+	var (
+		quote byte
+		depth int
+		start int
+	)
+	flush := func(end int) error {
+		item := strings.TrimSpace(body[start:end])
+		start = end + 1
+		if item == "" {
+			return nil
+		}
+		value, err := tomlValue(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, value)
+		return nil
+	}
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case quote != 0 && c == '\\' && quote == '"':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '[':
+			depth++
+		case quote == 0 && c == ']':
+			depth--
+		case quote == 0 && depth == 0 && c == ',':
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(len(body)); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
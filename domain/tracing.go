@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type (
+	// Tracer - starts spans. Adapters of tracing libraries, e.g. OpenTelemetry, implement it
+	// and keep the parent span in the returned context.
+	Tracer interface {
+		StartSpan(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Span - timed operation of a trace.
+	Span interface {
+		SetAttribute(key string, value interface{})
+		RecordError(err error)
+		End()
+	}
+
+	nopTracer struct{}
+	nopSpan   struct{}
+
+	// ProcessingTrace - child spans of the phases of message processing, one per ProcessingEvent.
+	// A phase lasts until the next event or the end of processing; failure events record their error.
+	ProcessingTrace struct {
+		ctx    context.Context
+		tracer Tracer
+		phase  Span
+	}
+
+	processingEventInfo struct {
+		Type         string       `json:"type"`
+		MessageID    string       `json:"message_id"`
+		ShardBlockID string       `json:"shard_block_id"`
+		Error        *ClientError `json:"error"`
+	}
+)
+
+// NopTracer - starts spans which record nothing.
+var NopTracer Tracer = nopTracer{}
+
+func (nopTracer) StartSpan(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+func (nopSpan) SetAttribute(string, interface{}) {}
+func (nopSpan) RecordError(error)                {}
+func (nopSpan) End()                             {}
+
+// TracerOf returns the tracer of client, set with client.WithTracer or remote.WithTracer, or NopTracer.
+func TracerOf(client ClientGateway) Tracer {
+	if traced, ok := client.(interface{ Tracer() Tracer }); ok {
+		if tracer := traced.Tracer(); tracer != nil {
+			return tracer
+		}
+	}
+
+	return NopTracer
+}
+
+// TracingInterceptor - interceptor which wraps every call in a span named after the SDK function,
+// a child of the span in the context of the call. The span ends with the last response.
+func TracingInterceptor(tracer Tracer) Interceptor {
+	return func(ctx context.Context, method string, paramIn interface{}, next RequestFunc) (<-chan *ClientResponse, error) {
+		ctx, span := tracer.StartSpan(ctx, method)
+		span.SetAttribute("method", method)
+
+		responses, err := next(ctx, method, paramIn)
+		if err != nil {
+			span.RecordError(err)
+			span.End()
+			return nil, err
+		}
+
+		return watch(ctx, responses, func(*ClientResponse) {}, func(err error) {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}), nil
+	}
+}
+
+// TraceProcessing starts the phases of processing as children of the span in ctx.
+func TraceProcessing(ctx context.Context, tracer Tracer) *ProcessingTrace {
+	return &ProcessingTrace{ctx: ctx, tracer: tracer}
+}
+
+// Events - handler for Stream.Wait which starts a phase span for every Event frame, named after the event,
+// e.g. processing.WillSend, with message_id and shard_block_id attributes, and then calls handle if not nil.
+func (t *ProcessingTrace) Events(handle func(Frame) error) func(Frame) error {
+	return func(frame Frame) error {
+		if event, ok := frame.(Event); ok {
+			t.next(event.Data)
+		}
+		if handle == nil {
+			return nil
+		}
+
+		return handle(frame)
+	}
+}
+
+func (t *ProcessingTrace) next(data json.RawMessage) {
+	var event processingEventInfo
+	if err := json.Unmarshal(data, &event); err != nil || event.Type == "" {
+		return
+	}
+	t.End(nil)
+	_, t.phase = t.tracer.StartSpan(t.ctx, "processing."+event.Type)
+	if event.MessageID != "" {
+		t.phase.SetAttribute("message_id", event.MessageID)
+	}
+	if event.ShardBlockID != "" {
+		t.phase.SetAttribute("shard_block_id", event.ShardBlockID)
+	}
+	if event.Error != nil {
+		t.phase.RecordError(event.Error)
+	}
+}
+
+// End ends the current phase, recording err if processing failed in it.
+func (t *ProcessingTrace) End(err error) {
+	if t.phase == nil {
+		return
+	}
+	if err != nil {
+		t.phase.RecordError(err)
+	}
+	t.phase.End()
+	t.phase = nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	recordingTracer struct {
+		mu    sync.Mutex
+		spans []*recordedSpan
+	}
+
+	recordedSpan struct {
+		name       string
+		parent     string
+		attributes map[string]interface{}
+		err        error
+		ended      bool
+	}
+
+	spanKey struct{}
+)
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &recordedSpan{name: name, attributes: make(map[string]interface{})}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name
+	}
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (t *recordingTracer) find(name string) *recordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, span := range t.spans {
+		if span.name == name {
+			return span
+		}
+	}
+
+	return nil
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *recordedSpan) End() {
+	s.ended = true
+}
+
+func TestTracing(t *testing.T) {
+	t.Run("TestTracingInterceptor", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		requester := ChainInterceptors(func(ctx context.Context, method string, paramIn interface{}) (<-chan *ClientResponse, error) {
+			out := make(chan *ClientResponse, 1)
+			out <- &ClientResponse{Code: 1, Error: &ClientError{Code: 603}}
+			close(out)
+			return out, nil
+		}, TracingInterceptor(tracer))
+
+		ctx, parent := tracer.StartSpan(context.Background(), "query")
+		params := &marshalCounter{}
+		responses, err := requester(ctx, "net.query", params)
+		assert.Equal(t, nil, err)
+		_, err = ReadResponse(ctx, responses)
+		assert.NotEqual(t, nil, err)
+		parent.End()
+
+		span := tracer.find("net.query")
+		assert.Equal(t, "query", span.parent)
+		assert.Equal(t, "net.query", span.attributes["method"])
+		assert.Equal(t, 603, span.err.(*ClientError).Code)
+		assert.True(t, span.ended)
+		assert.Equal(t, 0, params.calls)
+	})
+
+	t.Run("TestProcessingTrace", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		ctx, span := tracer.StartSpan(context.Background(), "processing.ProcessMessage")
+		responses := make(chan *ClientResponse, 4)
+		responses <- &ClientResponse{Code: 100, Data: []byte(`{"type":"WillFetchFirstBlock","message_id":"m1"}`)}
+		responses <- &ClientResponse{Code: 100, Data: []byte(`{"type":"WillSend","shard_block_id":"b1","message_id":"m1","message":"te6"}`)}
+		responses <- &ClientResponse{Code: 100, Data: []byte(`{"type":"DidSend","shard_block_id":"b1","message_id":"m1","message":"te6"}`)}
+		responses <- &ClientResponse{Code: 0, Data: []byte(`{}`)}
+		close(responses)
+
+		var events []*ProcessingEvent
+		trace := TraceProcessing(ctx, tracer)
+		result := &ResultOfProcessMessage{}
+		err := NewStream(responses, nil).Wait(ctx, result, trace.Events(ProcessingEvents(func(event *ProcessingEvent) {
+			events = append(events, event)
+		})))
+		assert.Equal(t, nil, err)
+		trace.End(nil)
+		span.End()
+
+		assert.Equal(t, 3, len(events))
+		for _, name := range []string{"processing.WillFetchFirstBlock", "processing.WillSend", "processing.DidSend"} {
+			phase := tracer.find(name)
+			assert.Equal(t, "processing.ProcessMessage", phase.parent)
+			assert.Equal(t, "m1", phase.attributes["message_id"])
+			assert.True(t, phase.ended)
+		}
+		assert.Equal(t, "b1", tracer.find("processing.WillSend").attributes["shard_block_id"])
+		assert.Equal(t, nil, tracer.find("processing.WillFetchFirstBlock").attributes["shard_block_id"])
+	})
+}
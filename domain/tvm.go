@@ -1,11 +1,30 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
 )
 
+// Error codes of the tvm module.
+const (
+	TVMErrorCanNotReadTransaction      = 401
+	TVMErrorCanNotReadBlockchainConfig = 402
+	TVMErrorTransactionAborted         = 403
+	TVMErrorInternalError              = 404
+	TVMErrorActionPhaseFailed          = 405
+	TVMErrorAccountCodeMissing         = 406
+	TVMErrorLowBalance                 = 407
+	TVMErrorAccountFrozenOrDeleted     = 408
+	TVMErrorAccountMissing             = 409
+	TVMErrorUnknownExecutionError      = 410
+	TVMErrorInvalidInputStack          = 411
+	TVMErrorInvalidAccountBoc          = 412
+	TVMErrorInvalidMessageType         = 413
+	TVMErrorContractExecutionError     = 414
+)
+
 // TVMErrorCode ...
 var TVMErrorCode map[string]int
 
@@ -103,27 +122,30 @@ type (
 	// TvmUseCase ...
 	TvmUseCase interface {
 		RunExecutor(*ParamsOfRunExecutor) (*ResultOfRunExecuteMessage, error)
+		RunExecutorCtx(context.Context, *ParamsOfRunExecutor) (*ResultOfRunExecuteMessage, error)
 		RunTvm(*ParamsOfRunTvm) (*ResultOfRunTvm, error)
+		RunTvmCtx(context.Context, *ParamsOfRunTvm) (*ResultOfRunTvm, error)
 		RunGet(*ParamsOfRunGet) (*ResultOfRunGet, error)
+		RunGetCtx(context.Context, *ParamsOfRunGet) (*ResultOfRunGet, error)
 	}
 )
 
 func init() {
 	TVMErrorCode = map[string]int{
-		"CanNotReadTransaction      ": 401,
-		"CanNotReadBlockchainConfig ": 402,
-		"TransactionAborted         ": 403,
-		"InternalError              ": 404,
-		"ActionPhaseFailed          ": 405,
-		"AccountCodeMissing         ": 406,
-		"LowBalance                 ": 407,
-		"AccountFrozenOrDeleted     ": 408,
-		"AccountMissing             ": 409,
-		"UnknownExecutionError      ": 410,
-		"InvalidInputStack          ": 411,
-		"InvalidAccountBoc          ": 412,
-		"InvalidMessageType         ": 413,
-		"ContractExecutionError     ": 414,
+		"CanNotReadTransaction":      TVMErrorCanNotReadTransaction,
+		"CanNotReadBlockchainConfig": TVMErrorCanNotReadBlockchainConfig,
+		"TransactionAborted":         TVMErrorTransactionAborted,
+		"InternalError":              TVMErrorInternalError,
+		"ActionPhaseFailed":          TVMErrorActionPhaseFailed,
+		"AccountCodeMissing":         TVMErrorAccountCodeMissing,
+		"LowBalance":                 TVMErrorLowBalance,
+		"AccountFrozenOrDeleted":     TVMErrorAccountFrozenOrDeleted,
+		"AccountMissing":             TVMErrorAccountMissing,
+		"UnknownExecutionError":      TVMErrorUnknownExecutionError,
+		"InvalidInputStack":          TVMErrorInvalidInputStack,
+		"InvalidAccountBoc":          TVMErrorInvalidAccountBoc,
+		"InvalidMessageType":         TVMErrorInvalidMessageType,
+		"ContractExecutionError":     TVMErrorContractExecutionError,
 	}
 }
 
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -90,10 +91,15 @@ type (
 	//UtilsUseCase ...
 	UtilsUseCase interface {
 		ConvertAddress(*ParamsOfConvertAddress) (*ResultOfConvertAddress, error)
+		ConvertAddressCtx(context.Context, *ParamsOfConvertAddress) (*ResultOfConvertAddress, error)
 		GetAddressType(*ParamsOfGetAddressType) (*ResultOfGetAddressType, error)
+		GetAddressTypeCtx(context.Context, *ParamsOfGetAddressType) (*ResultOfGetAddressType, error)
 		CalcStorageFee(pOCA *ParamsOfCalcStorageFee) (*ResultOfCalcStorageFee, error)
+		CalcStorageFeeCtx(ctx context.Context, pOCA *ParamsOfCalcStorageFee) (*ResultOfCalcStorageFee, error)
 		CompressZstd(pOCA *ParamsOfCompressZstd) (*ResultOfCompressZstd, error)
+		CompressZstdCtx(ctx context.Context, pOCA *ParamsOfCompressZstd) (*ResultOfCompressZstd, error)
 		DecompressZstd(pOCA *ParamsOfDecompressZstd) (*ResultOfDecompressZstd, error)
+		DecompressZstdCtx(ctx context.Context, pOCA *ParamsOfDecompressZstd) (*ResultOfDecompressZstd, error)
 	}
 )
 
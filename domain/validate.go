@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type (
+	// ConfigFieldError - invalid field of a ClientConfig, e.g. Field "network.wait_for_timeout".
+	ConfigFieldError struct {
+		Field   string
+		Message string
+	}
+
+	// ConfigError - invalid fields of a ClientConfig found by Validate. It matches ErrInvalidConfig.
+	ConfigError struct {
+		Fields []*ConfigFieldError
+	}
+)
+
+func (e *ConfigFieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	return "invalid config: " + strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrInvalidConfig.
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig
+}
+
+// Validate checks the config before a context is created with it and returns a *ConfigError listing
+// the invalid fields. A config without the network section is valid, e.g. for offline use.
+func (c ClientConfig) Validate() error {
+	var fields []*ConfigFieldError
+	invalid := func(field, format string, args ...interface{}) {
+		fields = append(fields, &ConfigFieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n := c.Network; n != nil {
+		if len(n.Endpoints) == 0 && n.ServerAddress == "" {
+			invalid("network.endpoints", "no endpoints and no server_address; leave out the network section to work offline")
+		}
+		for i, endpoint := range n.Endpoints {
+			if strings.TrimSpace(endpoint) == "" {
+				invalid(fmt.Sprintf("network.endpoints[%d]", i), "empty endpoint")
+			}
+		}
+		switch n.QueriesProtocol {
+		case "", NetworkQueriesProtocolHTTP, NetworkQueriesProtocolWS:
+		default:
+			invalid("network.queries_protocol", "unknown protocol %q, expected %s or %s",
+				n.QueriesProtocol, NetworkQueriesProtocolHTTP, NetworkQueriesProtocolWS)
+		}
+	}
+	for _, field := range negativeDurations(c) {
+		invalid(field, "negative timeout")
+	}
+	if crypto := c.Crypto; crypto != nil {
+		if count := crypto.MnemonicWordCount; count != nil {
+			if _, ok := WordCountList()[*count]; !ok {
+				invalid("crypto.mnemonic_word_count", "%d words, expected one of 12, 15, 18, 21, 24", *count)
+			}
+		}
+		if dictionary := crypto.MnemonicDictionary; dictionary != nil {
+			known := false
+			for _, value := range DictionaryList() {
+				known = known || *value == *dictionary
+			}
+			if !known {
+				invalid("crypto.mnemonic_dictionary", "unknown dictionary %d, see DictionaryList", *dictionary)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &ConfigError{Fields: fields}
+}
+
+// negativeDurations returns the duration fields of c which are set to a negative value, see durationFields.
+func negativeDurations(c ClientConfig) []string {
+	var negative []string
+	config := reflect.ValueOf(c)
+	for name, field := range configFields(config.Type()) {
+		section := config.FieldByIndex(field.Index)
+		if section.Kind() != reflect.Ptr || section.IsNil() || section.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		for key, f := range configFields(section.Elem().Type()) {
+			value := section.Elem().FieldByIndex(f.Index)
+			path := name + "." + key
+			if durationFields[path] && value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Int() < 0 {
+				negative = append(negative, path)
+			}
+		}
+	}
+	sort.Strings(negative)
+
+	return negative
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("TestValid", func(t *testing.T) {
+		assert.Equal(t, nil, NewDefaultConfig("", []string{"https://devnet.evercloud.dev/p1/graphql"}, "").Validate())
+		assert.Equal(t, nil, NewDefaultConfig(BaseCustomUrl, nil, "").Validate())
+		assert.Equal(t, nil, ClientConfig{}.Validate())
+	})
+
+	t.Run("TestInvalid", func(t *testing.T) {
+		config := NewDefaultConfig("", []string{"https://a.dev", " "}, "")
+		config.Network.WaitForTimeout = util.IntToPointerInt(-1)
+		config.Network.MessageRetriesCount = util.IntToPointerInt(-1)
+		config.Network.QueriesProtocol = "UDP"
+		config.Abi.MessageExpirationTimeout = util.IntToPointerInt(-40000)
+		config.Crypto.MnemonicWordCount = util.IntToPointerInt(13)
+		config.Crypto.MnemonicDictionary = util.IntToPointerInt(9)
+
+		err := config.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidConfig))
+		var configErr *ConfigError
+		assert.True(t, errors.As(err, &configErr))
+		var fields []string
+		for _, field := range configErr.Fields {
+			fields = append(fields, field.Field)
+		}
+		assert.Equal(t, []string{
+			"network.endpoints[1]",
+			"network.queries_protocol",
+			"abi.message_expiration_timeout",
+			"network.wait_for_timeout",
+			"crypto.mnemonic_word_count",
+			"crypto.mnemonic_dictionary",
+		}, fields)
+	})
+
+	t.Run("TestNoEndpoints", func(t *testing.T) {
+		err := NewDefaultConfig("", nil, "").Validate()
+		assert.Equal(t, "invalid config: network.endpoints: no endpoints and no server_address; leave out the network section to work offline", err.Error())
+	})
+}
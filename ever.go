@@ -2,7 +2,8 @@ package goever
 
 import (
 	"github.com/move-ton/ever-client-go/domain"
-	clientgw "github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/pool"
+	"github.com/move-ton/ever-client-go/gateway/remote"
 	"github.com/move-ton/ever-client-go/usecase/abi"
 	"github.com/move-ton/ever-client-go/usecase/boc"
 	"github.com/move-ton/ever-client-go/usecase/crypto"
@@ -28,9 +29,120 @@ type Ever struct {
 	Utils      domain.UtilsUseCase
 }
 
+type (
+	// Option - configures NewEverWithConfig.
+	Option func(*options)
+
+	options struct {
+		newGateway  func(config domain.ClientConfig) (domain.ClientGateway, error)
+		poolSize    int
+		poolOptions []pool.Option
+		logger      domain.Logger
+		metrics     domain.Metrics
+		tracer      domain.Tracer
+		limiter     *domain.Limiter
+		retryPolicy *domain.RetryPolicy
+		libraryPath string
+	}
+)
+
+// WithGateway - uses an already created gateway instead of creating a new one.
+func WithGateway(client domain.ClientGateway) Option {
+	return func(o *options) {
+		o.newGateway = func(domain.ClientConfig) (domain.ClientGateway, error) {
+			return client, nil
+		}
+	}
+}
+
+// WithRemoteGateway - talks to a tonclient server at url instead of the linked library. Needs no cgo.
+func WithRemoteGateway(url string, opts ...remote.Option) Option {
+	return func(o *options) {
+		o.newGateway = func(config domain.ClientConfig) (domain.ClientGateway, error) {
+			return remote.NewRemoteGateway(url, config, append([]remote.Option{
+				remote.WithLogger(o.logger), remote.WithMetrics(o.metrics), remote.WithTracer(o.tracer),
+				remote.WithLimiter(o.limiter),
+			}, opts...)...)
+		}
+	}
+}
+
+// WithLibraryPath - loads libton_client from path instead of linking it. Needs cgo and the ever_dlopen build tag:
+// without cgo NewEverWithConfig fails, without the tag the library gateway rejects the path.
+func WithLibraryPath(path string) Option {
+	return func(o *options) {
+		o.libraryPath = path
+		o.newGateway = func(config domain.ClientConfig) (domain.ClientGateway, error) {
+			return newLibraryGateway(config, o)
+		}
+	}
+}
+
+// WithPool - opens size contexts of the selected gateway and balances requests between them, see pool.PoolGateway.
+func WithPool(size int, opts ...pool.Option) Option {
+	return func(o *options) {
+		o.poolSize = size
+		o.poolOptions = opts
+	}
+}
+
+// WithLogger - passes logger to the library and remote gateways, see domain.LoggingInterceptor.
+// It has no effect on a gateway given with WithGateway.
+func WithLogger(logger domain.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMetrics - passes metrics to the library and remote gateways, see domain.MetricsInterceptor.
+// It has no effect on a gateway given with WithGateway.
+func WithMetrics(metrics domain.Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// WithTracer - passes tracer to the library and remote gateways, see domain.TracingInterceptor.
+// Processing use cases trace their phases with it. It has no effect on a gateway given with WithGateway.
+func WithTracer(tracer domain.Tracer) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// WithLimiter - limits the requests of the library and remote gateways, see domain.Limiter.
+// A pool shares the limiter between its contexts. It has no effect on a gateway given with WithGateway.
+func WithLimiter(limiter *domain.Limiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithRetryPolicy - retries the idempotent calls of Ever.Net after network failures, see net.NewRetryingNet.
+// Processing calls such as SendMessage are never retried.
+func WithRetryPolicy(policy domain.RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &policy
+	}
+}
+
 // NewEverWithConfig ...
-func NewEverWithConfig(config domain.ClientConfig) (*Ever, error) {
-	client, err := clientgw.NewClientGateway(config)
+func NewEverWithConfig(config domain.ClientConfig, opts ...Option) (*Ever, error) {
+	o := &options{}
+	o.newGateway = func(config domain.ClientConfig) (domain.ClientGateway, error) {
+		return newLibraryGateway(config, o)
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	newGateway := o.newGateway
+	if o.poolSize != 0 {
+		newGateway = func(config domain.ClientConfig) (domain.ClientGateway, error) {
+			return pool.NewPoolGateway(config, o.poolSize, o.newGateway, o.poolOptions...)
+		}
+	}
+	client, err := newGateway(config)
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +158,25 @@ func NewEverWithConfig(config domain.ClientConfig) (*Ever, error) {
 		Tvm:        tvm.NewTvm(config, client),
 		Utils:      utils.NewUtils(config, client),
 	}
+	if o.retryPolicy != nil {
+		ever.Net = net.NewRetryingNet(ever.Net, *o.retryPolicy)
+	}
 	return ever, nil
 }
 
 // NewEver ...
-func NewEver(address string, endPoints []string, accessKey string) (*Ever, error) {
+func NewEver(address string, endPoints []string, accessKey string, opts ...Option) (*Ever, error) {
 	conf := domain.NewDefaultConfig(address, endPoints, accessKey)
-	return NewEverWithConfig(conf)
+	return NewEverWithConfig(conf, opts...)
+}
+
+// NewEverForNetwork - NewEverWithConfig with the config of the profile called network in domain.Networks,
+// e.g. "mainnet", "devnet", "local" or a registered one.
+func NewEverForNetwork(network string, opts ...Option) (*Ever, error) {
+	config, err := domain.Networks.Config(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEverWithConfig(config, opts...)
 }
@@ -0,0 +1,23 @@
+//go:build cgo
+// +build cgo
+
+package goever
+
+import (
+	"github.com/move-ton/ever-client-go/domain"
+	clientgw "github.com/move-ton/ever-client-go/gateway/client"
+)
+
+func newLibraryGateway(config domain.ClientConfig, o *options) (domain.ClientGateway, error) {
+	opts := []clientgw.Option{
+		clientgw.WithLogger(o.logger),
+		clientgw.WithMetrics(o.metrics),
+		clientgw.WithTracer(o.tracer),
+		clientgw.WithLimiter(o.limiter),
+	}
+	if o.libraryPath != "" {
+		opts = append(opts, clientgw.WithLibraryPath(o.libraryPath))
+	}
+
+	return clientgw.NewClientGateway(config, opts...)
+}
@@ -0,0 +1,14 @@
+//go:build !cgo
+// +build !cgo
+
+package goever
+
+import (
+	"errors"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+func newLibraryGateway(domain.ClientConfig, *options) (domain.ClientGateway, error) {
+	return nil, errors.New("goever: built without cgo, use WithRemoteGateway or WithGateway")
+}
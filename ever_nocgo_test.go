@@ -0,0 +1,18 @@
+//go:build !cgo
+// +build !cgo
+
+package goever
+
+import (
+	"testing"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEverNoCgo(t *testing.T) {
+	t.Run("TestWithLibraryPath", func(t *testing.T) {
+		_, err := NewEverWithConfig(domain.NewDefaultConfig("", []string{"http://localhost"}, ""), WithLibraryPath("/usr/lib/libton_client.so"))
+		assert.EqualError(t, err, "goever: built without cgo, use WithRemoteGateway or WithGateway")
+	})
+}
@@ -0,0 +1,34 @@
+package goever
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEver(t *testing.T) {
+	t.Run("TestRetryPolicySkipsProcessing", func(t *testing.T) {
+		fake := clientmock.NewFake()
+		defer fake.Destroy()
+		fake.On("processing.send_message").Fail(domain.ClientError{Code: domain.NetErrorWebsocketDisconnected, Message: "Websocket disconnected"})
+
+		retries := 0
+		ever, err := NewEverWithConfig(domain.NewDefaultConfig("", []string{"http://localhost"}, ""), WithGateway(fake), WithRetryPolicy(domain.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			OnRetry: func(attempt int, err error, delay time.Duration) {
+				retries++
+			},
+		}))
+		assert.Equal(t, nil, err)
+
+		_, err = ever.Processing.SendMessage(&domain.ParamsOfSendMessage{Message: "te6"}, func(*domain.ProcessingEvent) {})
+		assert.True(t, errors.Is(err, domain.ErrWebsocketDisconnected))
+		assert.Equal(t, 1, len(fake.CallsOf("processing.send_message")))
+		assert.Equal(t, 0, retries)
+	})
+}
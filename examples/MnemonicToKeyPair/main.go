@@ -19,7 +19,7 @@ goever "github.com/move-ton/ever-client-go"
 )
 
 func main() {
-	ever, err := goever.NewEver("", domain.GetDevNetBaseUrls(), "")
+	ever, err := goever.NewEverForNetwork("devnet")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -15,7 +15,7 @@ import (
 )
 
 func main() {
-	ever, err := goever.NewEver("", domain.GetDevNetBaseUrls())
+	ever, err := goever.NewEverForNetwork("devnet")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -2,14 +2,13 @@ package main
 
 import (
 	"fmt"
-	"github.com/move-ton/ever-client-go/domain"
 	"log"
 
 	goever "github.com/move-ton/ever-client-go"
 )
 
 func main() {
-	ever, err := goever.NewEver("", domain.GetDevNetBaseUrls(), "")
+	ever, err := goever.NewEverForNetwork("devnet")
 	if err != nil {
 		log.Fatal(err)
 	}
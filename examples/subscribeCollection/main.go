@@ -11,7 +11,10 @@ import (
 )
 
 func main() {
-	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	config, err := domain.Networks.Config("devnet")
+	if err != nil {
+		log.Fatal(err)
+	}
 	clientConn, err := client.NewClientGateway(config)
 	if err != nil {
 		log.Fatal("Error to connect: ", err.Error())
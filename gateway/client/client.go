@@ -1,112 +1,321 @@
 package client
 
 /*
-#cgo darwin LDFLAGS: -L${SRCDIR}/lib/darwin -lton_client
-#cgo linux LDFLAGS: -L${SRCDIR}/lib/linux -lton_client
-#cgo windows LDFLAGS: -L${SRCDIR}/lib/windows -lton_client
-
 #include "client_method.h"
-void callB(uint32_t request_id, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
+void callB(uint64_t key, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
 
 */
 import "C"
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
+	"strconv"
+	"sync"
 	"unsafe"
 
 	"github.com/move-ton/ever-client-go/domain"
 )
 
 const (
-	// VersionLibSDK ...
+	// VersionLibSDK - libton_client version the bindings are written for.
 	VersionLibSDK = "1.40.0"
 )
 
-var mainStore = NewStore()
+// releasedOnClose - kinds of handles which Close releases before destroying the context,
+// so that their streams end normally.
+var releasedOnClose = map[string]bool{
+	"subscription": true,
+	"iterator":     true,
+}
+
+type (
+	clientGateway struct {
+		client        C.uint32_t
+		config        domain.ClientConfig
+		closeCanals   chan struct{}
+		interceptors  []domain.Interceptor
+		requester     domain.RequestFunc
+		versionPolicy VersionPolicy
+		libraryPath   string
+		capabilities  *domain.Capabilities
+		registry      *registry
+		errorHandler  domain.ErrorHandler
+		logger        domain.Logger
+		metrics       domain.Metrics
+		tracer        domain.Tracer
+		limiter       *domain.Limiter
+		appObjectOpts []domain.AppObjectOption
+		appObjects    *domain.AppObjectDispatcher
+
+		mu        sync.Mutex
+		closing   bool
+		closeOnce sync.Once
+		pending   int
+		drained   chan struct{}
+		handles   map[*domain.HandleKind]map[int]bool
+		streams   sync.WaitGroup
+	}
+
+	// Option - configures NewClientGateway.
+	Option func(*clientGateway)
+)
+
+// WithInterceptors - wraps every request of the gateway, including streamed events and app requests, with interceptors.
+// The first interceptor is the outermost one.
+func WithInterceptors(interceptors ...domain.Interceptor) Option {
+	return func(c *clientGateway) {
+		c.interceptors = append(c.interceptors, interceptors...)
+	}
+}
 
-type clientGateway struct {
-	client      C.uint32_t
-	config      domain.ClientConfig
-	closeCanals chan struct{}
+// WithErrorHandler - receives the errors of app object callbacks, see domain.AppObjectDispatcher and domain.ResolveAppObject.
+// domain.DefaultErrorHandler logs them by default.
+func WithErrorHandler(handler domain.ErrorHandler) Option {
+	return func(c *clientGateway) {
+		c.errorHandler = handler
+	}
 }
 
-func tcStringData(in []byte) C.tc_string_data_t {
-	return C.tc_string_data_t{
-		len:     C.uint32_t(len(in)),
-		content: C.CString(string(in)),
+// WithLogger - logs the requests of the gateway, see domain.LoggingInterceptor, and the errors of app object
+// callbacks and subscriptions unless WithErrorHandler is given. The logging interceptor is the outermost one.
+func WithLogger(logger domain.Logger) Option {
+	return func(c *clientGateway) {
+		c.logger = logger
 	}
 }
 
-func tcStringToByte(data C.tc_string_data_t) []byte {
-	if data.len == 0 {
-		return nil
+// WithMetrics - reports the requests, subscriptions and app requests of the gateway to metrics,
+// see domain.MetricsInterceptor. It wraps the interceptors of WithInterceptors.
+func WithMetrics(metrics domain.Metrics) Option {
+	return func(c *clientGateway) {
+		c.metrics = metrics
+	}
+}
+
+// WithTracer - wraps every request of the gateway in a span, see domain.TracingInterceptor, and lets the use cases
+// trace their work, see domain.TracerOf. It wraps the interceptors of WithInterceptors.
+func WithTracer(tracer domain.Tracer) Option {
+	return func(c *clientGateway) {
+		c.tracer = tracer
+	}
+}
+
+// WithLimiter - holds the requests of the gateway until limiter lets them through, see domain.Limiter.
+// A limiter may be shared by gateways using the same quota. It wraps the interceptors of WithInterceptors.
+func WithLimiter(limiter *domain.Limiter) Option {
+	return func(c *clientGateway) {
+		c.limiter = limiter
+	}
+}
+
+// WithAppObjectOptions - configures the dispatcher of app object callbacks, see domain.AppObjectDispatcher.
+func WithAppObjectOptions(opts ...domain.AppObjectOption) Option {
+	return func(c *clientGateway) {
+		c.appObjectOpts = append(c.appObjectOpts, opts...)
 	}
+}
 
-	return C.GoBytes(unsafe.Pointer(data.content), C.int(data.len))
+// WithLibraryPath - loads libton_client from path. Needs the ever_dlopen build tag, see loadLibrary.
+func WithLibraryPath(path string) Option {
+	return func(c *clientGateway) {
+		c.libraryPath = path
+	}
 }
 
-// NewClientGateway ...
-func NewClientGateway(config domain.ClientConfig) (domain.ClientGateway, error) {
+// NewClientGateway creates a context of the linked library and checks its version, see WithVersionPolicy.
+// An invalid config is reported as *domain.ConfigError, or as the *domain.ClientError of the library;
+// both match domain.ErrInvalidConfig.
+func NewClientGateway(config domain.ClientConfig, opts ...Option) (domain.ClientGateway, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 	cc := clientGateway{
 		config:      config,
 		closeCanals: make(chan struct{}),
+		drained:     make(chan struct{}),
+		handles:     make(map[*domain.HandleKind]map[int]bool),
+	}
+	cc.registry = newRegistry(cc.closeCanals)
+	for _, opt := range opts {
+		opt(&cc)
+	}
+	if cc.limiter != nil {
+		cc.interceptors = append([]domain.Interceptor{cc.limiter.Interceptor()}, cc.interceptors...)
+	}
+	if cc.metrics != nil {
+		cc.interceptors = append([]domain.Interceptor{domain.MetricsInterceptor(cc.metrics)}, cc.interceptors...)
 	}
+	if cc.tracer != nil {
+		cc.interceptors = append([]domain.Interceptor{domain.TracingInterceptor(cc.tracer)}, cc.interceptors...)
+	}
+	if cc.logger != nil {
+		cc.interceptors = append([]domain.Interceptor{domain.LoggingInterceptor(cc.logger)}, cc.interceptors...)
+		if cc.errorHandler == nil {
+			cc.errorHandler = domain.LogErrors(cc.logger)
+		}
+	}
+	if err := loadLibrary(cc.libraryPath); err != nil {
+		cc.registry.unregister()
+		return nil, err
+	}
+	// The version check is not passed to the interceptors.
+	cc.requester = cc.request
 
 	configTrf, err := json.Marshal(config)
 	if err != nil {
+		cc.registry.unregister()
 		return nil, err
 	}
 
-	handler := C.tc_create_context(tcStringData(configTrf))
+	args := newCStrings(configTrf)
+	handler := C.tc_create_context(args.strings[0])
+	args.free()
 	defer C.tc_destroy_string(handler)
 	response := tcStringToByte(C.tc_read_string(handler))
 
 	var skdResponse SDKResponse
 	err = json.Unmarshal(response, &skdResponse)
 	if err != nil {
+		cc.registry.unregister()
 		return nil, err
 	}
 	if skdResponse.Error != nil {
-		fmt.Println(skdResponse.Error)
+		cc.registry.unregister()
+		if cc.logger != nil {
+			cc.logger.Log(context.Background(), domain.LogError, "context not created", domain.KV("error", skdResponse.Error))
+		}
+		return nil, skdResponse.Error
 	}
 	cc.client = C.uint32_t(skdResponse.Result)
+	cc.capabilities = domain.NewCapabilities(cc.GetAPIReference)
+	if err := cc.checkVersion(); err != nil {
+		cc.Destroy()
+		return nil, err
+	}
+	cc.requester = domain.ChainInterceptors(cc.request, cc.interceptors...)
+	cc.appObjects = domain.NewAppObjectDispatcher(&cc, cc.appObjectOpts...)
 
 	return &cc, nil
 }
 
+// Destroy - closes the gateway without waiting for in-flight requests, see Close.
 func (c *clientGateway) Destroy() {
-	C.tc_destroy_context(c.client)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = c.Close(ctx)
+}
+
+// Close - stops accepting requests and waits until the in-flight ones get their results or ctx is done.
+// Then it unsubscribes the open subscriptions, removes the iterators and destroys the context.
+// Requests still waiting after that fail with *domain.ClientClosedError.
+// Streams that stay open after their result (subscriptions, app objects) are not waited for.
+// Returns ctx.Err() if ctx was done before the requests finished; later calls return nil.
+func (c *clientGateway) Close(ctx context.Context) error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closing = true
+		if c.pending == 0 {
+			close(c.drained)
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-c.drained:
+		case <-ctx.Done():
+		}
+		if err = ctx.Err(); err == nil {
+			err = c.releaseHandles(ctx)
+		}
+		if err == nil {
+			err = c.waitStreams(ctx)
+		}
+		close(c.closeCanals)
+		C.tc_destroy_context(c.client)
+		c.registry.unregister()
+	})
+
+	return err
+}
+
+// waitStreams - waits until the streams of the released handles end.
+func (c *clientGateway) waitStreams(ctx context.Context) error {
+	ended := make(chan struct{})
+	go func() {
+		c.streams.Wait()
+		close(ended)
+	}()
+	select {
+	case <-ended:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseHandles - releases the handles of releasedOnClose kinds.
+func (c *clientGateway) releaseHandles(ctx context.Context) error {
+	c.mu.Lock()
+	var release []func() error
+	for kind, handles := range c.handles {
+		for handle := range handles {
+			kind, params := kind, map[string]int{kind.ReleaseField: handle}
+			release = append(release, func() error {
+				responses, err := c.send(ctx, kind.Release, params)
+				if err != nil {
+					return err
+				}
+				_, err = domain.ReadResponse(ctx, responses)
+				return err
+			})
+		}
+	}
+	c.mu.Unlock()
+
+	var firstErr error
+	for _, r := range release {
+		if err := r(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
 }
 
 //export callB
-func callB(requestIDin C.uint32_t, paramsJSON C.tc_string_data_t, responseTypein C.uint32_t, finishedin C.bool) {
-	requestID := uint32(requestIDin)
+func callB(key C.uint64_t, paramsJSON C.tc_string_data_t, responseTypein C.uint32_t, finishedin C.bool) {
 	params := C.GoBytes(unsafe.Pointer(paramsJSON.content), C.int(paramsJSON.len))
 	responseType := uint32(responseTypein)
 	finished := bool(finishedin)
 
-	responses, closeSignal, isFound := mainStore.GetChannels(requestID, finished)
-	if !isFound {
+	r, ok := lookupRegistry(uint32(key >> 32))
+	if !ok {
+		return
+	}
+	requestID := uint32(key)
+	e, ok := r.get(requestID, finished)
+	if !ok {
 		return
 	}
+	e.received(responseType, finished)
 
 	if responseType == 2 {
 		if finished {
-			close(responses)
+			close(e.responses)
 		}
 		return
 	}
 
 	select {
-	case responses <- newResponse(params, responseType):
+	case e.responses <- newResponse(params, responseType):
 		if finished {
-			close(responses)
+			close(e.responses)
 		}
-	case <-closeSignal:
-		close(responses)
-		mainStore.DeleteRequestID(requestID)
+	case <-r.closed:
+		// The stream is left open: watch ends it with a *domain.ClientClosedError.
+		r.delete(requestID)
+	case <-e.done:
+		r.delete(requestID)
 	}
 }
 
@@ -115,7 +324,7 @@ func newResponse(rawBytes []byte, responseType uint32) *domain.ClientResponse {
 		Code: responseType,
 	}
 	if responseType == 1 {
-		res.Error = errors.New(string(rawBytes))
+		res.Error = domain.NewClientError(rawBytes)
 	} else {
 		res.Data = rawBytes
 	}
@@ -124,7 +333,12 @@ func newResponse(rawBytes []byte, responseType uint32) *domain.ClientResponse {
 }
 
 func (c *clientGateway) GetResult(method string, paramIn interface{}, resultStruct interface{}) error {
-	rawData, err := c.GetResponse(method, paramIn)
+	return c.GetResultContext(context.Background(), method, paramIn, resultStruct)
+}
+
+// GetResultContext - like GetResult, but gives up waiting when ctx is done.
+func (c *clientGateway) GetResultContext(ctx context.Context, method string, paramIn interface{}, resultStruct interface{}) error {
+	rawData, err := c.GetResponseContext(ctx, method, paramIn)
 	if err != nil {
 		return err
 	}
@@ -132,45 +346,208 @@ func (c *clientGateway) GetResult(method string, paramIn interface{}, resultStru
 }
 
 func (c *clientGateway) Request(method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
-	var (
-		rawBody []byte
-		err     error
-	)
+	return c.RequestContext(context.Background(), method, paramIn)
+}
 
-	if paramIn != nil {
-		rawBody, err = json.Marshal(paramIn)
-		if err != nil {
-			return nil, err
+// RequestContext - like Request, but the request is dropped when ctx is done:
+// the returned channel is closed and late responses from the library are discarded.
+func (c *clientGateway) RequestContext(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	return c.requester(ctx, method, paramIn)
+}
+
+func (c *clientGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.closing {
+		c.mu.Unlock()
+		return nil, &domain.ClientClosedError{Method: method}
+	}
+	c.pending++
+	c.mu.Unlock()
+
+	responses, err := c.send(ctx, method, paramIn)
+	if err != nil {
+		c.settle()
+		return nil, err
+	}
+	kind, ok := domain.HandleKindOf(method)
+	if ok && releasedOnClose[kind.Name] {
+		c.streams.Add(1)
+	} else {
+		kind = nil
+	}
+
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		if kind != nil {
+			defer c.streams.Done()
+		}
+		settled := false
+		defer func() {
+			if !settled {
+				c.settle()
+			}
+		}()
+		for r := range responses {
+			if !settled && (r.Code == 0 || r.Code == 1) {
+				if kind != nil && r.Code == 0 {
+					c.remember(kind, r.Data)
+				}
+				settled = true
+				c.settle()
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
+
+	return out, nil
+}
+
+// InFlight lists the requests which have not finished yet, oldest first, including open streams.
+func (c *clientGateway) InFlight() []RequestInfo {
+	return c.registry.inFlight()
+}
+
+// send - passes the request to the library. The returned stream ends when the request finishes,
+// when ctx is done or, with a *domain.ClientClosedError, when the gateway is closed.
+func (c *clientGateway) send(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	body := getBuffer()
+	defer putBuffer(body)
+	if err := encodeParams(body, paramIn); err != nil {
+		return nil, err
 	}
+	c.forget(method, body.Bytes())
 
 	responsChan := make(chan *domain.ClientResponse, 1)
-	requestID := mainStore.SetChannels(responsChan, c.closeCanals)
-	C.tc_request(c.client, tcStringData([]byte(method)), tcStringData(rawBody), C.uint32_t(requestID), C.tc_response_handler_t(C.callB))
-	return responsChan, nil
+	requestID, key := c.registry.add(method, responsChan, ctx.Done())
+	args := newCStrings([]byte(method), body.Bytes())
+	request(c.client, args.strings[0], args.strings[1], key)
+	args.free()
+
+	return c.watch(ctx, method, requestID, responsChan), nil
+}
+
+// watch forwards responses until the request finishes, ctx is done or the gateway is closed.
+// In the latter cases the request is removed from the store, so callB drops its late responses.
+func (c *clientGateway) watch(ctx context.Context, method string, requestID uint32, in <-chan *domain.ClientResponse) <-chan *domain.ClientResponse {
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case r, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					c.registry.delete(requestID)
+					return
+				}
+			case <-ctx.Done():
+				c.registry.delete(requestID)
+				return
+			case <-c.closeCanals:
+				c.registry.delete(requestID)
+				select {
+				case out <- &domain.ClientResponse{Code: 1, Error: &domain.ClientClosedError{Method: method}}:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// settle - marks an in-flight request as finished.
+func (c *clientGateway) settle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending--
+	if c.closing && c.pending == 0 {
+		close(c.drained)
+	}
+}
+
+// remember - records the handle created by a request, so that Close can release it.
+func (c *clientGateway) remember(kind *domain.HandleKind, result []byte) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(result, &fields) != nil {
+		return
+	}
+	handle, err := strconv.Atoi(string(fields[kind.ResultField]))
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.handles[kind] == nil {
+		c.handles[kind] = make(map[int]bool)
+	}
+	c.handles[kind][handle] = true
+}
+
+// forget - drops the handle released by a request.
+func (c *clientGateway) forget(method string, params []byte) {
+	for _, kind := range domain.HandleKinds {
+		if kind.Release != method || !releasedOnClose[kind.Name] {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(params, &fields) != nil {
+			return
+		}
+		handle, err := strconv.Atoi(string(fields[kind.ReleaseField]))
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		delete(c.handles[kind], handle)
+		c.mu.Unlock()
+	}
 }
 
 func (c *clientGateway) GetResponse(method string, paramIn interface{}) ([]byte, error) {
-	responsChan, err := c.Request(method, paramIn)
+	return c.GetResponseContext(context.Background(), method, paramIn)
+}
+
+// GetResponseContext - like GetResponse, but returns ctx.Err() when ctx is done before the result arrives.
+// Only the result (code 0) is returned, like domain.ReadResponse: events and app requests are skipped.
+func (c *clientGateway) GetResponseContext(ctx context.Context, method string, paramIn interface{}) ([]byte, error) {
+	responsChan, err := c.RequestContext(ctx, method, paramIn)
 	if err != nil {
 		return nil, err
 	}
-	var data []byte
 
-	for {
-		select {
-		case r, ok := <-responsChan:
-			if !ok {
-				return data, err
-			}
-			if r.Error != nil && err == nil {
-				err = r.Error
-			}
-			if r.Data != nil && data == nil {
-				data = r.Data
-			}
-		case <-c.closeCanals:
-			return nil, errors.New("channels is closed")
-		}
+	return domain.ReadResponse(ctx, responsChan)
+}
+
+// Call - calls any SDK function and returns its raw result, e.g. before it has a typed wrapper.
+func (c *clientGateway) Call(method string, params interface{}) (json.RawMessage, error) {
+	return c.CallContext(context.Background(), method, params)
+}
+
+// CallContext - Call bounded by ctx.
+func (c *clientGateway) CallContext(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	return c.GetResponseContext(ctx, method, params)
+}
+
+// CallStream - streaming counterpart of Call, see domain.ReadStream.
+func (c *clientGateway) CallStream(ctx context.Context, method string, params interface{}, handler domain.StreamHandler) (json.RawMessage, error) {
+	responses, err := c.RequestContext(ctx, method, params)
+	if err != nil {
+		return nil, err
 	}
+
+	return domain.ReadStream(ctx, responses, handler)
 }
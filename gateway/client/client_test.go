@@ -1,21 +1,29 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"github.com/move-ton/ever-client-go/util"
+	"log"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test(t *testing.T) {
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls())
+	configConn, err := domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientConn, err := NewClientGateway(configConn)
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
 
 	t.Run("TestConfigFields", func(t *testing.T) {
-		defConf := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls())
+		defConf := domain.NewDefaultConfig("", configConn.Network.Endpoints, "")
 		defConf.Abi.MessageExpirationTimeout = util.IntToPointerInt(0)
 		defConf.Network.MaxReconnectTimeOut = util.IntToPointerInt(100)
 		assert.Equal(t, defConf.Crypto.MnemonicWordCount, util.IntToPointerInt(domain.DefaultWordCount))
@@ -40,4 +48,171 @@ func Test(t *testing.T) {
 		assert.Equal(t, nil, err)
 		assert.NotNil(t, buildInfo.BuildNumber)
 	})
+	t.Run("TestTypedError", func(t *testing.T) {
+		_, err := clientConn.GetResponse("client.unknown_function", nil)
+		assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+		var clientErr *domain.ClientError
+		assert.True(t, errors.As(err, &clientErr))
+		assert.Equal(t, VersionLibSDK, clientErr.Details().CoreVersion)
+	})
+
+	t.Run("TestInterceptors", func(t *testing.T) {
+		var methods []string
+		observed, err := NewClientGateway(configConn, WithInterceptors(func(ctx context.Context, method string, paramIn interface{}, next domain.RequestFunc) (<-chan *domain.ClientResponse, error) {
+			methods = append(methods, method)
+			return next(ctx, method, paramIn)
+		}))
+		assert.Equal(t, nil, err)
+		defer observed.Destroy()
+
+		_, err = observed.Version()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []string{"client.version"}, methods)
+	})
+
+	t.Run("TestContextCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := clientConn.GetResponseContext(ctx, "client.version", nil)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("TestContextDeadline", func(t *testing.T) {
+		goroutines := runtime.NumGoroutine()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		result := new(domain.ResultOfWaitForCollection)
+		err := clientConn.GetResultContext(ctx, "net.wait_for_collection", &domain.ParamsOfWaitForCollection{
+			Collection: "transactions",
+			Filter:     json.RawMessage(`{"now":{"gt":4102444800}}`),
+			Result:     "id",
+		}, result)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		version, err := clientConn.Version()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, VersionLibSDK, version.Version)
+		for i := 0; i < 50 && runtime.NumGoroutine() > goroutines; i++ {
+			time.Sleep(100 * time.Millisecond)
+		}
+		assert.LessOrEqual(t, runtime.NumGoroutine(), goroutines)
+	})
+
+	t.Run("TestClose", func(t *testing.T) {
+		gw, err := NewClientGateway(configConn)
+		assert.Equal(t, nil, err)
+		done := make(chan error, 1)
+		go func() {
+			_, err := gw.GetResponse("net.wait_for_collection", &domain.ParamsOfWaitForCollection{Collection: "blocks", Result: "id"})
+			done <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		assert.Equal(t, nil, gw.Close(context.Background()))
+		assert.Equal(t, nil, <-done)
+		_, err = gw.Version()
+		assert.True(t, errors.Is(err, domain.ErrClientClosed))
+		assert.Equal(t, nil, gw.Close(context.Background()))
+	})
+
+	t.Run("TestCloseDeadline", func(t *testing.T) {
+		gw, err := NewClientGateway(configConn)
+		assert.Equal(t, nil, err)
+		done := make(chan error, 1)
+		go func() {
+			_, err := gw.GetResponse("net.wait_for_collection", &domain.ParamsOfWaitForCollection{Collection: "blocks", Result: "id"})
+			done <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, gw.Close(ctx))
+		err = <-done
+		var closedErr *domain.ClientClosedError
+		assert.True(t, errors.As(err, &closedErr))
+		assert.Equal(t, "net.wait_for_collection", closedErr.Method)
+	})
+
+	t.Run("TestCloseUnsubscribes", func(t *testing.T) {
+		gw, err := NewClientGateway(configConn)
+		assert.Equal(t, nil, err)
+		responses, err := gw.Request("net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "blocks", Result: "id"})
+		assert.Equal(t, nil, err)
+		first := <-responses
+		assert.Equal(t, uint32(0), first.Code)
+
+		inFlight := gw.(Inspector).InFlight()
+		assert.Equal(t, 1, len(inFlight))
+		assert.Equal(t, "net.subscribe_collection", inFlight[0].Method)
+		assert.Equal(t, StateOpen, inFlight[0].State)
+
+		assert.Equal(t, nil, gw.Close(context.Background()))
+		assert.Equal(t, 0, len(gw.(Inspector).InFlight()))
+		for r := range responses {
+			assert.Equal(t, nil, r.Error)
+		}
+	})
+
+	t.Run("TestSupports", func(t *testing.T) {
+		assert.True(t, clientConn.Supports("client.version"))
+		assert.False(t, clientConn.Supports("client.unknown_function"))
+	})
+
+	t.Run("TestVersionPolicy", func(t *testing.T) {
+		strict, err := NewClientGateway(configConn, WithVersionPolicy(VersionStrict))
+		assert.Equal(t, nil, err)
+		strict.Destroy()
+
+		assert.True(t, SupportedVersion(VersionLibSDK))
+		assert.True(t, SupportedVersion("1.41.2-rc"))
+		assert.False(t, SupportedVersion("1.39.9"))
+		assert.False(t, SupportedVersion("2.0.0"))
+		assert.Equal(t, "libton_client 1.2.0 (build 7) is not supported, want >= 1.40.0 and < 2.0.0", (&VersionError{Version: "1.2.0", BuildNumber: 7}).Error())
+	})
+
+	t.Run("TestInvalidConfig", func(t *testing.T) {
+		invalid := domain.NewDefaultConfig("", nil, "")
+		invalid.Crypto.MnemonicWordCount = util.IntToPointerInt(13)
+		_, err := NewClientGateway(invalid)
+		assert.True(t, errors.Is(err, domain.ErrInvalidConfig))
+		var configErr *domain.ConfigError
+		assert.True(t, errors.As(err, &configErr))
+		assert.Equal(t, 2, len(configErr.Fields))
+
+		_, err = NewClientGateway(domain.NewDefaultConfig("", []string{"invalid://"}, ""))
+		assert.True(t, errors.Is(err, domain.ErrInvalidConfig))
+		var clientErr *domain.ClientError
+		assert.True(t, errors.As(err, &clientErr))
+
+		offline, err := NewClientGateway(domain.ClientConfig{})
+		assert.Equal(t, nil, err)
+		offline.Destroy()
+	})
+
+	t.Run("TestLogger", func(t *testing.T) {
+		var buf bytes.Buffer
+		logged, err := NewClientGateway(configConn, WithLogger(domain.NewStdLogger(log.New(&buf, "", 0), domain.LogDebug)))
+		assert.Equal(t, nil, err)
+		defer logged.Destroy()
+		_, err = logged.Version()
+		assert.Equal(t, nil, err)
+		assert.Contains(t, buf.String(), "DEBUG request started method=client.version")
+		assert.Contains(t, buf.String(), "DEBUG request finished method=client.version")
+
+		buf.Reset()
+		_, err = NewClientGateway(domain.NewDefaultConfig("", []string{"invalid://"}, ""), WithLogger(domain.NewStdLogger(log.New(&buf, "", 0), domain.LogDebug)))
+		assert.NotEqual(t, nil, err)
+		assert.Contains(t, buf.String(), "ERROR context not created")
+	})
+
+	t.Run("TestTracer", func(t *testing.T) {
+		assert.Equal(t, domain.NopTracer, domain.TracerOf(clientConn))
+		traced, err := NewClientGateway(configConn, WithTracer(domain.NopTracer))
+		assert.Equal(t, nil, err)
+		defer traced.Destroy()
+		assert.Equal(t, domain.NopTracer, domain.TracerOf(traced))
+		_, err = traced.Version()
+		assert.Equal(t, nil, err)
+	})
 }
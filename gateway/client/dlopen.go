@@ -0,0 +1,117 @@
+//go:build ever_dlopen && !windows
+// +build ever_dlopen,!windows
+
+package client
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include "client_method.h"
+
+static tc_string_handle_t* (*ever_tc_create_context)(tc_string_data_t);
+static void (*ever_tc_destroy_context)(uint32_t);
+static void (*ever_tc_request_ptr)(uint32_t, tc_string_data_t, tc_string_data_t, void*, tc_response_handler_ptr_t);
+static tc_string_data_t (*ever_tc_read_string)(const tc_string_handle_t*);
+static void (*ever_tc_destroy_string)(const tc_string_handle_t*);
+
+// ever_reset forgets the functions of a library which failed to load, so none of them is called after dlclose.
+static void ever_reset(void) {
+	ever_tc_create_context = NULL;
+	ever_tc_destroy_context = NULL;
+	ever_tc_request_ptr = NULL;
+	ever_tc_read_string = NULL;
+	ever_tc_destroy_string = NULL;
+}
+
+#define EVER_RESOLVE(lib, name) \
+	if ((*(void**)(&ever_##name) = dlsym(lib, #name)) == NULL) { dlclose(lib); ever_reset(); return "symbol " #name " not found"; }
+
+// ever_load returns NULL or the reason the library could not be loaded.
+static const char* ever_load(const char* path) {
+	void* lib = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	if (lib == NULL) {
+		return dlerror();
+	}
+	EVER_RESOLVE(lib, tc_create_context)
+	EVER_RESOLVE(lib, tc_destroy_context)
+	EVER_RESOLVE(lib, tc_request_ptr)
+	EVER_RESOLVE(lib, tc_read_string)
+	EVER_RESOLVE(lib, tc_destroy_string)
+	return NULL;
+}
+
+tc_string_handle_t* tc_create_context(tc_string_data_t config) {
+	return ever_tc_create_context(config);
+}
+
+void tc_destroy_context(uint32_t context) {
+	ever_tc_destroy_context(context);
+}
+
+void tc_request_ptr(uint32_t context, tc_string_data_t function_name, tc_string_data_t function_params_json,
+	void* request_ptr, tc_response_handler_ptr_t response_handler) {
+	ever_tc_request_ptr(context, function_name, function_params_json, request_ptr, response_handler);
+}
+
+tc_string_data_t tc_read_string(const tc_string_handle_t* handle) {
+	return ever_tc_read_string(handle);
+}
+
+void tc_destroy_string(const tc_string_handle_t* handle) {
+	ever_tc_destroy_string(handle);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// LibraryEnv - environment variable with the path of libton_client, used when WithLibraryPath is not given.
+const LibraryEnv = "EVER_CLIENT_LIB"
+
+var (
+	libraryMu   sync.Mutex
+	libraryPath string
+)
+
+// loadLibrary - loads libton_client from path, $EVER_CLIENT_LIB or the default library search path.
+// The library is loaded once per process; loading it from another path fails.
+func loadLibrary(path string) error {
+	if path == "" {
+		path = os.Getenv(LibraryEnv)
+	}
+	if path == "" {
+		path = defaultLibrary()
+	}
+
+	libraryMu.Lock()
+	defer libraryMu.Unlock()
+	if libraryPath != "" {
+		if path != libraryPath {
+			return fmt.Errorf("ever-client-go: libton_client is already loaded from %s", libraryPath)
+		}
+		return nil
+	}
+
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	if reason := C.ever_load(cPath); reason != nil {
+		return fmt.Errorf("ever-client-go: load libton_client from %s: %s", path, C.GoString(reason))
+	}
+	libraryPath = path
+
+	return nil
+}
+
+func defaultLibrary() string {
+	if runtime.GOOS == "darwin" {
+		return "libton_client.dylib"
+	}
+
+	return "libton_client.so"
+}
@@ -0,0 +1,22 @@
+//go:build ever_dlopen && !windows
+// +build ever_dlopen,!windows
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLibrary(t *testing.T) {
+	err := loadLibrary("/nonexistent/libton_client.so")
+	assert.NotEqual(t, nil, err)
+	if libraryPath == "" {
+		assert.True(t, strings.HasPrefix(err.Error(), "ever-client-go: load libton_client from /nonexistent/libton_client.so: "))
+	} else {
+		assert.Equal(t, "ever-client-go: libton_client is already loaded from "+libraryPath, err.Error())
+		assert.Equal(t, nil, loadLibrary(libraryPath))
+	}
+}
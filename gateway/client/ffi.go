@@ -0,0 +1,114 @@
+package client
+
+/*
+#include "client_method.h"
+
+// The key of a request travels as the request pointer of the library, so it must fit in a pointer.
+_Static_assert(sizeof(void*) >= sizeof(uint64_t), "ever-client-go: the cgo gateway needs a 64-bit platform");
+
+extern void callB(uint64_t key, tc_string_data_t paramsJson, uint32_t response_type, bool finished);
+
+// ever_response_handler passes the key of the request, stored in request_ptr, to callB.
+static void ever_response_handler(void* request_ptr, tc_string_data_t params_json, uint32_t response_type, bool finished) {
+	callB((uint64_t)(uintptr_t)request_ptr, params_json, response_type, finished);
+}
+
+static void ever_request(uint32_t context, tc_string_data_t function_name, tc_string_data_t params_json, uint64_t key) {
+	tc_request_ptr(context, function_name, params_json, (void*)(uintptr_t)key, ever_response_handler);
+}
+*/
+import "C"
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"unsafe"
+)
+
+// maxCString - bound of the array type used to view C memory as a Go slice.
+const maxCString = 1 << 30
+
+// buffers - JSON encoding buffers reused between requests.
+var buffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// cStrings - Go bytes copied to C memory for the duration of one library call.
+// The library copies its string arguments, so the memory is freed as soon as the call returns.
+type cStrings struct {
+	mem     unsafe.Pointer
+	strings []C.tc_string_data_t
+}
+
+// newCStrings copies every part into a single C allocation.
+func newCStrings(parts ...[]byte) *cStrings {
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+	s := &cStrings{
+		mem:     C.malloc(C.size_t(size + 1)),
+		strings: make([]C.tc_string_data_t, len(parts)),
+	}
+	mem := (*[maxCString]byte)(s.mem)[: size+1 : size+1]
+	offset := 0
+	for i, part := range parts {
+		copy(mem[offset:], part)
+		s.strings[i] = C.tc_string_data_t{
+			content: (*C.char)(unsafe.Pointer(&mem[offset])),
+			len:     C.uint32_t(len(part)),
+		}
+		offset += len(part)
+	}
+	mem[size] = 0
+
+	return s
+}
+
+func (s *cStrings) free() {
+	C.free(s.mem)
+}
+
+// request - sends a request whose responses are passed to callB with key: the registry ID in the high half
+// and the request ID in the low half. The key travels as the request pointer, which is never dereferenced;
+// the build fails on 32-bit platforms, where it would be truncated.
+func request(client C.uint32_t, method, params C.tc_string_data_t, key uint64) {
+	C.ever_request(client, method, params, C.uint64_t(key))
+}
+
+func tcStringToByte(data C.tc_string_data_t) []byte {
+	if data.len == 0 {
+		return nil
+	}
+
+	return C.GoBytes(unsafe.Pointer(data.content), C.int(data.len))
+}
+
+// encodeParams appends the JSON of paramIn to buf. Raw JSON is passed as is.
+func encodeParams(buf *bytes.Buffer, paramIn interface{}) error {
+	switch params := paramIn.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		buf.Write(params)
+		return nil
+	}
+	if err := json.NewEncoder(buf).Encode(paramIn); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline.
+	buf.Truncate(buf.Len() - 1)
+
+	return nil
+}
+
+func getBuffer() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	buf.Reset()
+	buffers.Put(buf)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// leakCallsEnv - number of client.version calls of TestLeak, e.g. 1000000. The test is skipped if it is not set.
+const leakCallsEnv = "EVER_CLIENT_LEAK_CALLS"
+
+func TestEncodeParams(t *testing.T) {
+	for _, params := range []interface{}{
+		nil,
+		json.RawMessage(`{"a":1}`),
+		&domain.ParamsOfWaitForCollection{Collection: "blocks", Filter: json.RawMessage(`{"id":{"eq":"<&>"}}`), Result: "id"},
+	} {
+		buf := getBuffer()
+		assert.Equal(t, nil, encodeParams(buf, params))
+		expected := []byte{}
+		if params != nil {
+			expected, _ = json.Marshal(params)
+		}
+		assert.Equal(t, string(expected), buf.String())
+		putBuffer(buf)
+	}
+}
+
+func TestLeak(t *testing.T) {
+	calls, _ := strconv.Atoi(os.Getenv(leakCallsEnv))
+	if calls == 0 {
+		t.Skip(leakCallsEnv + " is not set")
+	}
+	if _, err := os.Stat("/proc/self/statm"); err != nil {
+		t.Skip("RSS is read from /proc/self/statm")
+	}
+	gw, err := NewClientGateway(domain.ClientConfig{})
+	assert.Equal(t, nil, err)
+	defer gw.Destroy()
+
+	run := func(n int) {
+		var wg sync.WaitGroup
+		for w := 0; w < 32; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; i < n; i += 32 {
+					if _, err := gw.GetResponse("client.version", nil); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+	}
+	run(calls / 10)
+	before := rss(t)
+	run(calls)
+	after := rss(t)
+	t.Logf("RSS %d KiB -> %d KiB after %d calls", before>>10, after>>10, calls)
+	assert.Less(t, after-before, int64(16<<20))
+}
+
+// rss returns the resident set size of the process in bytes.
+func rss(t *testing.T) int64 {
+	statm, err := ioutil.ReadFile("/proc/self/statm")
+	assert.Equal(t, nil, err)
+	pages, err := strconv.ParseInt(strings.Fields(string(statm))[1], 10, 64)
+	assert.Equal(t, nil, err)
+
+	return pages * int64(os.Getpagesize())
+}
+
+func BenchmarkRequest(b *testing.B) {
+	gw, err := NewClientGateway(domain.ClientConfig{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer gw.Destroy()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := gw.GetResponse("client.version", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeParams(b *testing.B) {
+	params := &domain.ParamsOfWaitForCollection{Collection: "blocks", Filter: json.RawMessage(`{"seq_no":{"gt":1}}`), Result: "id seq_no"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := getBuffer()
+		if err := encodeParams(buf, params); err != nil {
+			b.Fatal(err)
+		}
+		args := newCStrings([]byte("net.wait_for_collection"), buf.Bytes())
+		args.free()
+		putBuffer(buf)
+	}
+}
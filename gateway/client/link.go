@@ -0,0 +1,21 @@
+//go:build !ever_dlopen || windows
+// +build !ever_dlopen windows
+
+package client
+
+/*
+#cgo darwin LDFLAGS: -L${SRCDIR}/lib/darwin -lton_client
+#cgo linux LDFLAGS: -L${SRCDIR}/lib/linux -lton_client
+#cgo windows LDFLAGS: -L${SRCDIR}/lib/windows -lton_client
+*/
+import "C"
+import "errors"
+
+// loadLibrary - the library is linked, so there is nothing to load.
+func loadLibrary(path string) error {
+	if path != "" {
+		return errors.New("ever-client-go: libton_client is linked, build with -tags ever_dlopen to load it from a path")
+	}
+
+	return nil
+}
@@ -1,66 +1,194 @@
 package client
 
 import (
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/move-ton/ever-client-go/domain"
 )
 
-// Manager ...
-type Manager interface {
-	SetChannels(chan<- *domain.ClientResponse, <-chan struct{}) uint32
-	DeleteRequestID(uint32)
-	GetChannels(requestID uint32, delete bool) (chan<- *domain.ClientResponse, <-chan struct{}, bool)
+// registryShards - number of independently locked parts of a registry.
+const registryShards = 16
+
+// Stream states of RequestInfo.
+const (
+	// StatePending - no response has arrived yet.
+	StatePending = "pending"
+	// StateStreaming - events, app requests or notifications arrived, the result has not.
+	StateStreaming = "streaming"
+	// StateOpen - the result arrived and the stream stays open, e.g. a subscription or an app object.
+	StateOpen = "open"
+)
+
+var streamStates = [...]string{StatePending, StateStreaming, StateOpen}
+
+const (
+	statePending uint32 = iota
+	stateStreaming
+	stateOpen
+)
+
+// registries - the registries of the live contexts. Responses of the library are routed by registry ID.
+var registries = struct {
+	sync.RWMutex
+	next uint32
+	m    map[uint32]*registry
+}{m: make(map[uint32]*registry)}
+
+type (
+	// RequestInfo - request of a gateway which has not finished yet.
+	RequestInfo struct {
+		ID     uint32
+		Method string
+		Age    time.Duration
+		State  string
+	}
+
+	// Inspector - implemented by the gateways of the linked library:
+	//
+	//	requests := gw.(client.Inspector).InFlight()
+	Inspector interface {
+		InFlight() []RequestInfo
+	}
+
+	// registry - requests of one context. IDs are unique within the registry and skip the live ones when they wrap.
+	registry struct {
+		id     uint32
+		next   uint32
+		closed <-chan struct{}
+		shards [registryShards]shard
+	}
+
+	shard struct {
+		sync.Mutex
+		entries map[uint32]*entry
+	}
+
+	entry struct {
+		method    string
+		started   time.Time
+		state     uint32
+		responses chan<- *domain.ClientResponse
+		done      <-chan struct{}
+	}
+)
+
+// newRegistry creates and registers the registry of a context. closed is closed when the context is closed.
+func newRegistry(closed <-chan struct{}) *registry {
+	r := &registry{closed: closed}
+	for i := range r.shards {
+		r.shards[i].entries = make(map[uint32]*entry)
+	}
+
+	registries.Lock()
+	defer registries.Unlock()
+	for {
+		registries.next++
+		if _, live := registries.m[registries.next]; !live && registries.next != 0 {
+			break
+		}
+	}
+	r.id = registries.next
+	registries.m[r.id] = r
+
+	return r
 }
 
-type multiplexer struct {
-	sync.Locker
-	requestIDCounter uint32
-	callbacks        map[uint32]manageChan
+// lookupRegistry returns the registry with id, if its context is alive.
+func lookupRegistry(id uint32) (*registry, bool) {
+	registries.RLock()
+	defer registries.RUnlock()
+	r, ok := registries.m[id]
+
+	return r, ok
 }
 
-// NewStore ...
-func NewStore() Manager {
-	return &multiplexer{
-		Locker:    &sync.Mutex{},
-		callbacks: make(map[uint32]manageChan),
-	}
+// unregister drops the registry; late responses of its requests are discarded.
+func (r *registry) unregister() {
+	registries.Lock()
+	defer registries.Unlock()
+	delete(registries.m, r.id)
 }
 
-type manageChan struct {
-	responsChan chan<- *domain.ClientResponse
-	close       <-chan struct{}
+func (r *registry) shard(id uint32) *shard {
+	return &r.shards[id%registryShards]
 }
 
-// GetChannels ...
-func (m *multiplexer) GetChannels(requestID uint32, toDelete bool) (chan<- *domain.ClientResponse, <-chan struct{}, bool) {
-	m.Lock()
-	defer m.Unlock()
-	pair, isFound := m.callbacks[requestID]
-	if isFound && toDelete {
-		delete(m.callbacks, requestID)
+// add registers a request and returns its key for the library.
+// done is closed when the caller is no longer interested in the responses (e.g. its context is cancelled).
+func (r *registry) add(method string, responses chan<- *domain.ClientResponse, done <-chan struct{}) (uint32, uint64) {
+	e := &entry{method: method, started: time.Now(), responses: responses, done: done}
+	for {
+		id := atomic.AddUint32(&r.next, 1)
+		if id == 0 {
+			continue
+		}
+		s := r.shard(id)
+		s.Lock()
+		if _, live := s.entries[id]; live {
+			s.Unlock()
+			continue
+		}
+		s.entries[id] = e
+		s.Unlock()
+
+		return id, uint64(r.id)<<32 | uint64(id)
+	}
+}
+
+// get returns the request with id and drops it if the request is finished.
+func (r *registry) get(id uint32, finished bool) (*entry, bool) {
+	s := r.shard(id)
+	s.Lock()
+	defer s.Unlock()
+	e, ok := s.entries[id]
+	if ok && finished {
+		delete(s.entries, id)
 	}
 
-	return pair.responsChan, pair.close, isFound
+	return e, ok
 }
 
-//SetChannels ...
-func (m *multiplexer) SetChannels(responses chan<- *domain.ClientResponse, close <-chan struct{}) uint32 {
-	m.Lock()
-	defer m.Unlock()
-	m.requestIDCounter++
-	requestID := m.requestIDCounter
-	m.callbacks[requestID] = manageChan{
-		responsChan: responses,
-		close:       close,
+func (r *registry) delete(id uint32) {
+	s := r.shard(id)
+	s.Lock()
+	defer s.Unlock()
+	delete(s.entries, id)
+}
+
+// inFlight lists the registered requests, oldest first.
+func (r *registry) inFlight() []RequestInfo {
+	now := time.Now()
+	var requests []RequestInfo
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.Lock()
+		for id, e := range s.entries {
+			requests = append(requests, RequestInfo{
+				ID:     id,
+				Method: e.method,
+				Age:    now.Sub(e.started),
+				State:  streamStates[atomic.LoadUint32(&e.state)],
+			})
+		}
+		s.Unlock()
 	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Age > requests[j].Age
+	})
 
-	return requestID
+	return requests
 }
 
-// DeleteRequestID ...
-func (m *multiplexer) DeleteRequestID(requestID uint32) {
-	m.Lock()
-	defer m.Unlock()
-	delete(m.callbacks, requestID)
+// received updates the stream state of the request with a response of responseType.
+func (e *entry) received(responseType uint32, finished bool) {
+	switch {
+	case finished:
+	case responseType == 0:
+		atomic.StoreUint32(&e.state, stateOpen)
+	case responseType != 2:
+		atomic.CompareAndSwapUint32(&e.state, statePending, stateStreaming)
+	}
 }
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("TestKeys", func(t *testing.T) {
+		first, second := newRegistry(nil), newRegistry(nil)
+		defer first.unregister()
+		defer second.unregister()
+		assert.NotEqual(t, first.id, second.id)
+
+		id, key := first.add("client.version", make(chan *domain.ClientResponse, 1), nil)
+		assert.Equal(t, first.id, uint32(key>>32))
+		assert.Equal(t, id, uint32(key))
+		r, ok := lookupRegistry(uint32(key >> 32))
+		assert.True(t, ok)
+		assert.Equal(t, first, r)
+		_, ok = second.get(id, false)
+		assert.False(t, ok)
+	})
+
+	t.Run("TestWrapSkipsLiveIDs", func(t *testing.T) {
+		r := newRegistry(nil)
+		defer r.unregister()
+		subscription, _ := r.add("net.subscribe_collection", make(chan *domain.ClientResponse, 1), nil)
+		second, _ := r.add("client.version", make(chan *domain.ClientResponse, 1), nil)
+		r.delete(second)
+
+		r.next = math.MaxUint32 - 1
+		ids := make(map[uint32]bool)
+		for i := 0; i < 4; i++ {
+			id, _ := r.add("client.version", make(chan *domain.ClientResponse, 1), nil)
+			ids[id] = true
+		}
+		assert.Equal(t, map[uint32]bool{math.MaxUint32: true, second: true, 3: true, 4: true}, ids)
+		_, ok := r.get(subscription, false)
+		assert.True(t, ok)
+	})
+
+	t.Run("TestConcurrentAdd", func(t *testing.T) {
+		r := newRegistry(nil)
+		defer r.unregister()
+		var (
+			mu  sync.Mutex
+			wg  sync.WaitGroup
+			ids = make(map[uint32]bool)
+		)
+		for w := 0; w < 8; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					id, _ := r.add("client.version", nil, nil)
+					mu.Lock()
+					ids[id] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 8000, len(ids))
+		assert.Equal(t, 8000, len(r.inFlight()))
+	})
+
+	t.Run("TestStates", func(t *testing.T) {
+		r := newRegistry(nil)
+		defer r.unregister()
+		id, _ := r.add("net.subscribe_collection", nil, nil)
+		e, _ := r.get(id, false)
+		assert.Equal(t, StatePending, r.inFlight()[0].State)
+		e.received(100, false)
+		assert.Equal(t, StateStreaming, r.inFlight()[0].State)
+		e.received(0, false)
+		assert.Equal(t, StateOpen, r.inFlight()[0].State)
+		assert.Equal(t, "net.subscribe_collection", r.inFlight()[0].Method)
+	})
+}
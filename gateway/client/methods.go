@@ -37,3 +37,27 @@ func (c *clientGateway) ResolveAppRequest(pORAR *domain.ParamsOfResolveAppReques
 	_, err := c.GetResponse("client.resolve_app_request", pORAR)
 	return err
 }
+
+// Supports reports whether the loaded library has method, according to its API reference.
+func (c *clientGateway) Supports(method string) bool {
+	return c.capabilities.Supports(method)
+}
+
+// ReportError passes err to the handler set by WithErrorHandler.
+func (c *clientGateway) ReportError(err error) {
+	if c.errorHandler == nil {
+		domain.DefaultErrorHandler(err)
+		return
+	}
+	c.errorHandler(err)
+}
+
+// Tracer returns the tracer set by WithTracer, nil if none.
+func (c *clientGateway) Tracer() domain.Tracer {
+	return c.tracer
+}
+
+// AppObjects returns the dispatcher of app object callbacks.
+func (c *clientGateway) AppObjects() *domain.AppObjectDispatcher {
+	return c.appObjects
+}
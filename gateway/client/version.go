@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+const (
+	// MinVersionLibSDK - oldest supported libton_client version.
+	MinVersionLibSDK = "1.40.0"
+	// MaxVersionLibSDK - first libton_client version which is not supported.
+	MaxVersionLibSDK = "2.0.0"
+)
+
+// VersionPolicy - what NewClientGateway does when the loaded library is outside the supported versions.
+type VersionPolicy int
+
+const (
+	// VersionWarn - logs a warning with the logger of WithLogger, domain.DefaultLogger if not given. The default.
+	VersionWarn VersionPolicy = iota
+	// VersionStrict - fails with *VersionError.
+	VersionStrict
+	// VersionSkip - does not check the version.
+	VersionSkip
+)
+
+// VersionError - the loaded library is outside [MinVersionLibSDK, MaxVersionLibSDK).
+type VersionError struct {
+	Version     string
+	BuildNumber int
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("libton_client %s (build %d) is not supported, want >= %s and < %s",
+		e.Version, e.BuildNumber, MinVersionLibSDK, MaxVersionLibSDK)
+}
+
+// WithVersionPolicy - sets what happens when the loaded library version is not supported.
+func WithVersionPolicy(policy VersionPolicy) Option {
+	return func(c *clientGateway) {
+		c.versionPolicy = policy
+	}
+}
+
+// checkVersion - compares the version of the loaded library with the supported range according to the policy.
+func (c *clientGateway) checkVersion() error {
+	if c.versionPolicy == VersionSkip {
+		return nil
+	}
+	err := c.compareVersion()
+	if err != nil && c.versionPolicy == VersionWarn {
+		logger := c.logger
+		if logger == nil {
+			logger = domain.DefaultLogger
+		}
+		logger.Log(context.Background(), domain.LogWarn, "unsupported library version", domain.KV("error", err))
+		return nil
+	}
+
+	return err
+}
+
+func (c *clientGateway) compareVersion() error {
+	version, err := c.Version()
+	if err != nil {
+		return err
+	}
+	if SupportedVersion(version.Version) {
+		return nil
+	}
+	versionErr := &VersionError{Version: version.Version}
+	if buildInfo, err := c.GetBuildInfo(); err == nil {
+		versionErr.BuildNumber = buildInfo.BuildNumber
+	}
+
+	return versionErr
+}
+
+// SupportedVersion reports whether libton_client version is in [MinVersionLibSDK, MaxVersionLibSDK).
+func SupportedVersion(version string) bool {
+	return compareVersions(version, MinVersionLibSDK) >= 0 && compareVersions(version, MaxVersionLibSDK) < 0
+}
+
+// compareVersions compares major.minor.patch versions; pre-release and build suffixes are ignored.
+func compareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for i := range as {
+		switch {
+		case as[i] < bs[i]:
+			return -1
+		case as[i] > bs[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func versionParts(version string) [3]int {
+	var parts [3]int
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	for i, part := range strings.SplitN(version, ".", 3) {
+		parts[i], _ = strconv.Atoi(part)
+	}
+
+	return parts
+}
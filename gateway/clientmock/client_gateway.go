@@ -1,28 +1,161 @@
-// Code generated by mockery v2.0.0-alpha.13. DO NOT EDIT.
-
+// Package clientmock provides test doubles of domain.ClientGateway: the testify mock ClientGateway
+// and the scriptable Fake.
+//
+// ClientGateway is maintained by hand in the form mockery generates, it is not regenerated:
+// update it together with domain.ClientGateway.
 package clientmock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	json "encoding/json"
+
+	domain "github.com/move-ton/ever-client-go/domain"
+	mock "github.com/stretchr/testify/mock"
+)
 
-// ClientGateway is an autogenerated mock type for the ClientGateway type
+// ClientGateway is a mock type for the ClientGateway type
 type ClientGateway struct {
 	mock.Mock
 }
 
+// AppObjects provides a mock function with given fields:
+func (_m *ClientGateway) AppObjects() *domain.AppObjectDispatcher {
+	ret := _m.Called()
+
+	var r0 *domain.AppObjectDispatcher
+	if rf, ok := ret.Get(0).(func() *domain.AppObjectDispatcher); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AppObjectDispatcher)
+		}
+	}
+
+	return r0
+}
+
+// Call provides a mock function with given fields: _a0, _a1
+func (_m *ClientGateway) Call(_a0 string, _a1 interface{}) (json.RawMessage, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 json.RawMessage
+	if rf, ok := ret.Get(0).(func(string, interface{}) json.RawMessage); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, interface{}) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ClientGateway) CallContext(_a0 context.Context, _a1 string, _a2 interface{}) (json.RawMessage, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 json.RawMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) json.RawMessage); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CallStream provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ClientGateway) CallStream(_a0 context.Context, _a1 string, _a2 interface{}, _a3 domain.StreamHandler) (json.RawMessage, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 json.RawMessage
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, domain.StreamHandler) json.RawMessage); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, domain.StreamHandler) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Close provides a mock function with given fields: _a0
+func (_m *ClientGateway) Close(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Config provides a mock function with given fields:
+func (_m *ClientGateway) Config() (*domain.ClientConfig, error) {
+	ret := _m.Called()
+
+	var r0 *domain.ClientConfig
+	if rf, ok := ret.Get(0).(func() *domain.ClientConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ClientConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Destroy provides a mock function with given fields:
 func (_m *ClientGateway) Destroy() {
 	_m.Called()
 }
 
 // GetAPIReference provides a mock function with given fields:
-func (_m *ClientGateway) GetAPIReference() (int, error) {
+func (_m *ClientGateway) GetAPIReference() (*domain.ResultOfGetAPIReference, error) {
 	ret := _m.Called()
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
+	var r0 *domain.ResultOfGetAPIReference
+	if rf, ok := ret.Get(0).(func() *domain.ResultOfGetAPIReference); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResultOfGetAPIReference)
+		}
 	}
 
 	var r1 error
@@ -36,14 +169,16 @@ func (_m *ClientGateway) GetAPIReference() (int, error) {
 }
 
 // GetBuildInfo provides a mock function with given fields:
-func (_m *ClientGateway) GetBuildInfo() (int, error) {
+func (_m *ClientGateway) GetBuildInfo() (*domain.ResultOfBuildInfo, error) {
 	ret := _m.Called()
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
+	var r0 *domain.ResultOfBuildInfo
+	if rf, ok := ret.Get(0).(func() *domain.ResultOfBuildInfo); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResultOfBuildInfo)
+		}
 	}
 
 	var r1 error
@@ -56,22 +191,45 @@ func (_m *ClientGateway) GetBuildInfo() (int, error) {
 	return r0, r1
 }
 
-// GetResp provides a mock function with given fields: resp
-func (_m *ClientGateway) GetResp(resp int) (interface{}, error) {
-	ret := _m.Called(resp)
+// GetResponse provides a mock function with given fields: _a0, _a1
+func (_m *ClientGateway) GetResponse(_a0 string, _a1 interface{}) ([]byte, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, interface{}) []byte); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, interface{}) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResponseContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ClientGateway) GetResponseContext(_a0 context.Context, _a1 string, _a2 interface{}) ([]byte, error) {
+	ret := _m.Called(_a0, _a1, _a2)
 
-	var r0 interface{}
-	if rf, ok := ret.Get(0).(func(int) interface{}); ok {
-		r0 = rf(resp)
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) []byte); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(resp)
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,20 +237,78 @@ func (_m *ClientGateway) GetResp(resp int) (interface{}, error) {
 	return r0, r1
 }
 
-// Request provides a mock function with given fields: method, paramsIn
-func (_m *ClientGateway) Request(method string, paramsIn interface{}) (int, error) {
-	ret := _m.Called(method, paramsIn)
+// GetResult provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ClientGateway) GetResult(_a0 string, _a1 interface{}, _a2 interface{}) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, interface{}, interface{}) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetResultContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ClientGateway) GetResultContext(_a0 context.Context, _a1 string, _a2 interface{}, _a3 interface{}) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, interface{}) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportError provides a mock function with given fields: _a0
+func (_m *ClientGateway) ReportError(_a0 error) {
+	_m.Called(_a0)
+}
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func(string, interface{}) int); ok {
-		r0 = rf(method, paramsIn)
+// Request provides a mock function with given fields: _a0, _a1
+func (_m *ClientGateway) Request(_a0 string, _a1 interface{}) (<-chan *domain.ClientResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 <-chan *domain.ClientResponse
+	if rf, ok := ret.Get(0).(func(string, interface{}) <-chan *domain.ClientResponse); ok {
+		r0 = rf(_a0, _a1)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *domain.ClientResponse)
+		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string, interface{}) error); ok {
-		r1 = rf(method, paramsIn)
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *ClientGateway) RequestContext(_a0 context.Context, _a1 string, _a2 interface{}) (<-chan *domain.ClientResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 <-chan *domain.ClientResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) <-chan *domain.ClientResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *domain.ClientResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(_a0, _a1, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,15 +316,47 @@ func (_m *ClientGateway) Request(method string, paramsIn interface{}) (int, erro
 	return r0, r1
 }
 
+// ResolveAppRequest provides a mock function with given fields: _a0
+func (_m *ClientGateway) ResolveAppRequest(_a0 *domain.ParamsOfResolveAppRequest) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ParamsOfResolveAppRequest) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Supports provides a mock function with given fields: _a0
+func (_m *ClientGateway) Supports(_a0 string) bool {
+	ret := _m.Called(_a0)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bool)
+		}
+	}
+
+	return r0
+}
+
 // Version provides a mock function with given fields:
-func (_m *ClientGateway) Version() (int, error) {
+func (_m *ClientGateway) Version() (*domain.ResultOfVersion, error) {
 	ret := _m.Called()
 
-	var r0 int
-	if rf, ok := ret.Get(0).(func() int); ok {
+	var r0 *domain.ResultOfVersion
+	if rf, ok := ret.Get(0).(func() *domain.ResultOfVersion); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ResultOfVersion)
+		}
 	}
 
 	var r1 error
@@ -0,0 +1,347 @@
+package clientmock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// Fake - scriptable in-memory domain.ClientGateway. Every use case can run against it:
+	//
+	//	fake := clientmock.NewFake()
+	//	fake.On("net.query_collection").Respond(domain.ResultOfQueryCollection{Result: rows})
+	//	fake.On("processing.send_message").StreamEvents(willSend, didSend).Respond(result)
+	//	fake.On("processing.wait_for_transaction").Fail(domain.ClientError{Code: domain.ProcessingErrorMessageExpired})
+	//
+	// Unscripted methods fail with ClientErrorUnknownFunction, except client.resolve_app_request which succeeds.
+	Fake struct {
+		domain.BaseGateway
+
+		mu           sync.Mutex
+		expectations []*Expectation
+		calls        []Call
+		appRequestID int
+
+		ctx    context.Context
+		cancel context.CancelFunc
+	}
+
+	// Expectation - scripted response stream of a method. Frames are sent in the order they are scripted.
+	// By default an expectation answers any number of calls and the stream is closed after the last frame.
+	Expectation struct {
+		fake     *Fake
+		method   string
+		match    func(params json.RawMessage) bool
+		frames   []*domain.ClientResponse
+		keepOpen bool
+		times    int
+		calls    int
+	}
+
+	// Call - request received by the fake.
+	Call struct {
+		Method string
+		Params json.RawMessage
+	}
+)
+
+// NewFake creates a fake with no scripted methods.
+func NewFake() *Fake {
+	f := &Fake{}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	f.Requester = f.request
+	f.Dispatcher = domain.NewAppObjectDispatcher(f)
+
+	return f
+}
+
+// On - scripts the responses to method.
+// Expectations are tried in the order they were added; the first one with matching params which is not used up answers.
+func (f *Fake) On(method string) *Expectation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e := &Expectation{fake: f, method: method}
+	f.expectations = append(f.expectations, e)
+
+	return e
+}
+
+// WithParams - the expectation answers only calls whose params equal params as JSON documents.
+func (e *Expectation) WithParams(params interface{}) *Expectation {
+	expected, err := json.Marshal(params)
+	if err != nil {
+		panic(err)
+	}
+
+	return e.MatchParams(func(actual json.RawMessage) bool {
+		return jsonEqual(expected, actual)
+	})
+}
+
+// MatchParams - the expectation answers only calls whose params satisfy match.
+func (e *Expectation) MatchParams(match func(params json.RawMessage) bool) *Expectation {
+	e.fake.mu.Lock()
+	defer e.fake.mu.Unlock()
+	e.match = match
+
+	return e
+}
+
+// Respond - sends result (code 0).
+func (e *Expectation) Respond(result interface{}) *Expectation {
+	return e.Send(0, result)
+}
+
+// StreamEvents - sends every event (code 100), e.g. a map or json.RawMessage with a ProcessingEvent.
+func (e *Expectation) StreamEvents(events ...interface{}) *Expectation {
+	for _, event := range events {
+		e.Send(100, event)
+	}
+
+	return e
+}
+
+// AppRequest - sends an app request (code 3) with requestData, e.g. {"type": "GetPublicKey"}.
+// App request IDs are assigned in the order of the script. Resolutions are received as client.resolve_app_request calls.
+func (e *Expectation) AppRequest(requestData interface{}) *Expectation {
+	data, err := json.Marshal(requestData)
+	if err != nil {
+		panic(err)
+	}
+	e.fake.mu.Lock()
+	e.fake.appRequestID++
+	id := e.fake.appRequestID
+	e.fake.mu.Unlock()
+
+	return e.Send(3, domain.ParamsOfAppRequest{AppRequestID: id, RequestData: data})
+}
+
+// Fail - sends err (code 1).
+func (e *Expectation) Fail(err domain.ClientError) *Expectation {
+	e.fake.mu.Lock()
+	defer e.fake.mu.Unlock()
+	clientErr := err
+	e.frames = append(e.frames, &domain.ClientResponse{Code: 1, Error: &clientErr})
+
+	return e
+}
+
+// Send - sends data with an arbitrary response code. Data is marshaled to JSON unless it is []byte or json.RawMessage.
+func (e *Expectation) Send(code uint32, data interface{}) *Expectation {
+	var (
+		raw []byte
+		err error
+	)
+	switch value := data.(type) {
+	case []byte:
+		raw = value
+	case json.RawMessage:
+		raw = value
+	default:
+		raw, err = json.Marshal(value)
+	}
+	if err != nil {
+		panic(err)
+	}
+	e.fake.mu.Lock()
+	defer e.fake.mu.Unlock()
+	e.frames = append(e.frames, &domain.ClientResponse{Code: code, Data: raw})
+
+	return e
+}
+
+// KeepOpen - leaves the stream open after the last frame until the request context is done or the fake is destroyed,
+// like the streams of subscriptions and registered app objects.
+func (e *Expectation) KeepOpen() *Expectation {
+	e.fake.mu.Lock()
+	defer e.fake.mu.Unlock()
+	e.keepOpen = true
+
+	return e
+}
+
+// Times - the expectation answers exactly n calls.
+func (e *Expectation) Times(n int) *Expectation {
+	e.fake.mu.Lock()
+	defer e.fake.mu.Unlock()
+	e.times = n
+
+	return e
+}
+
+// Once - Times(1).
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// Decode unmarshals the params of the call into v.
+func (c Call) Decode(v interface{}) error {
+	return json.Unmarshal(c.Params, v)
+}
+
+// Destroy closes the streams left open by KeepOpen.
+func (f *Fake) Destroy() {
+	f.cancel()
+}
+
+// Close - Destroy.
+func (f *Fake) Close(context.Context) error {
+	f.Destroy()
+	return nil
+}
+
+// Supports reports whether method is scripted.
+func (f *Fake) Supports(method string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range f.expectations {
+		if e.method == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Calls returns the requests received so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsOf returns the requests of method received so far, in order.
+func (f *Fake) CallsOf(method string) []Call {
+	var calls []Call
+	for _, call := range f.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// AssertCalled asserts that method was called.
+func (f *Fake) AssertCalled(t assert.TestingT, method string) bool {
+	return assert.NotEmpty(t, f.CallsOf(method), "%s was not called", method)
+}
+
+// AssertNotCalled asserts that method was not called.
+func (f *Fake) AssertNotCalled(t assert.TestingT, method string) bool {
+	return assert.Empty(t, f.CallsOf(method), "%s was called", method)
+}
+
+// AssertNumberOfCalls asserts that method was called n times.
+func (f *Fake) AssertNumberOfCalls(t assert.TestingT, method string, n int) bool {
+	return assert.Equal(t, n, len(f.CallsOf(method)), "number of calls of %s", method)
+}
+
+// AssertCalledWith asserts that method was called with params equal to params as JSON documents.
+func (f *Fake) AssertCalledWith(t assert.TestingT, method string, params interface{}) bool {
+	expected, err := json.Marshal(params)
+	if err != nil {
+		return assert.Fail(t, err.Error())
+	}
+	calls := f.CallsOf(method)
+	for _, call := range calls {
+		if jsonEqual(expected, call.Params) {
+			return true
+		}
+	}
+
+	return assert.Fail(t, fmt.Sprintf("%s was not called with %s", method, expected), "calls: %v", calls)
+}
+
+// AssertExpectations asserts that every expectation with Times was used exactly that many times
+// and every other expectation was used at least once.
+func (f *Fake) AssertExpectations(t assert.TestingT) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ok := true
+	for _, e := range f.expectations {
+		switch {
+		case e.times > 0 && e.calls != e.times:
+			ok = assert.Fail(t, fmt.Sprintf("%s: expected %d calls, got %d", e.method, e.times, e.calls)) && ok
+		case e.times == 0 && e.calls == 0:
+			ok = assert.Fail(t, fmt.Sprintf("%s: expected to be called", e.method)) && ok
+		}
+	}
+
+	return ok
+}
+
+func (f *Fake) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var params json.RawMessage
+	if paramIn != nil {
+		raw, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		params = raw
+	}
+
+	frames, keepOpen := f.answer(method, params)
+	responses := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(responses)
+		for _, frame := range frames {
+			r := *frame
+			select {
+			case responses <- &r:
+			case <-ctx.Done():
+				return
+			case <-f.ctx.Done():
+				return
+			}
+		}
+		if keepOpen {
+			select {
+			case <-ctx.Done():
+			case <-f.ctx.Done():
+			}
+		}
+	}()
+
+	return responses, nil
+}
+
+func (f *Fake) answer(method string, params json.RawMessage) ([]*domain.ClientResponse, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Params: params})
+	for _, e := range f.expectations {
+		if e.method != method || (e.times > 0 && e.calls >= e.times) || (e.match != nil && !e.match(params)) {
+			continue
+		}
+		e.calls++
+		return append([]*domain.ClientResponse(nil), e.frames...), e.keepOpen
+	}
+	if method == "client.resolve_app_request" {
+		return []*domain.ClientResponse{{Code: 0, Data: []byte("{}")}}, false
+	}
+
+	return []*domain.ClientResponse{{Code: 1, Error: &domain.ClientError{
+		Code:    domain.ClientErrorUnknownFunction,
+		Message: "clientmock: unexpected call of " + method,
+	}}}, false
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var docA, docB interface{}
+	if json.Unmarshal(a, &docA) != nil || json.Unmarshal(b, &docB) != nil {
+		return len(a) == 0 && len(b) == 0
+	}
+
+	return reflect.DeepEqual(docA, docB)
+}
@@ -0,0 +1,112 @@
+package clientmock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/usecase/crypto"
+	"github.com/move-ton/ever-client-go/usecase/net"
+	"github.com/move-ton/ever-client-go/usecase/processing"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ domain.ClientGateway = (*ClientGateway)(nil)
+
+type encryptionBox struct{}
+
+func (encryptionBox) GetInfo() (domain.ResultOfAppEncryptionBoxGetInfo, error) {
+	return domain.ResultOfAppEncryptionBoxGetInfo{Info: domain.EncryptionBoxInfo{Algorithm: "fake"}}, nil
+}
+
+func (encryptionBox) Encrypt(domain.ParamsOfAppEncryptionBoxEncrypt) (domain.ResultOfAppEncryptionBoxEncrypt, error) {
+	return domain.ResultOfAppEncryptionBoxEncrypt{}, nil
+}
+
+func (encryptionBox) Decrypt(domain.ParamsOfAppEncryptionBoxDecrypt) (domain.ResultOfAppEncryptionBoxDecrypt, error) {
+	return domain.ResultOfAppEncryptionBoxDecrypt{}, nil
+}
+
+func TestFake(t *testing.T) {
+	config := domain.NewDefaultConfig("", domain.GetLocalNetBaseUrls(), "")
+
+	t.Run("TestRespond", func(t *testing.T) {
+		fake := NewFake()
+		defer fake.Destroy()
+		fake.On("net.query_collection").WithParams(&domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id"}).
+			Respond(domain.ResultOfQueryCollection{Result: []json.RawMessage{json.RawMessage(`{"id":"0:01"}`)}}).Once()
+
+		result, err := net.NewNet(config, fake).QueryCollection(&domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"id":"0:01"}`, string(result.Result[0]))
+
+		_, err = net.NewNet(config, fake).QueryCollection(&domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id"})
+		assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+
+		fake.AssertNumberOfCalls(t, "net.query_collection", 2)
+		fake.AssertCalledWith(t, "net.query_collection", &domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id"})
+		fake.AssertNotCalled(t, "net.query")
+		fake.AssertExpectations(t)
+	})
+
+	t.Run("TestStreamEventsAndFail", func(t *testing.T) {
+		fake := NewFake()
+		defer fake.Destroy()
+		fake.On("processing.send_message").
+			StreamEvents(map[string]string{"type": "WillSend", "message_id": "m1"}, map[string]string{"type": "DidSend", "message_id": "m1"}).
+			Respond(domain.ResultOfSendMessage{ShardBlockID: "b1"})
+		fake.On("processing.wait_for_transaction").Fail(domain.ClientError{Code: domain.ProcessingErrorMessageExpired, Message: "Message expired"})
+
+		proc := processing.NewProcessing(config, fake)
+		events := 0
+		result, err := proc.SendMessage(&domain.ParamsOfSendMessage{Message: "te6", SendEvents: true}, func(*domain.ProcessingEvent) {
+			events++
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "b1", result.ShardBlockID)
+		assert.Equal(t, 2, events)
+
+		_, err = proc.WaitForTransaction(&domain.ParamsOfWaitForTransaction{Message: "te6", ShardBlockID: "b1"}, func(*domain.ProcessingEvent) {})
+		assert.True(t, errors.Is(err, domain.ErrMessageExpired))
+
+		var params domain.ParamsOfSendMessage
+		assert.Equal(t, nil, fake.CallsOf("processing.send_message")[0].Decode(&params))
+		assert.Equal(t, "te6", params.Message)
+	})
+
+	t.Run("TestAppRequest", func(t *testing.T) {
+		fake := NewFake()
+		defer fake.Destroy()
+		fake.On("crypto.register_encryption_box").
+			Respond(domain.RegisteredEncryptionBox{Handle: 5}).
+			AppRequest(map[string]string{"type": "GetInfo"}).
+			KeepOpen()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		box, err := crypto.NewCrypto(config, fake).RegisterEncryptionBoxCtx(ctx, encryptionBox{})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, domain.EncryptionBoxHandle(5), box.Handle)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(fake.CallsOf("client.resolve_app_request")) == 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		calls := fake.CallsOf("client.resolve_app_request")
+		assert.Equal(t, 1, len(calls))
+		var resolved domain.ParamsOfResolveAppRequest
+		assert.Equal(t, nil, calls[0].Decode(&resolved))
+		assert.Equal(t, 1, resolved.AppRequestID)
+	})
+
+	t.Run("TestSupports", func(t *testing.T) {
+		fake := NewFake()
+		defer fake.Destroy()
+		fake.On("processing.send_message").Respond(domain.ResultOfSendMessage{})
+		assert.True(t, fake.Supports("processing.send_message"))
+		assert.False(t, fake.Supports("processing.monitor_messages"))
+	})
+}
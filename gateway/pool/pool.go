@@ -0,0 +1,434 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// Strategy - how requests which are not pinned to a context are distributed.
+type Strategy int
+
+const (
+	// RoundRobin - contexts take requests in turn.
+	RoundRobin Strategy = iota
+	// LeastInFlight - the context with the fewest open response streams takes the request.
+	LeastInFlight
+)
+
+// broadcast - functions which change the state of every context. They are sent to all contexts
+// and return the result of the first one.
+var broadcast = map[string]bool{
+	"net.suspend":       true,
+	"net.resume":        true,
+	"net.set_endpoints": true,
+}
+
+var bocRef = regexp.MustCompile(`"\*[0-9a-fA-F]{64}"`)
+
+type (
+	// Option - configures NewPoolGateway.
+	Option func(*PoolGateway)
+
+	// PoolGateway - domain.ClientGateway over several client contexts created from the same config.
+	// Stateless requests are balanced between the contexts. Requests which use a stateful handle
+	// (see domain.HandleKinds), a boc cache reference or a boc cache pin go to the context which created it.
+	// Handles returned by the pool are pool-wide and differ from the handles of the contexts.
+	PoolGateway struct {
+		domain.BaseGateway
+		members    []*member
+		strategy   Strategy
+		next       uint32
+		appObjects []domain.AppObjectOption
+
+		mu          sync.Mutex
+		lastID      int
+		handles     map[string]map[int]*handle
+		appRequests map[int]*handle
+		refs        map[string]*member
+		pins        map[string]*member
+	}
+
+	member struct {
+		gateway  domain.ClientGateway
+		inFlight int64
+	}
+
+	// handle - object of a context behind a pool-wide handle.
+	handle struct {
+		member *member
+		local  int
+	}
+
+	// route - context and params of a request after the pool handles were replaced with local ones.
+	route struct {
+		member *member
+		params interface{}
+		pin    string
+		err    error
+	}
+)
+
+// WithStrategy - sets the balancing strategy, RoundRobin by default.
+func WithStrategy(strategy Strategy) Option {
+	return func(p *PoolGateway) {
+		p.strategy = strategy
+	}
+}
+
+// WithAppObjectOptions - configures the dispatcher of app object callbacks, see domain.AppObjectDispatcher.
+// App objects registered through the pool are served by the pool, not by its contexts.
+func WithAppObjectOptions(opts ...domain.AppObjectOption) Option {
+	return func(p *PoolGateway) {
+		p.appObjects = append(p.appObjects, opts...)
+	}
+}
+
+// NewPoolGateway creates size contexts with newGateway, e.g. client.NewClientGateway.
+// If any of them fails the created ones are destroyed.
+func NewPoolGateway(config domain.ClientConfig, size int, newGateway func(domain.ClientConfig) (domain.ClientGateway, error), opts ...Option) (*PoolGateway, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("pool: invalid size %d", size)
+	}
+	p := &PoolGateway{
+		handles:     make(map[string]map[int]*handle),
+		appRequests: make(map[int]*handle),
+		refs:        make(map[string]*member),
+		pins:        make(map[string]*member),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	for i := 0; i < size; i++ {
+		gateway, err := newGateway(config)
+		if err != nil {
+			p.Destroy()
+			return nil, err
+		}
+		p.members = append(p.members, &member{gateway: gateway})
+	}
+	p.Requester = p.request
+	p.Dispatcher = domain.NewAppObjectDispatcher(p, p.appObjects...)
+
+	return p, nil
+}
+
+// Size returns the number of contexts.
+func (p *PoolGateway) Size() int {
+	return len(p.members)
+}
+
+// Destroy destroys every context.
+func (p *PoolGateway) Destroy() {
+	for _, m := range p.members {
+		m.gateway.Destroy()
+	}
+}
+
+// Close closes every context concurrently and returns the first error.
+func (p *PoolGateway) Close(ctx context.Context) error {
+	errs := make([]error, len(p.members))
+	var wg sync.WaitGroup
+	for i, m := range p.members {
+		wg.Add(1)
+		go func(i int, m *member) {
+			defer wg.Done()
+			errs[i] = m.gateway.Close(ctx)
+		}(i, m)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Supports asks the first context; all of them run the same library.
+func (p *PoolGateway) Supports(method string) bool {
+	return p.members[0].gateway.Supports(method)
+}
+
+// Tracer returns the tracer of the first context, see domain.TracerOf.
+func (p *PoolGateway) Tracer() domain.Tracer {
+	return domain.TracerOf(p.members[0].gateway)
+}
+
+// ReportError passes err to the first context.
+func (p *PoolGateway) ReportError(err error) {
+	p.members[0].gateway.ReportError(err)
+}
+
+func (p *PoolGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if broadcast[method] {
+		return p.broadcast(ctx, method, paramIn)
+	}
+	r := p.route(method, paramIn)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	atomic.AddInt64(&r.member.inFlight, 1)
+	responses, err := r.member.gateway.RequestContext(ctx, method, r.params)
+	if err != nil {
+		atomic.AddInt64(&r.member.inFlight, -1)
+		return nil, err
+	}
+
+	kind, _ := domain.HandleKindOf(method)
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		defer atomic.AddInt64(&r.member.inFlight, -1)
+		for res := range responses {
+			res = p.translate(r.member, kind, res)
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				for range responses {
+				}
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// route - chooses the context of a request and replaces pool handles in its params with local ones.
+func (p *PoolGateway) route(method string, paramIn interface{}) *route {
+	r := &route{params: paramIn}
+	if paramIn == nil {
+		r.member = p.pick()
+		return r
+	}
+	raw, err := json.Marshal(paramIn)
+	if err != nil {
+		return &route{err: err}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var params interface{}
+	if err := decoder.Decode(&params); err != nil {
+		return &route{err: err}
+	}
+
+	p.mu.Lock()
+	params = p.localize(r, method, "", "", params)
+	if method == "client.resolve_app_request" {
+		p.localizeAppRequest(r, params)
+	}
+	if r.member == nil && r.pin != "" {
+		r.member = p.pins[r.pin]
+	}
+	p.mu.Unlock()
+	if r.err != nil {
+		return r
+	}
+
+	if r.member == nil {
+		r.member = p.pick()
+	}
+	if r.pin != "" {
+		p.mu.Lock()
+		if _, ok := p.pins[r.pin]; !ok {
+			p.pins[r.pin] = r.member
+		}
+		p.mu.Unlock()
+	}
+	if raw, err = json.Marshal(params); err != nil {
+		return &route{err: err}
+	}
+	r.params = json.RawMessage(raw)
+
+	return r
+}
+
+// localize - walks the params; field is the name of value in its object, tag the "type" of that object.
+func (p *PoolGateway) localize(r *route, method, field, tag string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		tag, _ := value["type"].(string)
+		for name, v := range value {
+			value[name] = p.localize(r, method, name, tag, v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = p.localize(r, method, field, "", v)
+		}
+	case json.Number:
+		kind, ok := domain.ParamKind(method, field, tag)
+		if !ok {
+			return value
+		}
+		id, err := strconv.Atoi(value.String())
+		h, ok := p.handles[kind.Name][id]
+		if err != nil || !ok {
+			r.fail(fmt.Errorf("pool: unknown %s handle %s", kind.Name, value))
+			return value
+		}
+		r.use(h.member)
+		if method == kind.Release {
+			delete(p.handles[kind.Name], id)
+		}
+		return json.Number(strconv.Itoa(h.local))
+	case string:
+		if field == "pin" {
+			r.pin = value
+		} else if m, ok := p.refs[value]; ok {
+			r.use(m)
+		}
+	}
+
+	return value
+}
+
+func (p *PoolGateway) localizeAppRequest(r *route, params interface{}) {
+	object, ok := params.(map[string]interface{})
+	if !ok {
+		return
+	}
+	number, _ := object["app_request_id"].(json.Number)
+	id, err := strconv.Atoi(number.String())
+	h, ok := p.appRequests[id]
+	if err != nil || !ok {
+		r.fail(fmt.Errorf("pool: unknown app request %s", number))
+		return
+	}
+	delete(p.appRequests, id)
+	r.use(h.member)
+	object["app_request_id"] = json.Number(strconv.Itoa(h.local))
+}
+
+func (r *route) use(m *member) {
+	if r.member != nil && r.member != m {
+		r.fail(errors.New("pool: params refer to objects of different contexts"))
+		return
+	}
+	r.member = m
+}
+
+func (r *route) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (p *PoolGateway) pick() *member {
+	if p.strategy == LeastInFlight {
+		best := p.members[0]
+		for _, m := range p.members[1:] {
+			if atomic.LoadInt64(&m.inFlight) < atomic.LoadInt64(&best.inFlight) {
+				best = m
+			}
+		}
+		return best
+	}
+
+	return p.members[int(atomic.AddUint32(&p.next, 1)-1)%len(p.members)]
+}
+
+// translate - replaces local handles and app request IDs in a response with pool-wide ones
+// and remembers the boc cache references it contains.
+func (p *PoolGateway) translate(m *member, kind *domain.HandleKind, res *domain.ClientResponse) *domain.ClientResponse {
+	if res.Error != nil || res.Data == nil {
+		return res
+	}
+	if refs := bocRef.FindAll(res.Data, -1); len(refs) != 0 {
+		p.mu.Lock()
+		for _, ref := range refs {
+			p.refs[string(ref[1:len(ref)-1])] = m
+		}
+		p.mu.Unlock()
+	}
+
+	switch {
+	case res.Code == 0 && kind != nil:
+		return p.globalize(m, res, kind.ResultField, func(local int) int {
+			return p.register(p.handlesOf(kind.Name), m, local)
+		})
+	case res.Code == 3:
+		return p.globalize(m, res, "app_request_id", func(local int) int {
+			return p.register(p.appRequests, m, local)
+		})
+	}
+
+	return res
+}
+
+func (p *PoolGateway) globalize(m *member, res *domain.ClientResponse, field string, register func(local int) int) *domain.ClientResponse {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(res.Data, &object); err != nil {
+		return res
+	}
+	local, err := strconv.Atoi(string(object[field]))
+	if err != nil {
+		return res
+	}
+	object[field] = json.RawMessage(strconv.Itoa(register(local)))
+	data, err := json.Marshal(object)
+	if err != nil {
+		return res
+	}
+
+	return &domain.ClientResponse{Code: res.Code, Data: data}
+}
+
+func (p *PoolGateway) handlesOf(kind string) map[int]*handle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	handles, ok := p.handles[kind]
+	if !ok {
+		handles = make(map[int]*handle)
+		p.handles[kind] = handles
+	}
+
+	return handles
+}
+
+func (p *PoolGateway) register(ids map[int]*handle, m *member, local int) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastID++
+	ids[p.lastID] = &handle{member: m, local: local}
+
+	return p.lastID
+}
+
+// broadcast - sends the request to every context and returns the result of the first one
+// or the first error.
+func (p *PoolGateway) broadcast(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	results := make([][]byte, len(p.members))
+	errs := make([]error, len(p.members))
+	var wg sync.WaitGroup
+	for i, m := range p.members {
+		wg.Add(1)
+		go func(i int, m *member) {
+			defer wg.Done()
+			results[i], errs[i] = m.gateway.GetResponseContext(ctx, method, paramIn)
+		}(i, m)
+	}
+	wg.Wait()
+
+	out := make(chan *domain.ClientResponse, 1)
+	defer close(out)
+	for _, err := range errs {
+		if err != nil {
+			out <- &domain.ClientResponse{Code: 1, Error: err}
+			return out, nil
+		}
+	}
+	out <- &domain.ClientResponse{Code: 0, Data: results[0]}
+
+	return out, nil
+}
@@ -0,0 +1,164 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPool(t *testing.T, size int, opts ...Option) (*PoolGateway, []*clientmock.Fake) {
+	var fakes []*clientmock.Fake
+	p, err := NewPoolGateway(domain.NewDefaultConfig("", nil, ""), size, func(domain.ClientConfig) (domain.ClientGateway, error) {
+		fake := clientmock.NewFake()
+		fake.On("client.version").Respond(domain.ResultOfVersion{Version: "1.40.0"})
+		fakes = append(fakes, fake)
+		return fake, nil
+	}, opts...)
+	assert.Equal(t, nil, err)
+
+	return p, fakes
+}
+
+func TestPool(t *testing.T) {
+	var _ domain.ClientGateway = (*PoolGateway)(nil)
+
+	t.Run("TestRoundRobin", func(t *testing.T) {
+		p, fakes := newTestPool(t, 2)
+		defer p.Destroy()
+		for i := 0; i < 4; i++ {
+			_, err := p.Version()
+			assert.Equal(t, nil, err)
+		}
+		fakes[0].AssertNumberOfCalls(t, "client.version", 2)
+		fakes[1].AssertNumberOfCalls(t, "client.version", 2)
+	})
+
+	t.Run("TestLeastInFlight", func(t *testing.T) {
+		p, fakes := newTestPool(t, 2, WithStrategy(LeastInFlight))
+		defer p.Destroy()
+		fakes[0].On("net.subscribe_collection").Respond(map[string]int{"handle": 1}).KeepOpen()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := p.RequestContext(ctx, "net.subscribe_collection", map[string]string{"collection": "blocks"})
+		assert.Equal(t, nil, err)
+		for i := 0; i < 3; i++ {
+			_, err := p.Version()
+			assert.Equal(t, nil, err)
+		}
+		fakes[0].AssertNotCalled(t, "client.version")
+		fakes[1].AssertNumberOfCalls(t, "client.version", 3)
+	})
+
+	t.Run("TestHandles", func(t *testing.T) {
+		p, fakes := newTestPool(t, 2)
+		defer p.Destroy()
+		for _, fake := range fakes {
+			fake.On("crypto.get_signing_box").Respond(domain.RegisteredSigningBox{Handle: 1})
+			fake.On("crypto.signing_box_get_public_key").Respond(domain.ResultOfSigningBoxGetPublicKey{PubKey: "key"})
+			fake.On("abi.encode_message").Respond(domain.ResultOfEncodeMessage{Message: "message"})
+			fake.On("crypto.remove_signing_box").Respond(map[string]interface{}{})
+		}
+		first := new(domain.RegisteredSigningBox)
+		second := new(domain.RegisteredSigningBox)
+		assert.Equal(t, nil, p.GetResult("crypto.get_signing_box", domain.KeyPair{}, first))
+		assert.Equal(t, nil, p.GetResult("crypto.get_signing_box", domain.KeyPair{}, second))
+		assert.NotEqual(t, first.Handle, second.Handle)
+
+		for i := 0; i < 2; i++ {
+			_, err := p.GetResponse("crypto.signing_box_get_public_key", domain.RegisteredSigningBox{Handle: second.Handle})
+			assert.Equal(t, nil, err)
+		}
+		fakes[0].AssertNotCalled(t, "crypto.signing_box_get_public_key")
+		fakes[1].AssertCalledWith(t, "crypto.signing_box_get_public_key", map[string]int{"handle": 1})
+
+		signer := map[string]interface{}{"type": "SigningBox", "handle": first.Handle}
+		_, err := p.GetResponse("abi.encode_message", map[string]interface{}{"signer": signer})
+		assert.Equal(t, nil, err)
+		fakes[1].AssertNotCalled(t, "abi.encode_message")
+		fakes[0].AssertCalledWith(t, "abi.encode_message", map[string]interface{}{"signer": map[string]interface{}{"type": "SigningBox", "handle": 1}})
+
+		_, err = p.GetResponse("crypto.remove_signing_box", domain.RegisteredSigningBox{Handle: first.Handle})
+		assert.Equal(t, nil, err)
+		_, err = p.GetResponse("crypto.remove_signing_box", domain.RegisteredSigningBox{Handle: first.Handle})
+		assert.Equal(t, true, err != nil && strings.Contains(err.Error(), "unknown signing_box handle"))
+
+		_, err = p.GetResponse("abi.encode_message", map[string]interface{}{
+			"signer":      map[string]interface{}{"type": "SigningBox", "handle": second.Handle},
+			"signing_box": 12345,
+		})
+		assert.NotEqual(t, nil, err)
+	})
+
+	t.Run("TestBocRefs", func(t *testing.T) {
+		p, fakes := newTestPool(t, 2)
+		defer p.Destroy()
+		ref := "*" + strings.Repeat("ab", 32)
+		fakes[0].On("boc.cache_set").Respond(domain.ResultOfBocCacheSet{BocRef: ref})
+		fakes[0].On("boc.cache_get").Respond(domain.ResultOfBocCacheGet{Boc: "boc"})
+		fakes[0].On("boc.cache_unpin").Respond(map[string]interface{}{})
+		pinned := map[string]interface{}{"boc": "boc", "cache_type": map[string]string{"type": "Pinned", "pin": "p"}}
+		_, err := p.GetResponse("boc.cache_set", pinned)
+		assert.Equal(t, nil, err)
+		for i := 0; i < 2; i++ {
+			_, err = p.GetResponse("boc.cache_get", map[string]string{"boc_ref": ref})
+			assert.Equal(t, nil, err)
+		}
+		_, err = p.GetResponse("boc.cache_unpin", map[string]string{"pin": "p"})
+		assert.Equal(t, nil, err)
+		fakes[0].AssertNumberOfCalls(t, "boc.cache_get", 2)
+		fakes[0].AssertCalled(t, "boc.cache_unpin")
+		fakes[1].AssertNotCalled(t, "boc.cache_get")
+	})
+
+	t.Run("TestAppRequests", func(t *testing.T) {
+		p, fakes := newTestPool(t, 2)
+		defer p.Destroy()
+		for _, fake := range fakes {
+			fake.On("crypto.register_signing_box").
+				AppRequest(map[string]string{"type": "GetPublicKey"}).
+				Respond(domain.RegisteredSigningBox{Handle: 1})
+		}
+		ids := make(map[int]bool)
+		for i := 0; i < 2; i++ {
+			_, err := p.CallStream(context.Background(), "crypto.register_signing_box", nil, func(code uint32, data json.RawMessage) {
+				if code != 3 {
+					return
+				}
+				request := new(domain.ParamsOfAppRequest)
+				assert.Equal(t, nil, json.Unmarshal(data, request))
+				ids[request.AppRequestID] = true
+				assert.Equal(t, nil, p.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
+					AppRequestID: request.AppRequestID,
+					Result:       &domain.AppRequestResult{ValueEnumType: domain.AppRequestResultOk{Result: json.RawMessage(`{}`)}},
+				}))
+			})
+			assert.Equal(t, nil, err)
+		}
+		assert.Equal(t, 2, len(ids))
+		for _, fake := range fakes {
+			calls := fake.CallsOf("client.resolve_app_request")
+			assert.Equal(t, 1, len(calls))
+			resolved := new(domain.ParamsOfResolveAppRequest)
+			assert.Equal(t, nil, calls[0].Decode(resolved))
+			assert.Equal(t, 1, resolved.AppRequestID)
+		}
+	})
+
+	t.Run("TestBroadcast", func(t *testing.T) {
+		p, fakes := newTestPool(t, 3)
+		defer p.Destroy()
+		for _, fake := range fakes {
+			fake.On("net.suspend").Respond(map[string]interface{}{})
+		}
+		_, err := p.GetResponse("net.suspend", nil)
+		assert.Equal(t, nil, err)
+		for _, fake := range fakes {
+			fake.AssertNumberOfCalls(t, "net.suspend", 1)
+		}
+	})
+}
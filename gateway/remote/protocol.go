@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"encoding/json"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// The wire protocol is plain HTTP with JSON bodies:
+//
+//	POST   /contexts               body: ClientConfig     -> {"result": <context id>} or {"error": ClientError}
+//	DELETE /contexts/{id}                                 -> 204
+//	POST   /contexts/{id}/requests body: requestBody      -> stream of responseFrame, one JSON object per line
+//
+// The response stream of a request carries the same codes as the library callback
+// (0 result, 1 error, 2 nop, 3 app request, 4 app notify, 100 event) and ends when the request is finished.
+// App requests are resolved with an ordinary client.resolve_app_request request.
+
+const (
+	contextsPath = "/contexts"
+	requestsPath = "/requests"
+)
+
+type (
+	contextResponse struct {
+		Result uint32              `json:"result"`
+		Error  *domain.ClientError `json:"error,omitempty"`
+	}
+
+	requestBody struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params,omitempty"`
+	}
+
+	responseFrame struct {
+		Code uint32          `json:"code"`
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+)
+
+func newFrame(r *domain.ClientResponse) *responseFrame {
+	frame := &responseFrame{Code: r.Code, Data: r.Data}
+	if r.Error != nil {
+		clientErr, ok := r.Error.(*domain.ClientError)
+		if !ok {
+			clientErr = &domain.ClientError{Message: r.Error.Error()}
+		}
+		frame.Data, _ = json.Marshal(clientErr)
+	}
+
+	return frame
+}
+
+func (f *responseFrame) clientResponse() *domain.ClientResponse {
+	res := &domain.ClientResponse{Code: f.Code}
+	if f.Code == 1 {
+		res.Error = domain.NewClientError(f.Data)
+	} else {
+		res.Data = f.Data
+	}
+
+	return res
+}
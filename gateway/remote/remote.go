@@ -0,0 +1,355 @@
+// Package remote implements domain.ClientGateway without cgo by forwarding every request
+// to an out-of-process tonclient server (see NewServer) over HTTP.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+type (
+	// Option - configures the remote gateway.
+	Option func(*remoteGateway)
+
+	remoteGateway struct {
+		domain.BaseGateway
+		url          string
+		httpClient   *http.Client
+		contextID    uint32
+		ctx          context.Context
+		cancel       context.CancelFunc
+		interceptors []domain.Interceptor
+		appObjects   []domain.AppObjectOption
+		logger       domain.Logger
+		metrics      domain.Metrics
+		tracer       domain.Tracer
+		limiter      *domain.Limiter
+
+		mu        sync.Mutex
+		closing   bool
+		pending   int
+		drained   chan struct{}
+		closeOnce sync.Once
+	}
+)
+
+// WithHTTPClient - sets the HTTP client used to talk to the server. The client must not time out streaming bodies.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(r *remoteGateway) {
+		r.httpClient = httpClient
+	}
+}
+
+// WithInterceptors - wraps every request of the gateway, including streamed events and app requests, with interceptors.
+// The first interceptor is the outermost one.
+func WithInterceptors(interceptors ...domain.Interceptor) Option {
+	return func(r *remoteGateway) {
+		r.interceptors = append(r.interceptors, interceptors...)
+	}
+}
+
+// WithErrorHandler - receives the errors of app object callbacks, see domain.AppObjectDispatcher and domain.ResolveAppObject.
+func WithErrorHandler(handler domain.ErrorHandler) Option {
+	return func(r *remoteGateway) {
+		r.ErrorHandler = handler
+	}
+}
+
+// WithLogger - logs the requests of the gateway, see domain.LoggingInterceptor, and the errors of app object
+// callbacks and subscriptions unless WithErrorHandler is given. The logging interceptor is the outermost one.
+func WithLogger(logger domain.Logger) Option {
+	return func(r *remoteGateway) {
+		r.logger = logger
+	}
+}
+
+// WithMetrics - reports the requests, subscriptions and app requests of the gateway to metrics,
+// see domain.MetricsInterceptor. It wraps the interceptors of WithInterceptors.
+func WithMetrics(metrics domain.Metrics) Option {
+	return func(r *remoteGateway) {
+		r.metrics = metrics
+	}
+}
+
+// WithTracer - wraps every request of the gateway in a span, see domain.TracingInterceptor, and lets the use cases
+// trace their work, see domain.TracerOf. It wraps the interceptors of WithInterceptors.
+func WithTracer(tracer domain.Tracer) Option {
+	return func(r *remoteGateway) {
+		r.tracer = tracer
+	}
+}
+
+// WithLimiter - holds the requests of the gateway until limiter lets them through, see domain.Limiter.
+// A limiter may be shared by gateways using the same quota. It wraps the interceptors of WithInterceptors.
+func WithLimiter(limiter *domain.Limiter) Option {
+	return func(r *remoteGateway) {
+		r.limiter = limiter
+	}
+}
+
+// WithAppObjectOptions - configures the dispatcher of app object callbacks, see domain.AppObjectDispatcher.
+func WithAppObjectOptions(opts ...domain.AppObjectOption) Option {
+	return func(r *remoteGateway) {
+		r.appObjects = append(r.appObjects, opts...)
+	}
+}
+
+// NewRemoteGateway creates a client context on the tonclient server at url.
+func NewRemoteGateway(url string, config domain.ClientConfig, opts ...Option) (domain.ClientGateway, error) {
+	r := &remoteGateway{
+		url:        strings.TrimRight(url, "/"),
+		httpClient: http.DefaultClient,
+		drained:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.limiter != nil {
+		r.interceptors = append([]domain.Interceptor{r.limiter.Interceptor()}, r.interceptors...)
+	}
+	if r.metrics != nil {
+		r.interceptors = append([]domain.Interceptor{domain.MetricsInterceptor(r.metrics)}, r.interceptors...)
+	}
+	if r.tracer != nil {
+		r.interceptors = append([]domain.Interceptor{domain.TracingInterceptor(r.tracer)}, r.interceptors...)
+	}
+	if r.logger != nil {
+		r.interceptors = append([]domain.Interceptor{domain.LoggingInterceptor(r.logger)}, r.interceptors...)
+		if r.ErrorHandler == nil {
+			r.ErrorHandler = domain.LogErrors(r.logger)
+		}
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.Requester = domain.ChainInterceptors(r.request, r.interceptors...)
+	r.Capabilities = domain.NewCapabilities(r.GetAPIReference)
+	r.Dispatcher = domain.NewAppObjectDispatcher(r, r.appObjects...)
+
+	contextID, err := r.createContext(config)
+	if err != nil {
+		r.cancel()
+		return nil, err
+	}
+	r.contextID = contextID
+
+	return r, nil
+}
+
+// createContext creates the client context with config on the server.
+func (r *remoteGateway) createContext(config domain.ClientConfig) (uint32, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := r.httpClient.Post(r.url+contextsPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+
+	var created contextResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return 0, err
+	}
+	if created.Error != nil {
+		return 0, created.Error
+	}
+
+	return created.Result, nil
+}
+
+// Tracer returns the tracer set by WithTracer, nil if none.
+func (r *remoteGateway) Tracer() domain.Tracer {
+	return r.tracer
+}
+
+func (r *remoteGateway) contextURL() string {
+	return r.url + contextsPath + "/" + strconv.FormatUint(uint64(r.contextID), 10)
+}
+
+// Destroy - closes the gateway without waiting for in-flight requests, see Close.
+func (r *remoteGateway) Destroy() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = r.Close(ctx)
+}
+
+// Close - stops accepting requests and waits until the in-flight ones get their results or ctx is done.
+// Then it destroys the context on the server. Requests still waiting after that fail with *domain.ClientClosedError.
+// Streams that stay open after their result (subscriptions, app objects) are not waited for.
+// Returns ctx.Err() if ctx was done before the requests finished; later calls return nil.
+func (r *remoteGateway) Close(ctx context.Context) error {
+	var err error
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		r.closing = true
+		if r.pending == 0 {
+			close(r.drained)
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-r.drained:
+		case <-ctx.Done():
+		}
+		err = ctx.Err()
+		r.cancel()
+		req, reqErr := http.NewRequest(http.MethodDelete, r.contextURL(), nil)
+		if reqErr != nil {
+			return
+		}
+		resp, reqErr := r.httpClient.Do(req)
+		if reqErr != nil {
+			return
+		}
+		resp.Body.Close()
+	})
+
+	return err
+}
+
+// settle - marks an in-flight request as finished.
+func (r *remoteGateway) settle() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending--
+	if r.closing && r.pending == 0 {
+		close(r.drained)
+	}
+}
+
+func (r *remoteGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	body := requestBody{Method: method}
+	if paramIn != nil {
+		params, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		body.Params = params
+	}
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	if r.closing {
+		r.mu.Unlock()
+		return nil, &domain.ClientClosedError{Method: method}
+	}
+	r.pending++
+	r.mu.Unlock()
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			cancel()
+		case <-stop:
+		}
+	}()
+	req, err := http.NewRequest(http.MethodPost, r.contextURL()+requestsPath, bytes.NewReader(rawBody))
+	if err != nil {
+		close(stop)
+		cancel()
+		r.settle()
+		return nil, err
+	}
+	req = req.WithContext(reqCtx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		close(stop)
+		cancel()
+		r.settle()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if r.ctx.Err() != nil {
+			return nil, &domain.ClientClosedError{Method: method}
+		}
+		return nil, err
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		close(stop)
+		cancel()
+		r.settle()
+		return nil, err
+	}
+
+	responses := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(responses)
+		defer close(stop)
+		defer cancel()
+		defer resp.Body.Close()
+		settled := false
+		defer func() {
+			if !settled {
+				r.settle()
+			}
+		}()
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var frame responseFrame
+			if err := decoder.Decode(&frame); err != nil {
+				switch {
+				case ctx.Err() != nil:
+				case r.ctx.Err() != nil && err != io.EOF:
+					r.deliver(ctx, responses, &domain.ClientResponse{Code: 1, Error: &domain.ClientClosedError{Method: method}})
+				case err != io.EOF:
+					r.deliver(reqCtx, responses, &domain.ClientResponse{Code: 1, Error: fmt.Errorf("remote stream: %w", err)})
+				}
+				return
+			}
+			res := frame.clientResponse()
+			if !settled && (res.Code == 0 || res.Code == 1) {
+				settled = true
+				r.settle()
+			}
+			if !r.deliver(reqCtx, responses, res) {
+				return
+			}
+		}
+	}()
+
+	return responses, nil
+}
+
+func (r *remoteGateway) deliver(ctx context.Context, responses chan<- *domain.ClientResponse, res *domain.ClientResponse) bool {
+	select {
+	case responses <- res:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	text, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	if len(text) == 0 {
+		return errors.New(resp.Status)
+	}
+
+	return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(text))
+}
@@ -0,0 +1,187 @@
+package remote_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/remote"
+	"github.com/move-ton/ever-client-go/gateway/remote/remotetest"
+	"github.com/move-ton/ever-client-go/usecase/crypto"
+	"github.com/move-ton/ever-client-go/usecase/processing"
+	"github.com/stretchr/testify/assert"
+)
+
+type encryptionBox struct{}
+
+func (encryptionBox) GetInfo() (domain.ResultOfAppEncryptionBoxGetInfo, error) {
+	return domain.ResultOfAppEncryptionBoxGetInfo{Info: domain.EncryptionBoxInfo{Algorithm: "remote"}}, nil
+}
+
+func (encryptionBox) Encrypt(domain.ParamsOfAppEncryptionBoxEncrypt) (domain.ResultOfAppEncryptionBoxEncrypt, error) {
+	return domain.ResultOfAppEncryptionBoxEncrypt{}, nil
+}
+
+func (encryptionBox) Decrypt(domain.ParamsOfAppEncryptionBoxDecrypt) (domain.ResultOfAppEncryptionBoxDecrypt, error) {
+	return domain.ResultOfAppEncryptionBoxDecrypt{}, nil
+}
+
+func TestRemote(t *testing.T) {
+	resolved := make(chan domain.ParamsOfResolveAppRequest, 1)
+	server := remotetest.NewServer(func(ctx context.Context, method string, params json.RawMessage, send func(uint32, interface{})) {
+		switch method {
+		case "client.version":
+			send(0, domain.ResultOfVersion{Version: "1.40.0"})
+		case "processing.send_message":
+			send(100, map[string]string{"type": "WillSend", "message_id": "m1", "shard_block_id": "b1"})
+			send(100, map[string]string{"type": "DidSend", "message_id": "m1", "shard_block_id": "b1"})
+			send(0, domain.ResultOfSendMessage{ShardBlockID: "b1"})
+		case "crypto.register_encryption_box":
+			send(0, domain.RegisteredEncryptionBox{Handle: 7})
+			send(3, map[string]interface{}{"app_request_id": 1, "request_data": map[string]string{"type": "GetInfo"}})
+			<-ctx.Done()
+		case "client.resolve_app_request":
+			var p domain.ParamsOfResolveAppRequest
+			_ = json.Unmarshal(params, &p)
+			resolved <- p
+			send(0, json.RawMessage(`{}`))
+		case "net.wait_for_collection":
+			<-ctx.Done()
+		case "net.query":
+			time.Sleep(50 * time.Millisecond)
+			send(0, domain.ResultOfQuery{Result: json.RawMessage(`{}`)})
+		default:
+			send(1, domain.ClientError{Code: domain.ClientErrorUnknownFunction, Message: "Unknown function"})
+		}
+	})
+	defer server.Close()
+
+	config := domain.NewDefaultConfig("", domain.GetLocalNetBaseUrls(), "")
+	gw, err := remote.NewRemoteGateway(server.URL, config)
+	assert.Equal(t, nil, err)
+	defer gw.Destroy()
+
+	t.Run("TestVersion", func(t *testing.T) {
+		version, err := gw.Version()
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "1.40.0", version.Version)
+	})
+
+	t.Run("TestTypedError", func(t *testing.T) {
+		_, err := gw.GetResponse("client.unknown_function", nil)
+		assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+	})
+
+	t.Run("TestEvents", func(t *testing.T) {
+		var events []*domain.ProcessingEvent
+		result, err := processing.NewProcessing(config, gw).SendMessage(&domain.ParamsOfSendMessage{Message: "te6", SendEvents: true}, func(event *domain.ProcessingEvent) {
+			events = append(events, event)
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "b1", result.ShardBlockID)
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, "m1", events[1].ValueEnumType.(domain.ProcessingEventDidSend).MessageID)
+	})
+
+	t.Run("TestAppRequest", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		box, err := crypto.NewCrypto(config, gw).RegisterEncryptionBoxCtx(ctx, encryptionBox{})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, domain.EncryptionBoxHandle(7), box.Handle)
+
+		select {
+		case p := <-resolved:
+			assert.Equal(t, 1, p.AppRequestID)
+			ok, isOk := p.Result.ValueEnumType.(domain.AppRequestResultOk)
+			assert.True(t, isOk)
+			assert.Contains(t, string(ok.Result), `"algorithm":"remote"`)
+		case <-time.After(5 * time.Second):
+			t.Fatal("app request was not resolved")
+		}
+	})
+
+	t.Run("TestCall", func(t *testing.T) {
+		raw, err := gw.Call("client.version", nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"version":"1.40.0"}`, string(raw))
+
+		var codes []uint32
+		raw, err = gw.CallStream(context.Background(), "processing.send_message", &domain.ParamsOfSendMessage{Message: "te6", SendEvents: true}, func(code uint32, data json.RawMessage) {
+			codes = append(codes, code)
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []uint32{100, 100, 0}, codes)
+		assert.Contains(t, string(raw), `"shard_block_id":"b1"`)
+
+		_, err = gw.CallStream(context.Background(), "client.unknown_function", nil, nil)
+		assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+	})
+
+	t.Run("TestInterceptors", func(t *testing.T) {
+		calls := make(chan *domain.CallInfo, 1)
+		observed, err := remote.NewRemoteGateway(server.URL, config, remote.WithInterceptors(domain.Observe(domain.CallObserver{
+			OnFinish: func(ctx context.Context, call *domain.CallInfo) {
+				if call.Method == "processing.send_message" {
+					calls <- call
+				}
+			},
+		})))
+		assert.Equal(t, nil, err)
+		defer observed.Destroy()
+
+		_, err = processing.NewProcessing(config, observed).SendMessage(&domain.ParamsOfSendMessage{Message: "te6", SendEvents: true}, func(*domain.ProcessingEvent) {})
+		assert.Equal(t, nil, err)
+		call := <-calls
+		assert.Equal(t, []uint32{100, 100, 0}, call.Codes)
+		assert.Contains(t, string(call.Params), `"message":"te6"`)
+	})
+
+	t.Run("TestContextDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := gw.GetResultContext(ctx, "net.wait_for_collection", &domain.ParamsOfWaitForCollection{Collection: "blocks"}, &domain.ResultOfWaitForCollection{})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+	t.Run("TestClose", func(t *testing.T) {
+		closed, err := remote.NewRemoteGateway(server.URL, config)
+		assert.Equal(t, nil, err)
+
+		// Close waits for the in-flight request.
+		queried := make(chan []byte, 1)
+		go func() {
+			raw, err := closed.GetResponse("net.query", &domain.ParamsOfQuery{Query: "query{info{version}}"})
+			assert.Equal(t, nil, err)
+			queried <- raw
+		}()
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, nil, closed.Close(context.Background()))
+		assert.Equal(t, `{"result":{}}`, string(<-queried))
+
+		_, err = closed.GetResponse("client.version", nil)
+		var closedErr *domain.ClientClosedError
+		assert.True(t, errors.As(err, &closedErr))
+		assert.Equal(t, "client.version", closedErr.Method)
+		assert.Equal(t, nil, closed.Close(context.Background()))
+	})
+
+	t.Run("TestCloseTimeout", func(t *testing.T) {
+		closed, err := remote.NewRemoteGateway(server.URL, config)
+		assert.Equal(t, nil, err)
+
+		waited := make(chan error, 1)
+		go func() {
+			_, err := closed.GetResponse("net.wait_for_collection", &domain.ParamsOfWaitForCollection{Collection: "blocks"})
+			waited <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, closed.Close(ctx))
+		// The request still waiting is failed rather than left hanging.
+		assert.True(t, errors.Is(<-waited, domain.ErrClientClosed))
+	})
+}
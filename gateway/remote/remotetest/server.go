@@ -0,0 +1,101 @@
+// Package remotetest provides an in-process stand-in for a tonclient server, so code using
+// the remote gateway can be tested without the native library.
+package remotetest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/remote"
+)
+
+type (
+	// Handler - produces the response stream of one request by calling send for every frame.
+	// Data is marshaled to JSON unless it is already []byte or json.RawMessage.
+	// The stream is finished when the handler returns.
+	Handler func(ctx context.Context, method string, params json.RawMessage, send func(code uint32, data interface{}))
+
+	standIn struct {
+		domain.BaseGateway
+		handler Handler
+		once    sync.Once
+		ctx     context.Context
+		cancel  context.CancelFunc
+	}
+)
+
+// NewServer starts a stand-in server. Every created context is served by handler; invalid configs are rejected
+// with the *domain.ConfigError of ClientConfig.Validate.
+// The caller must Close the returned server.
+func NewServer(handler Handler) *httptest.Server {
+	return httptest.NewServer(remote.NewServer(func(config domain.ClientConfig) (domain.ClientGateway, error) {
+		// Configs are checked as the library gateway checks them.
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+		s := &standIn{handler: handler}
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+		s.Requester = s.request
+		s.Capabilities = domain.NewCapabilities(s.GetAPIReference)
+		s.Dispatcher = domain.NewAppObjectDispatcher(s)
+		return s, nil
+	}))
+}
+
+func (s *standIn) Destroy() {
+	s.once.Do(s.cancel)
+}
+
+func (s *standIn) Close(context.Context) error {
+	s.Destroy()
+	return nil
+}
+
+func (s *standIn) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	var params json.RawMessage
+	if paramIn != nil {
+		raw, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		params = raw
+	}
+
+	responses := make(chan *domain.ClientResponse)
+	go func() {
+		defer close(responses)
+		s.handler(ctx, method, params, func(code uint32, data interface{}) {
+			res := &domain.ClientResponse{Code: code}
+			raw, err := marshal(data)
+			switch {
+			case err != nil:
+				res.Code, res.Error = 1, err
+			case code == 1:
+				res.Error = domain.NewClientError(raw)
+			default:
+				res.Data = raw
+			}
+			select {
+			case responses <- res:
+			case <-ctx.Done():
+			case <-s.ctx.Done():
+			}
+		})
+	}()
+
+	return responses, nil
+}
+
+func marshal(data interface{}) ([]byte, error) {
+	switch value := data.(type) {
+	case []byte:
+		return value, nil
+	case json.RawMessage:
+		return value, nil
+	default:
+		return json.Marshal(value)
+	}
+}
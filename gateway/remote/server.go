@@ -0,0 +1,159 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+type (
+	// GatewayFactory - creates the gateway serving one remote client context.
+	GatewayFactory func(config domain.ClientConfig) (domain.ClientGateway, error)
+
+	// Server - exposes gateways created by a GatewayFactory over the remote protocol.
+	// Wrap client.NewClientGateway to run a tonclient server next to the native library.
+	Server struct {
+		factory  GatewayFactory
+		mu       sync.Mutex
+		lastID   uint32
+		contexts map[uint32]domain.ClientGateway
+	}
+)
+
+// NewServer ...
+func NewServer(factory GatewayFactory) *Server {
+	return &Server{
+		factory:  factory,
+		contexts: make(map[uint32]domain.ClientGateway),
+	}
+}
+
+// Close destroys every context created through the server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	contexts := s.contexts
+	s.contexts = make(map[uint32]domain.ClientGateway)
+	s.mu.Unlock()
+	for _, gw := range contexts {
+		gw.Destroy()
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	if parts[0] != strings.Trim(contextsPath, "/") || len(parts) > 3 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.createContext(w, r)
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.destroyContext(w, parts[1])
+	case len(parts) == 3 && parts[2] == strings.Trim(requestsPath, "/") && r.Method == http.MethodPost:
+		s.request(w, r, parts[1])
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) createContext(w http.ResponseWriter, r *http.Request) {
+	var config domain.ClientConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response contextResponse
+	gw, err := s.factory(config)
+	if err != nil {
+		clientErr, ok := err.(*domain.ClientError)
+		if !ok {
+			clientErr = &domain.ClientError{Code: domain.ClientErrorInvalidConfig, Message: err.Error()}
+		}
+		response.Error = clientErr
+	} else {
+		s.mu.Lock()
+		s.lastID++
+		response.Result = s.lastID
+		s.contexts[s.lastID] = gw
+		s.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&response)
+}
+
+func (s *Server) lookup(rawID string) (uint32, domain.ClientGateway, bool) {
+	id, err := strconv.ParseUint(rawID, 10, 32)
+	if err != nil {
+		return 0, nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	gw, ok := s.contexts[uint32(id)]
+
+	return uint32(id), gw, ok
+}
+
+func (s *Server) destroyContext(w http.ResponseWriter, rawID string) {
+	id, gw, ok := s.lookup(rawID)
+	if !ok {
+		http.Error(w, "context not found", http.StatusNotFound)
+		return
+	}
+	s.mu.Lock()
+	delete(s.contexts, id)
+	s.mu.Unlock()
+	gw.Destroy()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) request(w http.ResponseWriter, r *http.Request, rawID string) {
+	_, gw, ok := s.lookup(rawID)
+	if !ok {
+		http.Error(w, "context not found", http.StatusNotFound)
+		return
+	}
+	var body requestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var paramIn interface{}
+	if len(body.Params) != 0 {
+		paramIn = body.Params
+	}
+	responses, err := gw.RequestContext(r.Context(), body.Method, paramIn)
+	if err != nil {
+		responses = failed(err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	for res := range responses {
+		if err := encoder.Encode(newFrame(res)); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func failed(err error) <-chan *domain.ClientResponse {
+	responses := make(chan *domain.ClientResponse, 1)
+	responses <- &domain.ClientResponse{Code: 1, Error: err}
+	close(responses)
+
+	return responses
+}
@@ -0,0 +1,78 @@
+// Package replay records the traffic of a domain.ClientGateway to a fixture file and replays it
+// without the native library or the network, so use case tests can run offline and deterministically.
+package replay
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+type (
+	// Fixture - recorded calls in the order they were made.
+	Fixture struct {
+		Calls []*Call `json:"calls"`
+	}
+
+	// Call - one recorded request with its full ordered response stream.
+	// Finished is false for streams that were still open when the fixture was saved (e.g. subscriptions, app objects).
+	Call struct {
+		Method    string          `json:"method"`
+		Params    json.RawMessage `json:"params,omitempty"`
+		Responses []Frame         `json:"responses"`
+		Finished  bool            `json:"finished"`
+	}
+
+	// Frame - one response. For errors (code 1) Data holds the SDK error object.
+	Frame struct {
+		Code uint32          `json:"code"`
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+)
+
+// LoadFixture reads a fixture file written by Recorder.
+func LoadFixture(path string) (*Fixture, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{}
+	if err := json.Unmarshal(raw, fixture); err != nil {
+		return nil, err
+	}
+
+	return fixture, nil
+}
+
+// Save writes the fixture to path.
+func (f *Fixture) Save(path string) error {
+	raw, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(raw, '\n'), 0644)
+}
+
+func newFrame(r *domain.ClientResponse) Frame {
+	frame := Frame{Code: r.Code, Data: r.Data}
+	if r.Error == nil {
+		return frame
+	}
+	if clientErr, ok := r.Error.(*domain.ClientError); ok {
+		frame.Data, _ = json.Marshal(clientErr)
+	} else {
+		frame.Data, _ = json.Marshal(domain.ClientError{Message: r.Error.Error()})
+	}
+
+	return frame
+}
+
+func (f Frame) clientResponse() *domain.ClientResponse {
+	if f.Code == 1 {
+		return &domain.ClientResponse{Code: 1, Error: domain.NewClientError(f.Data)}
+	}
+
+	return &domain.ClientResponse{Code: f.Code, Data: f.Data}
+}
@@ -0,0 +1,149 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+type (
+	// Recorder - gateway decorator which passes every request to the wrapped gateway and records it.
+	// Secret fields of params and responses, see domain.RedactSecrets, are recorded as domain.RedactedValue
+	// unless the recorder is created with KeepSecrets.
+	Recorder struct {
+		domain.BaseGateway
+		gateway     domain.ClientGateway
+		path        string
+		keepSecrets bool
+
+		mu      sync.Mutex
+		fixture Fixture
+	}
+
+	// RecorderOption - configures the recorder.
+	RecorderOption func(*Recorder)
+)
+
+// KeepSecrets - records secret fields as they are. Fixtures recorded with it must not be committed
+// unless the keys in them are test keys.
+func KeepSecrets() RecorderOption {
+	return func(r *Recorder) {
+		r.keepSecrets = true
+	}
+}
+
+// NewRecorder wraps gateway. The fixture is written to path on Save and on Destroy.
+func NewRecorder(gateway domain.ClientGateway, path string, opts ...RecorderOption) *Recorder {
+	r := &Recorder{gateway: gateway, path: path}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.Requester = r.request
+	r.Dispatcher = domain.NewAppObjectDispatcher(r)
+
+	return r
+}
+
+// Fixture returns a copy of the calls recorded so far.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fixture := &Fixture{Calls: make([]*Call, len(r.fixture.Calls))}
+	for i, call := range r.fixture.Calls {
+		c := *call
+		c.Responses = append([]Frame(nil), call.Responses...)
+		fixture.Calls[i] = &c
+	}
+
+	return fixture
+}
+
+// Save writes the calls recorded so far to the fixture file.
+func (r *Recorder) Save() error {
+	return r.Fixture().Save(r.path)
+}
+
+// Destroy saves the fixture and destroys the wrapped gateway.
+// Use Save beforehand to get the error.
+func (r *Recorder) Destroy() {
+	_ = r.Save()
+	r.gateway.Destroy()
+}
+
+// Close saves the fixture and closes the wrapped gateway.
+func (r *Recorder) Close(ctx context.Context) error {
+	err := r.Save()
+	if closeErr := r.gateway.Close(ctx); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Supports asks the wrapped gateway.
+func (r *Recorder) Supports(method string) bool {
+	return r.gateway.Supports(method)
+}
+
+// Tracer returns the tracer of the wrapped gateway, see domain.TracerOf.
+func (r *Recorder) Tracer() domain.Tracer {
+	return domain.TracerOf(r.gateway)
+}
+
+// ReportError passes err to the wrapped gateway.
+func (r *Recorder) ReportError(err error) {
+	r.gateway.ReportError(err)
+}
+
+func (r *Recorder) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	call := &Call{Method: method, Responses: []Frame{}}
+	if paramIn != nil {
+		params, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		call.Params = r.redact(params)
+	}
+
+	responses, err := r.gateway.RequestContext(ctx, method, paramIn)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.fixture.Calls = append(r.fixture.Calls, call)
+	r.mu.Unlock()
+
+	out := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(out)
+		for res := range responses {
+			r.mu.Lock()
+			frame := newFrame(res)
+			frame.Data = r.redact(frame.Data)
+			call.Responses = append(call.Responses, frame)
+			r.mu.Unlock()
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				for range responses {
+				}
+				return
+			}
+		}
+		r.mu.Lock()
+		call.Finished = ctx.Err() == nil
+		r.mu.Unlock()
+	}()
+
+	return out, nil
+}
+
+func (r *Recorder) redact(raw json.RawMessage) json.RawMessage {
+	if r.keepSecrets {
+		return raw
+	}
+
+	return domain.RedactSecrets(raw)
+}
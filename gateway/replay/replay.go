@@ -0,0 +1,215 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// RecordEnv - when this environment variable is set, Open records fixtures from the live gateway instead of replaying them.
+const RecordEnv = "EVER_CLIENT_RECORD"
+
+type (
+	// Matcher reports whether a recorded call of method with params recorded can answer a request with params actual.
+	// Secret fields of both are replaced by domain.RedactedValue beforehand, so fixtures recorded with
+	// and without KeepSecrets match the same requests.
+	Matcher func(method string, recorded, actual json.RawMessage) bool
+
+	// Option - configures the replay gateway.
+	Option func(*replayGateway)
+
+	replayGateway struct {
+		domain.BaseGateway
+		matcher Matcher
+
+		mu    sync.Mutex
+		calls []*Call
+		used  []bool
+
+		ctx    context.Context
+		cancel context.CancelFunc
+	}
+)
+
+// WithMatcher - sets how request params are matched against recorded ones. The default is MatchExact.
+func WithMatcher(matcher Matcher) Option {
+	return func(r *replayGateway) {
+		r.matcher = matcher
+	}
+}
+
+// MatchExact matches params which are equal as JSON documents.
+func MatchExact(_ string, recorded, actual json.RawMessage) bool {
+	return jsonEqual(recorded, actual, nil)
+}
+
+// MatchMethod matches any params, so calls of a method are replayed in the recorded order.
+func MatchMethod(string, json.RawMessage, json.RawMessage) bool {
+	return true
+}
+
+// IgnoreFields matches params which are equal as JSON documents after the fields are removed at any depth.
+// Useful for values which change between runs, e.g. timestamps or generated keys.
+func IgnoreFields(fields ...string) Matcher {
+	ignored := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		ignored[field] = true
+	}
+
+	return func(_ string, recorded, actual json.RawMessage) bool {
+		return jsonEqual(recorded, actual, ignored)
+	}
+}
+
+// NewReplayGateway answers requests from fixture. Every recorded call is replayed once;
+// a request is answered by the first unused call of the same method with matching params.
+func NewReplayGateway(fixture *Fixture, opts ...Option) domain.ClientGateway {
+	r := &replayGateway{
+		matcher: MatchExact,
+		calls:   fixture.Calls,
+		used:    make([]bool, len(fixture.Calls)),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.Requester = r.request
+	r.Capabilities = domain.NewCapabilities(r.GetAPIReference)
+	r.Dispatcher = domain.NewAppObjectDispatcher(r)
+
+	return r
+}
+
+// Load - NewReplayGateway from the fixture file at path.
+func Load(path string, opts ...Option) (domain.ClientGateway, error) {
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayGateway(fixture, opts...), nil
+}
+
+// Open - gateway for tests which can run both live and offline.
+// If RecordEnv is set, the gateway created by live is wrapped in a Recorder writing to path.
+// Otherwise the fixture at path is replayed, or, if there is none, the live gateway is used as is.
+func Open(path string, live func() (domain.ClientGateway, error), opts ...Option) (domain.ClientGateway, error) {
+	if os.Getenv(RecordEnv) != "" {
+		gateway, err := live()
+		if err != nil {
+			return nil, err
+		}
+		return NewRecorder(gateway, path), nil
+	}
+	if _, err := os.Stat(path); err == nil {
+		return Load(path, opts...)
+	}
+
+	return live()
+}
+
+// Destroy closes the streams which were left open in the fixture.
+func (r *replayGateway) Destroy() {
+	r.cancel()
+}
+
+// Close - Destroy.
+func (r *replayGateway) Close(context.Context) error {
+	r.Destroy()
+	return nil
+}
+
+func (r *replayGateway) request(ctx context.Context, method string, paramIn interface{}) (<-chan *domain.ClientResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var params json.RawMessage
+	if paramIn != nil {
+		raw, err := json.Marshal(paramIn)
+		if err != nil {
+			return nil, err
+		}
+		params = raw
+	}
+
+	params = domain.RedactSecrets(params)
+	call := r.take(method, params)
+	if call == nil {
+		return nil, fmt.Errorf("replay: no recorded call of %s matches params %s", method, params)
+	}
+
+	responses := make(chan *domain.ClientResponse, 1)
+	go func() {
+		defer close(responses)
+		for _, frame := range call.Responses {
+			select {
+			case responses <- frame.clientResponse():
+			case <-ctx.Done():
+				return
+			case <-r.ctx.Done():
+				return
+			}
+		}
+		if !call.Finished {
+			select {
+			case <-ctx.Done():
+			case <-r.ctx.Done():
+			}
+		}
+	}()
+
+	return responses, nil
+}
+
+func (r *replayGateway) take(method string, params json.RawMessage) *Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, call := range r.calls {
+		if r.used[i] || call.Method != method || !r.matcher(method, domain.RedactSecrets(call.Params), params) {
+			continue
+		}
+		r.used[i] = true
+		return call
+	}
+
+	return nil
+}
+
+func jsonEqual(a, b json.RawMessage, ignored map[string]bool) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var docA, docB interface{}
+	if json.Unmarshal(a, &docA) != nil || json.Unmarshal(b, &docB) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(strip(docA, ignored), strip(docB, ignored))
+}
+
+func strip(doc interface{}, ignored map[string]bool) interface{} {
+	if len(ignored) == 0 {
+		return doc
+	}
+	switch value := doc.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if ignored[k] {
+				delete(value, k)
+				continue
+			}
+			value[k] = strip(v, ignored)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = strip(v, ignored)
+		}
+	}
+
+	return doc
+}
@@ -0,0 +1,137 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/remote"
+	"github.com/move-ton/ever-client-go/gateway/remote/remotetest"
+	"github.com/move-ton/ever-client-go/usecase/processing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	server := remotetest.NewServer(func(ctx context.Context, method string, params json.RawMessage, send func(uint32, interface{})) {
+		switch method {
+		case "processing.send_message":
+			send(100, map[string]string{"type": "WillSend", "message_id": "m1", "shard_block_id": "b1"})
+			send(0, domain.ResultOfSendMessage{ShardBlockID: "b1"})
+		case "net.subscribe_collection":
+			send(0, domain.ResultOfSubscribeCollection{Handle: 3})
+			<-ctx.Done()
+		case "crypto.nacl_sign_keypair_from_secret_key":
+			send(0, domain.KeyPair{Public: "pub", Secret: "sec"})
+		default:
+			send(1, domain.ClientError{Code: domain.ClientErrorUnknownFunction, Message: "Unknown function"})
+		}
+	})
+	defer server.Close()
+
+	config := domain.NewDefaultConfig("", domain.GetLocalNetBaseUrls(), "")
+	live, err := remote.NewRemoteGateway(server.URL, config)
+	assert.Equal(t, nil, err)
+	dir, err := ioutil.TempDir("", "replay")
+	assert.Equal(t, nil, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixture.json")
+	recorder := NewRecorder(live, path)
+
+	sendMessage := func(gw domain.ClientGateway, message string) (*domain.ResultOfSendMessage, int, error) {
+		events := 0
+		result, err := processing.NewProcessing(config, gw).SendMessage(&domain.ParamsOfSendMessage{Message: message, SendEvents: true}, func(*domain.ProcessingEvent) {
+			events++
+		})
+		return result, events, err
+	}
+
+	_, _, err = sendMessage(recorder, "te6")
+	assert.Equal(t, nil, err)
+	_, err = recorder.GetResponse("client.unknown_function", nil)
+	assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+	ctx, cancel := context.WithCancel(context.Background())
+	responses, err := recorder.RequestContext(ctx, "net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "blocks"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(0), (<-responses).Code)
+	cancel()
+	for range responses {
+	}
+	assert.Equal(t, nil, recorder.Save())
+	recorder.Destroy()
+
+	fixture, err := LoadFixture(path)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(fixture.Calls))
+	assert.True(t, fixture.Calls[0].Finished)
+	assert.False(t, fixture.Calls[2].Finished)
+
+	t.Run("TestReplayStream", func(t *testing.T) {
+		gw, err := Load(path)
+		assert.Equal(t, nil, err)
+		defer gw.Destroy()
+
+		result, events, err := sendMessage(gw, "te6")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "b1", result.ShardBlockID)
+		assert.Equal(t, 1, events)
+
+		_, err = gw.GetResponse("client.unknown_function", nil)
+		assert.True(t, errors.Is(err, domain.ErrUnknownFunction))
+
+		_, _, err = sendMessage(gw, "te6")
+		assert.Contains(t, err.Error(), "no recorded call of processing.send_message")
+	})
+
+	t.Run("TestReplayOpenStream", func(t *testing.T) {
+		gw := NewReplayGateway(fixture)
+		ctx, cancel := context.WithCancel(context.Background())
+		responses, err := gw.RequestContext(ctx, "net.subscribe_collection", &domain.ParamsOfSubscribeCollection{Collection: "blocks"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uint32(0), (<-responses).Code)
+		cancel()
+		_, ok := <-responses
+		assert.False(t, ok)
+	})
+
+	t.Run("TestMatchers", func(t *testing.T) {
+		_, _, err := sendMessage(NewReplayGateway(fixture), "other")
+		assert.NotEqual(t, nil, err)
+		_, _, err = sendMessage(NewReplayGateway(fixture, WithMatcher(IgnoreFields("message"))), "other")
+		assert.Equal(t, nil, err)
+		_, _, err = sendMessage(NewReplayGateway(fixture, WithMatcher(MatchMethod)), "other")
+		assert.Equal(t, nil, err)
+	})
+	t.Run("TestRedaction", func(t *testing.T) {
+		live, err := remote.NewRemoteGateway(server.URL, config)
+		assert.Equal(t, nil, err)
+		defer live.Destroy()
+		path := filepath.Join(dir, "secrets.json")
+		recorder := NewRecorder(live, path)
+		params := &domain.ParamsOfNaclSignKeyPairFromSecret{Secret: "sec"}
+		_, err = recorder.GetResponse("crypto.nacl_sign_keypair_from_secret_key", params)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, nil, recorder.Save())
+		raw, err := ioutil.ReadFile(path)
+		assert.Equal(t, nil, err)
+		assert.NotContains(t, string(raw), `"sec"`)
+
+		gw, err := Load(path)
+		assert.Equal(t, nil, err)
+		data, err := gw.GetResponse("crypto.nacl_sign_keypair_from_secret_key", params)
+		assert.Equal(t, nil, err)
+		keys := &domain.KeyPair{}
+		assert.Equal(t, nil, json.Unmarshal(data, keys))
+		assert.Equal(t, "pub", keys.Public)
+		assert.Equal(t, domain.RedactedValue, keys.Secret)
+
+		recorder = NewRecorder(live, path, KeepSecrets())
+		_, err = recorder.GetResponse("crypto.nacl_sign_keypair_from_secret_key", params)
+		assert.Equal(t, nil, err)
+		assert.Contains(t, string(recorder.Fixture().Calls[0].Responses[0].Data), `"secret":"sec"`)
+	})
+}
@@ -0,0 +1,75 @@
+// Package apiref loads the Core Library API reference for the command line tools.
+package apiref
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/remote"
+)
+
+// Load reads the API reference from the api.json file at path (- for stdin)
+// or, if remoteURL is set, fetches it from a tonclient server.
+func Load(path, remoteURL string) (*domain.API, error) {
+	switch {
+	case remoteURL != "":
+		client, err := remote.NewRemoteGateway(remoteURL, domain.ClientConfig{})
+		if err != nil {
+			return nil, err
+		}
+		defer client.Destroy()
+		return Fetch(client)
+	case path == "-":
+		return Parse(os.Stdin)
+	case path != "":
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return Parse(file)
+	default:
+		return nil, errors.New("one of -api or -remote is required")
+	}
+}
+
+// Fetch returns the API reference of the library behind client.
+func Fetch(client domain.ClientGateway) (*domain.API, error) {
+	reference, err := client.GetAPIReference()
+	if err != nil {
+		return nil, err
+	}
+	if reference.API == nil {
+		return nil, errors.New("empty API reference")
+	}
+
+	return reference.API, nil
+}
+
+// Parse accepts both the bare API object and the ResultOfGetAPIReference wrapper.
+func Parse(r io.Reader) (*domain.API, error) {
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reference := &domain.ResultOfGetAPIReference{}
+	if err := json.Unmarshal(raw, reference); err != nil {
+		return nil, err
+	}
+	if reference.API != nil {
+		return reference.API, nil
+	}
+	api := &domain.API{}
+	if err := json.Unmarshal(raw, api); err != nil {
+		return nil, err
+	}
+	if len(api.Modules) == 0 {
+		return nil, errors.New("API reference has no modules")
+	}
+
+	return api, nil
+}
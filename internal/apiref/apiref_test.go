@@ -0,0 +1,39 @@
+package apiref
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/move-ton/ever-client-go/gateway/remote/remotetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	wrapped, err := Parse(strings.NewReader(`{"api":{"version":"1.40.0","modules":[{"name":"utils"}]}}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "utils", wrapped.Modules[0].Name)
+
+	bare, err := Parse(strings.NewReader(`{"version":"1.40.0","modules":[{"name":"net"}]}`))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "net", bare.Modules[0].Name)
+
+	_, err = Parse(strings.NewReader(`{}`))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLoadRemote(t *testing.T) {
+	server := remotetest.NewServer(func(ctx context.Context, method string, params json.RawMessage, send func(uint32, interface{})) {
+		if method == "client.get_api_reference" {
+			send(0, json.RawMessage(`{"api":{"version":"1.40.0","modules":[{"name":"client"}]}}`))
+			return
+		}
+		send(1, map[string]interface{}{"code": 22, "message": "unknown function " + method})
+	})
+	defer server.Close()
+
+	api, err := Load("", server.URL)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "client", api.Modules[0].Name)
+}
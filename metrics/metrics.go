@@ -0,0 +1,272 @@
+// Package metrics implements domain.Metrics in memory and exposes the measurements in the Prometheus text format:
+//
+//	registry := metrics.NewRegistry()
+//	gw, err := client.NewClientGateway(config, client.WithMetrics(registry))
+//	http.Handle("/metrics", registry)
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// DefaultBuckets - upper bounds in seconds of the latency histograms of NewRegistry.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type (
+	// Registry - in-memory domain.Metrics, safe for concurrent use. It serves the text exposition over HTTP.
+	Registry struct {
+		buckets []float64
+
+		mu               sync.Mutex
+		requests         map[string]uint64
+		errors           map[errorKey]uint64
+		latency          map[string]*histogram
+		inFlight         map[string]int64
+		subscriptions    map[string]int64
+		appRequests      map[string]*histogram
+		appRequestErrors map[string]uint64
+	}
+
+	errorKey struct {
+		method string
+		code   string
+	}
+
+	histogram struct {
+		counts []uint64
+		count  uint64
+		sum    float64
+	}
+)
+
+var _ domain.Metrics = (*Registry)(nil)
+
+// NewRegistry creates a registry with latency histograms of buckets, DefaultBuckets if none are given.
+func NewRegistry(buckets ...float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Registry{
+		buckets:          buckets,
+		requests:         make(map[string]uint64),
+		errors:           make(map[errorKey]uint64),
+		latency:          make(map[string]*histogram),
+		inFlight:         make(map[string]int64),
+		subscriptions:    make(map[string]int64),
+		appRequests:      make(map[string]*histogram),
+		appRequestErrors: make(map[string]uint64),
+	}
+}
+
+// RequestStarted counts the call and adds it to the in-flight gauge.
+func (r *Registry) RequestStarted(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[method]++
+	r.inFlight[method]++
+}
+
+// RequestFinished removes the call from the in-flight gauge, observes its latency and counts its error.
+func (r *Registry) RequestFinished(method string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight[method]--
+	r.observe(r.latency, method, duration)
+	if err != nil {
+		r.errors[errorKey{method: method, code: ErrorCode(err)}]++
+	}
+}
+
+// SubscriptionOpened adds a subscription to the active gauge.
+func (r *Registry) SubscriptionOpened(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[method]++
+}
+
+// SubscriptionClosed removes a subscription from the active gauge.
+func (r *Registry) SubscriptionClosed(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[method]--
+}
+
+// AppRequestHandled observes the handling time of the app request and counts its error.
+func (r *Registry) AppRequestHandled(method string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observe(r.appRequests, method, duration)
+	if err != nil {
+		r.appRequestErrors[method]++
+	}
+}
+
+func (r *Registry) observe(histograms map[string]*histogram, method string, duration time.Duration) {
+	h, ok := histograms[method]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		histograms[method] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range r.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ErrorCode - label of err: the code of a *domain.ClientError, "canceled", "deadline_exceeded" or "other".
+func ErrorCode(err error) string {
+	var clientErr *domain.ClientError
+	switch {
+	case errors.As(err, &clientErr):
+		return strconv.Itoa(clientErr.Code)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "other"
+	}
+}
+
+// ServeHTTP writes the text exposition, see WriteText.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = r.WriteText(w)
+}
+
+// WriteText writes the measurements in the Prometheus text format, version 0.0.4:
+//
+//	ever_client_requests_total{method="net.query_collection"} 12
+//	ever_client_request_errors_total{method="net.query_collection",code="603"} 1
+//	ever_client_request_duration_seconds_bucket{method="net.query_collection",le="0.5"} 11
+//	ever_client_requests_in_flight{method="net.query_collection"} 0
+//	ever_client_subscriptions_active{method="net.subscribe_collection"} 2
+//	ever_client_app_request_duration_seconds_count{method="crypto.register_signing_box"} 4
+//	ever_client_app_request_errors_total{method="crypto.register_signing_box"} 0
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := bufio.NewWriter(w)
+
+	header(b, "ever_client_requests_total", "counter", "Calls of SDK functions.")
+	for _, method := range sortedKeys(r.requests) {
+		sample(b, "ever_client_requests_total", labels("method", method), float64(r.requests[method]))
+	}
+
+	header(b, "ever_client_request_errors_total", "counter", "Failed calls of SDK functions by error code.")
+	errorKeys := make([]errorKey, 0, len(r.errors))
+	for key := range r.errors {
+		errorKeys = append(errorKeys, key)
+	}
+	sort.Slice(errorKeys, func(i, j int) bool {
+		if errorKeys[i].method != errorKeys[j].method {
+			return errorKeys[i].method < errorKeys[j].method
+		}
+		return errorKeys[i].code < errorKeys[j].code
+	})
+	for _, key := range errorKeys {
+		sample(b, "ever_client_request_errors_total", labels("method", key.method, "code", key.code), float64(r.errors[key]))
+	}
+
+	r.writeHistograms(b, "ever_client_request_duration_seconds", "Time from the call of an SDK function to its result.", r.latency)
+
+	header(b, "ever_client_requests_in_flight", "gauge", "Calls of SDK functions waiting for their result.")
+	for _, method := range sortedKeys(r.inFlight) {
+		sample(b, "ever_client_requests_in_flight", labels("method", method), float64(r.inFlight[method]))
+	}
+
+	header(b, "ever_client_subscriptions_active", "gauge", "Open subscriptions.")
+	for _, method := range sortedKeys(r.subscriptions) {
+		sample(b, "ever_client_subscriptions_active", labels("method", method), float64(r.subscriptions[method]))
+	}
+
+	r.writeHistograms(b, "ever_client_app_request_duration_seconds", "Time app objects take to resolve app requests.", r.appRequests)
+
+	header(b, "ever_client_app_request_errors_total", "counter", "App requests resolved with an error.")
+	for _, method := range sortedKeys(r.appRequestErrors) {
+		sample(b, "ever_client_app_request_errors_total", labels("method", method), float64(r.appRequestErrors[method]))
+	}
+
+	return b.Flush()
+}
+
+func (r *Registry) writeHistograms(b *bufio.Writer, name, help string, histograms map[string]*histogram) {
+	header(b, name, "histogram", help)
+	for _, method := range sortedKeys(histograms) {
+		h := histograms[method]
+		for i, bound := range r.buckets {
+			sample(b, name+"_bucket", labels("method", method, "le", formatFloat(bound)), float64(h.counts[i]))
+		}
+		sample(b, name+"_bucket", labels("method", method, "le", "+Inf"), float64(h.count))
+		sample(b, name+"_sum", labels("method", method), h.sum)
+		sample(b, name+"_count", labels("method", method), float64(h.count))
+	}
+}
+
+func header(b *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sample(b *bufio.Writer, name, labels string, value float64) {
+	fmt.Fprintf(b, "%s{%s} %s\n", name, labels, formatFloat(value))
+}
+
+// labels formats the name/value pairs of keyValues, escaping the values.
+func labels(keyValues ...string) string {
+	pairs := make([]string, 0, len(keyValues)/2)
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(keyValues[i+1])
+		pairs = append(pairs, keyValues[i]+`="`+value+`"`)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch values := m.(type) {
+	case map[string]uint64:
+		for key := range values {
+			keys = append(keys, key)
+		}
+	case map[string]int64:
+		for key := range values {
+			keys = append(keys, key)
+		}
+	case map[string]*histogram:
+		for key := range values {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
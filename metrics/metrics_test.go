@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("TestWriteText", func(t *testing.T) {
+		registry := NewRegistry(0.1, 1)
+		registry.RequestStarted("net.query_collection")
+		registry.RequestFinished("net.query_collection", 50*time.Millisecond, nil)
+		registry.RequestStarted("net.query_collection")
+		registry.RequestFinished("net.query_collection", 2*time.Second, &domain.ClientError{Code: 603})
+		registry.RequestStarted("processing.process_message")
+		registry.SubscriptionOpened("net.subscribe_collection")
+		registry.AppRequestHandled("crypto.register_signing_box", 10*time.Millisecond, errors.New("no key"))
+
+		var buf bytes.Buffer
+		assert.Equal(t, nil, registry.WriteText(&buf))
+		text := buf.String()
+		assert.Contains(t, text, "# TYPE ever_client_requests_total counter\n")
+		assert.Contains(t, text, `ever_client_requests_total{method="net.query_collection"} 2`+"\n")
+		assert.Contains(t, text, `ever_client_request_errors_total{method="net.query_collection",code="603"} 1`+"\n")
+		assert.Contains(t, text, `ever_client_request_duration_seconds_bucket{method="net.query_collection",le="0.1"} 1`+"\n")
+		assert.Contains(t, text, `ever_client_request_duration_seconds_bucket{method="net.query_collection",le="+Inf"} 2`+"\n")
+		assert.Contains(t, text, `ever_client_request_duration_seconds_sum{method="net.query_collection"} 2.05`+"\n")
+		assert.Contains(t, text, `ever_client_requests_in_flight{method="processing.process_message"} 1`+"\n")
+		assert.Contains(t, text, `ever_client_subscriptions_active{method="net.subscribe_collection"} 1`+"\n")
+		assert.Contains(t, text, `ever_client_app_request_duration_seconds_count{method="crypto.register_signing_box"} 1`+"\n")
+		assert.Contains(t, text, `ever_client_app_request_errors_total{method="crypto.register_signing_box"} 1`+"\n")
+	})
+
+	t.Run("TestServeHTTP", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.RequestStarted("client.version")
+		recorder := httptest.NewRecorder()
+		registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Contains(t, recorder.Body.String(), `ever_client_requests_total{method="client.version"} 1`)
+	})
+
+	t.Run("TestErrorCode", func(t *testing.T) {
+		assert.Equal(t, "603", ErrorCode(&domain.ClientError{Code: 603}))
+		assert.Equal(t, "canceled", ErrorCode(context.Canceled))
+		assert.Equal(t, "deadline_exceeded", ErrorCode(context.DeadlineExceeded))
+		assert.Equal(t, "other", ErrorCode(errors.New("boom")))
+	})
+}
@@ -1,6 +1,7 @@
 package abi
 
 import (
+	"context"
 	"github.com/move-ton/ever-client-go/domain"
 )
 
@@ -22,68 +23,113 @@ func NewAbi(
 
 // EncodeMessageBody - Encode message body according to ABI function call.
 func (a *abi) EncodeMessageBody(pOEMB *domain.ParamsOfEncodeMessageBody) (*domain.ResultOfEncodeMessageBody, error) {
+	return a.EncodeMessageBodyCtx(context.Background(), pOEMB)
+}
+
+// EncodeMessageBodyCtx - EncodeMessageBody bounded by ctx.
+func (a *abi) EncodeMessageBodyCtx(ctx context.Context, pOEMB *domain.ParamsOfEncodeMessageBody) (*domain.ResultOfEncodeMessageBody, error) {
 	result := new(domain.ResultOfEncodeMessageBody)
-	err := a.client.GetResult("abi.encode_message_body", pOEMB, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_message_body", pOEMB, result)
 	return result, err
 }
 
 // AttachSignatureToMessageBody - method attach_signature_to_message_body
 func (a *abi) AttachSignatureToMessageBody(pOASTMB *domain.ParamsOfAttachSignatureToMessageBody) (*domain.ResultOfAttachSignatureToMessageBody, error) {
+	return a.AttachSignatureToMessageBodyCtx(context.Background(), pOASTMB)
+}
+
+// AttachSignatureToMessageBodyCtx - AttachSignatureToMessageBody bounded by ctx.
+func (a *abi) AttachSignatureToMessageBodyCtx(ctx context.Context, pOASTMB *domain.ParamsOfAttachSignatureToMessageBody) (*domain.ResultOfAttachSignatureToMessageBody, error) {
 	result := new(domain.ResultOfAttachSignatureToMessageBody)
-	err := a.client.GetResult("abi.attach_signature_to_message_body", pOASTMB, result)
+	err := a.client.GetResultContext(ctx, "abi.attach_signature_to_message_body", pOASTMB, result)
 	return result, err
 }
 
 // EncodeMessage - Encodes an ABI-compatible message.
 // Allows to encode deploy and function call messages, both signed and unsigned.
 func (a *abi) EncodeMessage(pOEM *domain.ParamsOfEncodeMessage) (*domain.ResultOfEncodeMessage, error) {
+	return a.EncodeMessageCtx(context.Background(), pOEM)
+}
+
+// EncodeMessageCtx - EncodeMessage bounded by ctx.
+func (a *abi) EncodeMessageCtx(ctx context.Context, pOEM *domain.ParamsOfEncodeMessage) (*domain.ResultOfEncodeMessage, error) {
 	result := new(domain.ResultOfEncodeMessage)
-	err := a.client.GetResult("abi.encode_message", pOEM, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_message", pOEM, result)
 	return result, err
 }
 
 // EncodeInternalMessage - Encodes an internal ABI-compatible message
 // Allows to encode deploy and function call messages.
 func (a *abi) EncodeInternalMessage(pOEIM *domain.ParamsOfEncodeInternalMessage) (*domain.ResultOfEncodeInternalMessage, error) {
+	return a.EncodeInternalMessageCtx(context.Background(), pOEIM)
+}
+
+// EncodeInternalMessageCtx - EncodeInternalMessage bounded by ctx.
+func (a *abi) EncodeInternalMessageCtx(ctx context.Context, pOEIM *domain.ParamsOfEncodeInternalMessage) (*domain.ResultOfEncodeInternalMessage, error) {
 	result := new(domain.ResultOfEncodeInternalMessage)
-	err := a.client.GetResult("abi.encode_internal_message", pOEIM, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_internal_message", pOEIM, result)
 	return result, err
 }
 
 // AttachSignature - сombines hex-encoded signature with base64-encoded unsigned_message.
 // Returns signed message encoded in base64.
 func (a *abi) AttachSignature(pOAS *domain.ParamsOfAttachSignature) (*domain.ResultOfAttachSignature, error) {
+	return a.AttachSignatureCtx(context.Background(), pOAS)
+}
+
+// AttachSignatureCtx - AttachSignature bounded by ctx.
+func (a *abi) AttachSignatureCtx(ctx context.Context, pOAS *domain.ParamsOfAttachSignature) (*domain.ResultOfAttachSignature, error) {
 	result := new(domain.ResultOfAttachSignature)
-	err := a.client.GetResult("abi.attach_signature", pOAS, result)
+	err := a.client.GetResultContext(ctx, "abi.attach_signature", pOAS, result)
 	return result, err
 }
 
 // DecodeMessage Decodes message body using provided message BOC and ABI.
 func (a *abi) DecodeMessage(pODM *domain.ParamsOfDecodeMessage) (*domain.DecodedMessageBody, error) {
+	return a.DecodeMessageCtx(context.Background(), pODM)
+}
+
+// DecodeMessageCtx - DecodeMessage bounded by ctx.
+func (a *abi) DecodeMessageCtx(ctx context.Context, pODM *domain.ParamsOfDecodeMessage) (*domain.DecodedMessageBody, error) {
 	result := new(domain.DecodedMessageBody)
-	err := a.client.GetResult("abi.decode_message", pODM, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_message", pODM, result)
 	return result, err
 }
 
 // DecodeMessageBody Decodes message body using provided body BOC and ABI.
 func (a *abi) DecodeMessageBody(pODMB *domain.ParamsOfDecodeMessageBody) (*domain.DecodedMessageBody, error) {
+	return a.DecodeMessageBodyCtx(context.Background(), pODMB)
+}
+
+// DecodeMessageBodyCtx - DecodeMessageBody bounded by ctx.
+func (a *abi) DecodeMessageBodyCtx(ctx context.Context, pODMB *domain.ParamsOfDecodeMessageBody) (*domain.DecodedMessageBody, error) {
 	result := new(domain.DecodedMessageBody)
-	err := a.client.GetResult("abi.decode_message_body", pODMB, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_message_body", pODMB, result)
 	return result, err
 }
 
 // EncodeAccount Creates account state BOC.
 func (a *abi) EncodeAccount(pOEA *domain.ParamsOfEncodeAccount) (*domain.ResultOfEncodeAccount, error) {
+	return a.EncodeAccountCtx(context.Background(), pOEA)
+}
+
+// EncodeAccountCtx - EncodeAccount bounded by ctx.
+func (a *abi) EncodeAccountCtx(ctx context.Context, pOEA *domain.ParamsOfEncodeAccount) (*domain.ResultOfEncodeAccount, error) {
 	result := new(domain.ResultOfEncodeAccount)
-	err := a.client.GetResult("abi.encode_account", pOEA, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_account", pOEA, result)
 	return result, err
 }
 
 // DecodeAccountData - Decodes account data using provided data BOC and ABI.
 // Note: this feature requires ABI 2.1 or higher.
 func (a *abi) DecodeAccountData(pODAD *domain.ParamsOfDecodeAccountData) (*domain.ResultOfDecodeData, error) {
+	return a.DecodeAccountDataCtx(context.Background(), pODAD)
+}
+
+// DecodeAccountDataCtx - DecodeAccountData bounded by ctx.
+func (a *abi) DecodeAccountDataCtx(ctx context.Context, pODAD *domain.ParamsOfDecodeAccountData) (*domain.ResultOfDecodeData, error) {
 	result := new(domain.ResultOfDecodeData)
-	err := a.client.GetResult("abi.decode_account_data", pODAD, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_account_data", pODAD, result)
 	return result, err
 }
 
@@ -91,8 +137,13 @@ func (a *abi) DecodeAccountData(pODAD *domain.ParamsOfDecodeAccountData) (*domai
 // This operation is applicable only for initial account data (before deploy). If the contract is already deployed, its data doesn't contain
 // this data section any more.
 func (a *abi) UpdateInitialData(pOUID *domain.ParamsOfUpdateInitialData) (*domain.ResultOfUpdateInitialData, error) {
+	return a.UpdateInitialDataCtx(context.Background(), pOUID)
+}
+
+// UpdateInitialDataCtx - UpdateInitialData bounded by ctx.
+func (a *abi) UpdateInitialDataCtx(ctx context.Context, pOUID *domain.ParamsOfUpdateInitialData) (*domain.ResultOfUpdateInitialData, error) {
 	result := new(domain.ResultOfUpdateInitialData)
-	err := a.client.GetResult("abi.update_initial_data", pOUID, result)
+	err := a.client.GetResultContext(ctx, "abi.update_initial_data", pOUID, result)
 	return result, err
 }
 
@@ -100,8 +151,13 @@ func (a *abi) UpdateInitialData(pOUID *domain.ParamsOfUpdateInitialData) (*domai
 // a data BOC that can be passed to encode_tvc function afterwards.
 // This function is analogue of tvm.buildDataInit function in Solidity
 func (a *abi) EncodeInitialData(pOEID *domain.ParamsOfEncodeInitialData) (*domain.ResultOfEncodeInitialData, error) {
+	return a.EncodeInitialDataCtx(context.Background(), pOEID)
+}
+
+// EncodeInitialDataCtx - EncodeInitialData bounded by ctx.
+func (a *abi) EncodeInitialDataCtx(ctx context.Context, pOEID *domain.ParamsOfEncodeInitialData) (*domain.ResultOfEncodeInitialData, error) {
 	result := new(domain.ResultOfEncodeInitialData)
-	err := a.client.GetResult("abi.encode_initial_data", pOEID, result)
+	err := a.client.GetResultContext(ctx, "abi.encode_initial_data", pOEID, result)
 	return result, err
 }
 
@@ -109,8 +165,13 @@ func (a *abi) EncodeInitialData(pOEID *domain.ParamsOfEncodeInitialData) (*domai
 // This operation is applicable only for initial account data (before deploy). If the contract is already deployed, its data doesn't
 // contain this data section any more.
 func (a *abi) DecodeInitialData(pODID *domain.ParamsOfDecodeInitialData) (*domain.ResultOfDecodeInitialData, error) {
+	return a.DecodeInitialDataCtx(context.Background(), pODID)
+}
+
+// DecodeInitialDataCtx - DecodeInitialData bounded by ctx.
+func (a *abi) DecodeInitialDataCtx(ctx context.Context, pODID *domain.ParamsOfDecodeInitialData) (*domain.ResultOfDecodeInitialData, error) {
 	result := new(domain.ResultOfDecodeInitialData)
-	err := a.client.GetResult("abi.decode_initial_data", pODID, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_initial_data", pODID, result)
 	return result, err
 }
 
@@ -128,28 +189,48 @@ func (a *abi) DecodeInitialData(pODID *domain.ParamsOfDecodeInitialData) (*domai
 // fields up to fork condition, check the parsed data manually, expand the parsing schema and then decode the whole BOC
 // with the full schema.
 func (a *abi) DecodeBoc(boc *domain.ParamsOfDecodeBoc) (*domain.ResultOfDecodeBoc, error) {
+	return a.DecodeBocCtx(context.Background(), boc)
+}
+
+// DecodeBocCtx - DecodeBoc bounded by ctx.
+func (a *abi) DecodeBocCtx(ctx context.Context, boc *domain.ParamsOfDecodeBoc) (*domain.ResultOfDecodeBoc, error) {
 	result := new(domain.ResultOfDecodeBoc)
-	err := a.client.GetResult("abi.decode_boc", boc, result)
+	err := a.client.GetResultContext(ctx, "abi.decode_boc", boc, result)
 	return result, err
 }
 
 // EncodeBoc - Encodes given parameters in JSON into a BOC using param types from ABI.
 func (a *abi) EncodeBoc(boc *domain.ParamsOfAbiEncodeBoc) (*domain.ResultOfAbiEncodeBoc, error) {
+	return a.EncodeBocCtx(context.Background(), boc)
+}
+
+// EncodeBocCtx - EncodeBoc bounded by ctx.
+func (a *abi) EncodeBocCtx(ctx context.Context, boc *domain.ParamsOfAbiEncodeBoc) (*domain.ResultOfAbiEncodeBoc, error) {
 	result := new(domain.ResultOfAbiEncodeBoc)
-	err := a.client.GetResult("encode_bocs", boc, result)
+	err := a.client.GetResultContext(ctx, "encode_bocs", boc, result)
 	return result, err
 }
 
 // CalcFunctionID - Calculates contract function ID by contract ABI
 func (a *abi) CalcFunctionID(functionID *domain.ParamsOfCalcFunctionId) (*domain.ResultOfCalcFunctionId, error) {
+	return a.CalcFunctionIDCtx(context.Background(), functionID)
+}
+
+// CalcFunctionIDCtx - CalcFunctionID bounded by ctx.
+func (a *abi) CalcFunctionIDCtx(ctx context.Context, functionID *domain.ParamsOfCalcFunctionId) (*domain.ResultOfCalcFunctionId, error) {
 	result := new(domain.ResultOfCalcFunctionId)
-	err := a.client.GetResult("calc_function_id", functionID, result)
+	err := a.client.GetResultContext(ctx, "calc_function_id", functionID, result)
 	return result, err
 }
 
 // GetSignatureData -Extracts signature from message body and calculates hash to verify the signature
 func (a *abi) GetSignatureData(data *domain.ParamsOfGetSignatureData) (*domain.ResultOfGetSignatureData, error) {
+	return a.GetSignatureDataCtx(context.Background(), data)
+}
+
+// GetSignatureDataCtx - GetSignatureData bounded by ctx.
+func (a *abi) GetSignatureDataCtx(ctx context.Context, data *domain.ParamsOfGetSignatureData) (*domain.ResultOfGetSignatureData, error) {
 	result := new(domain.ResultOfGetSignatureData)
-	err := a.client.GetResult("get_signature_data", data, result)
+	err := a.client.GetResultContext(ctx, "get_signature_data", data, result)
 	return result, err
 }
@@ -18,7 +18,8 @@ import (
 
 func TestAbi(t *testing.T) {
 
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	configConn, err := domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientConn, err := client.NewClientGateway(configConn)
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
@@ -1,6 +1,10 @@
 package boc
 
-import "github.com/move-ton/ever-client-go/domain"
+import (
+	"context"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
 
 type boc struct {
 	config domain.ClientConfig
@@ -20,78 +24,133 @@ func NewBoc(
 
 // ParseMessage - Parses message boc into a JSON.
 func (b *boc) ParseMessage(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseMessageCtx(context.Background(), pOP)
+}
+
+// ParseMessageCtx - ParseMessage bounded by ctx.
+func (b *boc) ParseMessageCtx(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_message", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_message", pOP, result)
 	return result, err
 }
 
 // ParseTransaction - Parses transaction boc into a JSON.
 func (b *boc) ParseTransaction(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseTransactionCtx(context.Background(), pOP)
+}
+
+// ParseTransactionCtx - ParseTransaction bounded by ctx.
+func (b *boc) ParseTransactionCtx(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_transaction", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_transaction", pOP, result)
 	return result, err
 }
 
 // ParseAccount - Parses account boc into a JSON.
 func (b *boc) ParseAccount(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseAccountCtx(context.Background(), pOP)
+}
+
+// ParseAccountCtx - ParseAccount bounded by ctx.
+func (b *boc) ParseAccountCtx(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_account", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_account", pOP, result)
 	return result, err
 }
 
 // ParseBlock - Parses block boc into a JSON.
 func (b *boc) ParseBlock(pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
+	return b.ParseBlockCtx(context.Background(), pOP)
+}
+
+// ParseBlockCtx - ParseBlock bounded by ctx.
+func (b *boc) ParseBlockCtx(ctx context.Context, pOP *domain.ParamsOfParse) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_block", pOP, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_block", pOP, result)
 	return result, err
 }
 
 // ParseShardstate - Parses shardstate boc into a JSON.
 func (b *boc) ParseShardstate(pOPS *domain.ParamsOfParseShardstate) (*domain.ResultOfParse, error) {
+	return b.ParseShardstateCtx(context.Background(), pOPS)
+}
+
+// ParseShardstateCtx - ParseShardstate bounded by ctx.
+func (b *boc) ParseShardstateCtx(ctx context.Context, pOPS *domain.ParamsOfParseShardstate) (*domain.ResultOfParse, error) {
 	result := new(domain.ResultOfParse)
-	err := b.client.GetResult("boc.parse_shardstate", pOPS, result)
+	err := b.client.GetResultContext(ctx, "boc.parse_shardstate", pOPS, result)
 	return result, err
 }
 
 // GetBlockhainConfig - Extract blockchain configuration from key block and also from zerostate.
 func (b *boc) GetBlockhainConfig(pOGBC *domain.ParamsOfGetBlockchainConfig) (*domain.ResultOfGetBlockchainConfig, error) {
+	return b.GetBlockhainConfigCtx(context.Background(), pOGBC)
+}
+
+// GetBlockhainConfigCtx - GetBlockhainConfig bounded by ctx.
+func (b *boc) GetBlockhainConfigCtx(ctx context.Context, pOGBC *domain.ParamsOfGetBlockchainConfig) (*domain.ResultOfGetBlockchainConfig, error) {
 	result := new(domain.ResultOfGetBlockchainConfig)
-	err := b.client.GetResult("boc.get_blockchain_config", pOGBC, result)
+	err := b.client.GetResultContext(ctx, "boc.get_blockchain_config", pOGBC, result)
 	return result, err
 }
 
 // GetBocHash - Calculates BOC root hash.
 func (b *boc) GetBocHash(pOGBH *domain.ParamsOfGetBocHash) (*domain.ResultOfGetBocHash, error) {
+	return b.GetBocHashCtx(context.Background(), pOGBH)
+}
+
+// GetBocHashCtx - GetBocHash bounded by ctx.
+func (b *boc) GetBocHashCtx(ctx context.Context, pOGBH *domain.ParamsOfGetBocHash) (*domain.ResultOfGetBocHash, error) {
 	result := new(domain.ResultOfGetBocHash)
-	err := b.client.GetResult("boc.get_boc_hash", pOGBH, result)
+	err := b.client.GetResultContext(ctx, "boc.get_boc_hash", pOGBH, result)
 	return result, err
 }
 
 // GetBocDepth - Calculates BOC depth.
 func (b *boc) GetBocDepth(pOGBD *domain.ParamsOfGetBocDepth) (*domain.ResultOfGetBocDepth, error) {
+	return b.GetBocDepthCtx(context.Background(), pOGBD)
+}
+
+// GetBocDepthCtx - GetBocDepth bounded by ctx.
+func (b *boc) GetBocDepthCtx(ctx context.Context, pOGBD *domain.ParamsOfGetBocDepth) (*domain.ResultOfGetBocDepth, error) {
 	result := new(domain.ResultOfGetBocDepth)
-	err := b.client.GetResult("boc.get_boc_depth", pOGBD, result)
+	err := b.client.GetResultContext(ctx, "boc.get_boc_depth", pOGBD, result)
 	return result, err
 }
 
 // GetCodeFromTvc - Extracts code from TVC contract image.
 func (b *boc) GetCodeFromTvc(pOGCFT *domain.ParamsOfGetCodeFromTvc) (*domain.ResultOfGetCodeFromTvc, error) {
+	return b.GetCodeFromTvcCtx(context.Background(), pOGCFT)
+}
+
+// GetCodeFromTvcCtx - GetCodeFromTvc bounded by ctx.
+func (b *boc) GetCodeFromTvcCtx(ctx context.Context, pOGCFT *domain.ParamsOfGetCodeFromTvc) (*domain.ResultOfGetCodeFromTvc, error) {
 	result := new(domain.ResultOfGetCodeFromTvc)
-	err := b.client.GetResult("boc.get_code_from_tvc", pOGCFT, result)
+	err := b.client.GetResultContext(ctx, "boc.get_code_from_tvc", pOGCFT, result)
 	return result, err
 }
 
 // CacheGet - Get BOC from cache.
 func (b *boc) CacheGet(pOBCG *domain.ParamsOfBocCacheGet) (*domain.ResultOfBocCacheGet, error) {
+	return b.CacheGetCtx(context.Background(), pOBCG)
+}
+
+// CacheGetCtx - CacheGet bounded by ctx.
+func (b *boc) CacheGetCtx(ctx context.Context, pOBCG *domain.ParamsOfBocCacheGet) (*domain.ResultOfBocCacheGet, error) {
 	result := new(domain.ResultOfBocCacheGet)
-	err := b.client.GetResult("boc.cache_get", pOBCG, result)
+	err := b.client.GetResultContext(ctx, "boc.cache_get", pOBCG, result)
 	return result, err
 }
 
 // CacheSet - Save BOC into cache or increase pin counter for existing pinned BOC.
 func (b *boc) CacheSet(pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCacheSet, error) {
+	return b.CacheSetCtx(context.Background(), pOBCS)
+}
+
+// CacheSetCtx - CacheSet bounded by ctx.
+func (b *boc) CacheSetCtx(ctx context.Context, pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCacheSet, error) {
 	result := new(domain.ResultOfBocCacheSet)
-	err := b.client.GetResult("boc.cache_set", pOBCS, result)
+	err := b.client.GetResultContext(ctx, "boc.cache_set", pOBCS, result)
 	return result, err
 }
 
@@ -100,57 +159,97 @@ func (b *boc) CacheSet(pOBCS *domain.ParamsOfBocCacheSet) (*domain.ResultOfBocCa
 // the `cache_set`. BOCs which have only 1 pin and its reference counter
 // become 0 will be removed from cache
 func (b *boc) CacheUnpin(pOBCU *domain.ParamsOfBocCacheUnpin) error {
-	_, err := b.client.GetResponse("boc.cache_unpin", pOBCU)
+	return b.CacheUnpinCtx(context.Background(), pOBCU)
+}
+
+// CacheUnpinCtx - CacheUnpin bounded by ctx.
+func (b *boc) CacheUnpinCtx(ctx context.Context, pOBCU *domain.ParamsOfBocCacheUnpin) error {
+	_, err := b.client.GetResponseContext(ctx, "boc.cache_unpin", pOBCU)
 	return err
 }
 
 // EncodeBoc - Encodes BOC from builder operations.
 func (b *boc) EncodeBoc(pOEB *domain.ParamsOfEncodeBoc) (*domain.ResultOfEncodeBoc, error) {
+	return b.EncodeBocCtx(context.Background(), pOEB)
+}
+
+// EncodeBocCtx - EncodeBoc bounded by ctx.
+func (b *boc) EncodeBocCtx(ctx context.Context, pOEB *domain.ParamsOfEncodeBoc) (*domain.ResultOfEncodeBoc, error) {
 	result := new(domain.ResultOfEncodeBoc)
-	err := b.client.GetResult("boc.encode_boc", pOEB, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_boc", pOEB, result)
 	return result, err
 }
 
 // GetCodeSalt - Returns the contract code's salt if it is present.
 func (b *boc) GetCodeSalt(pOGCS *domain.ParamsOfGetCodeSalt) (*domain.ResultOfGetCodeSalt, error) {
+	return b.GetCodeSaltCtx(context.Background(), pOGCS)
+}
+
+// GetCodeSaltCtx - GetCodeSalt bounded by ctx.
+func (b *boc) GetCodeSaltCtx(ctx context.Context, pOGCS *domain.ParamsOfGetCodeSalt) (*domain.ResultOfGetCodeSalt, error) {
 	result := new(domain.ResultOfGetCodeSalt)
-	err := b.client.GetResult("boc.get_code_salt", pOGCS, result)
+	err := b.client.GetResultContext(ctx, "boc.get_code_salt", pOGCS, result)
 	return result, err
 }
 
 // SetCodeSalt - Sets new salt to contract code.
 // Returns the new contract code with salt.
 func (b *boc) SetCodeSalt(pOSCS *domain.ParamsOfSetCodeSalt) (*domain.ResultOfSetCodeSalt, error) {
+	return b.SetCodeSaltCtx(context.Background(), pOSCS)
+}
+
+// SetCodeSaltCtx - SetCodeSalt bounded by ctx.
+func (b *boc) SetCodeSaltCtx(ctx context.Context, pOSCS *domain.ParamsOfSetCodeSalt) (*domain.ResultOfSetCodeSalt, error) {
 	result := new(domain.ResultOfSetCodeSalt)
-	err := b.client.GetResult("boc.set_code_salt", pOSCS, result)
+	err := b.client.GetResultContext(ctx, "boc.set_code_salt", pOSCS, result)
 	return result, err
 }
 
 // DecodeTvc - Decodes tvc into code, data, libraries and special options.
 func (b *boc) DecodeTvc(pODT *domain.ParamsOfDecodeTvc) (*domain.ResultOfDecodeTvc, error) {
+	return b.DecodeTvcCtx(context.Background(), pODT)
+}
+
+// DecodeTvcCtx - DecodeTvc bounded by ctx.
+func (b *boc) DecodeTvcCtx(ctx context.Context, pODT *domain.ParamsOfDecodeTvc) (*domain.ResultOfDecodeTvc, error) {
 	result := new(domain.ResultOfDecodeTvc)
-	err := b.client.GetResult("boc.decode_tvc", pODT, result)
+	err := b.client.GetResultContext(ctx, "boc.decode_tvc", pODT, result)
 	return result, err
 }
 
 // EncodeTvc - Encodes tvc from code, data, libraries ans special options (see input params).
 func (b *boc) EncodeTvc(pOET *domain.ParamsOfEncodeTvc) (*domain.ResultOfEncodeTvc, error) {
+	return b.EncodeTvcCtx(context.Background(), pOET)
+}
+
+// EncodeTvcCtx - EncodeTvc bounded by ctx.
+func (b *boc) EncodeTvcCtx(ctx context.Context, pOET *domain.ParamsOfEncodeTvc) (*domain.ResultOfEncodeTvc, error) {
 	result := new(domain.ResultOfEncodeTvc)
-	err := b.client.GetResult("boc.encode_tvc", pOET, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_tvc", pOET, result)
 	return result, err
 }
 
 // EncodeExternalInMessage - Encodes a message.
 // Allows to encode any external inbound message.
 func (b *boc) EncodeExternalInMessage(pOEEIM *domain.ParamsOfEncodeExternalInMessage) (*domain.ResultOfEncodeExternalInMessage, error) {
+	return b.EncodeExternalInMessageCtx(context.Background(), pOEEIM)
+}
+
+// EncodeExternalInMessageCtx - EncodeExternalInMessage bounded by ctx.
+func (b *boc) EncodeExternalInMessageCtx(ctx context.Context, pOEEIM *domain.ParamsOfEncodeExternalInMessage) (*domain.ResultOfEncodeExternalInMessage, error) {
 	result := new(domain.ResultOfEncodeExternalInMessage)
-	err := b.client.GetResult("boc.encode_external_in_message", pOEEIM, result)
+	err := b.client.GetResultContext(ctx, "boc.encode_external_in_message", pOEEIM, result)
 	return result, err
 }
 
 // GetCompilerVersion - Returns the compiler version used to compile the code.
 func (b *boc) GetCompilerVersion(pOGCV *domain.ParamsOfGetCompilerVersion) (*domain.ResultOfGetCompilerVersion, error) {
+	return b.GetCompilerVersionCtx(context.Background(), pOGCV)
+}
+
+// GetCompilerVersionCtx - GetCompilerVersion bounded by ctx.
+func (b *boc) GetCompilerVersionCtx(ctx context.Context, pOGCV *domain.ParamsOfGetCompilerVersion) (*domain.ResultOfGetCompilerVersion, error) {
 	result := new(domain.ResultOfGetCompilerVersion)
-	err := b.client.GetResult("boc.get_compiler_version", pOGCV, result)
+	err := b.client.GetResultContext(ctx, "boc.get_compiler_version", pOGCV, result)
 	return result, err
 }
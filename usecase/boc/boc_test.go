@@ -9,13 +9,21 @@ import (
 
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/replay"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestBoc(t *testing.T) {
 
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
-	clientConn, err := client.NewClientGateway(configConn)
+	// The suite replays testdata/boc.json; set replay.RecordEnv to record it again from the library.
+	// The devnet config is needed only then.
+	configConn, configErr := domain.Networks.Config("devnet")
+	clientConn, err := replay.Open("testdata/boc.json", func() (domain.ClientGateway, error) {
+		if configErr != nil {
+			return nil, configErr
+		}
+		return client.NewClientGateway(configConn)
+	})
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
 
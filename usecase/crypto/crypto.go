@@ -1,8 +1,8 @@
 package crypto
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/move-ton/ever-client-go/domain"
 )
@@ -23,239 +23,404 @@ func NewCrypto(config domain.ClientConfig, client domain.ClientGateway) domain.C
 // Factorize - Performs prime factorization – decomposition of a composite number into a product
 // of smaller prime integers (factors).
 func (c *crypto) Factorize(poF *domain.ParamsOfFactorize) (*domain.ResultOfFactorize, error) {
+	return c.FactorizeCtx(context.Background(), poF)
+}
+
+// FactorizeCtx - Factorize bounded by ctx.
+func (c *crypto) FactorizeCtx(ctx context.Context, poF *domain.ParamsOfFactorize) (*domain.ResultOfFactorize, error) {
 	result := new(domain.ResultOfFactorize)
-	err := c.client.GetResult("crypto.factorize", poF, result)
+	err := c.client.GetResultContext(ctx, "crypto.factorize", poF, result)
 	return result, err
 }
 
 // ModularPower - Performs modular exponentiation for big integers (base^exponent mod modulus).
 func (c *crypto) ModularPower(pOMP *domain.ParamsOfModularPower) (*domain.ResultOfModularPower, error) {
+	return c.ModularPowerCtx(context.Background(), pOMP)
+}
+
+// ModularPowerCtx - ModularPower bounded by ctx.
+func (c *crypto) ModularPowerCtx(ctx context.Context, pOMP *domain.ParamsOfModularPower) (*domain.ResultOfModularPower, error) {
 	result := new(domain.ResultOfModularPower)
-	err := c.client.GetResult("crypto.modular_power", pOMP, result)
+	err := c.client.GetResultContext(ctx, "crypto.modular_power", pOMP, result)
 	return result, err
 }
 
 // TonCrc16 - Calculates CRC16 using TON algorithm.
 func (c *crypto) TonCrc16(pOTC *domain.ParamsOfTonCrc16) (*domain.ResultOfTonCrc16, error) {
+	return c.TonCrc16Ctx(context.Background(), pOTC)
+}
+
+// TonCrc16Ctx - TonCrc16 bounded by ctx.
+func (c *crypto) TonCrc16Ctx(ctx context.Context, pOTC *domain.ParamsOfTonCrc16) (*domain.ResultOfTonCrc16, error) {
 	result := new(domain.ResultOfTonCrc16)
-	err := c.client.GetResult("crypto.ton_crc16", pOTC, result)
+	err := c.client.GetResultContext(ctx, "crypto.ton_crc16", pOTC, result)
 	return result, err
 }
 
 // GenerateRandomBytes - Generates random byte array of the specified length and returns it in base64 format.
 func (c *crypto) GenerateRandomBytes(pOGRB *domain.ParamsOfGenerateRandomBytes) (*domain.ResultOfGenerateRandomBytes, error) {
+	return c.GenerateRandomBytesCtx(context.Background(), pOGRB)
+}
+
+// GenerateRandomBytesCtx - GenerateRandomBytes bounded by ctx.
+func (c *crypto) GenerateRandomBytesCtx(ctx context.Context, pOGRB *domain.ParamsOfGenerateRandomBytes) (*domain.ResultOfGenerateRandomBytes, error) {
 	result := new(domain.ResultOfGenerateRandomBytes)
-	err := c.client.GetResult("crypto.generate_random_bytes", pOGRB, result)
+	err := c.client.GetResultContext(ctx, "crypto.generate_random_bytes", pOGRB, result)
 	return result, err
 }
 
 // ConvertPublicKeyString - Converts public key to ton safe_format.
 func (c *crypto) ConvertPublicKeyString(pOCPTTSF *domain.ParamsOfConvertPublicKeyToTonSafeFormat) (*domain.ResultOfConvertPublicKeyToTonSafeFormat, error) {
+	return c.ConvertPublicKeyStringCtx(context.Background(), pOCPTTSF)
+}
+
+// ConvertPublicKeyStringCtx - ConvertPublicKeyString bounded by ctx.
+func (c *crypto) ConvertPublicKeyStringCtx(ctx context.Context, pOCPTTSF *domain.ParamsOfConvertPublicKeyToTonSafeFormat) (*domain.ResultOfConvertPublicKeyToTonSafeFormat, error) {
 	result := new(domain.ResultOfConvertPublicKeyToTonSafeFormat)
-	err := c.client.GetResult("crypto.convert_public_key_to_ton_safe_format", pOCPTTSF, result)
+	err := c.client.GetResultContext(ctx, "crypto.convert_public_key_to_ton_safe_format", pOCPTTSF, result)
 	return result, err
 }
 
 // GenerateRandomSignKeys - Generates random ed25519 key pair.
 func (c *crypto) GenerateRandomSignKeys() (*domain.KeyPair, error) {
+	return c.GenerateRandomSignKeysCtx(context.Background())
+}
+
+// GenerateRandomSignKeysCtx - GenerateRandomSignKeys bounded by ctx.
+func (c *crypto) GenerateRandomSignKeysCtx(ctx context.Context) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.generate_random_sign_keys", "{}", result)
+	err := c.client.GetResultContext(ctx, "crypto.generate_random_sign_keys", "{}", result)
 	return result, err
 }
 
 // Sign - Signs a data using the provided keys.
 func (c *crypto) Sign(pOS *domain.ParamsOfSign) (*domain.ResultOfSign, error) {
+	return c.SignCtx(context.Background(), pOS)
+}
+
+// SignCtx - Sign bounded by ctx.
+func (c *crypto) SignCtx(ctx context.Context, pOS *domain.ParamsOfSign) (*domain.ResultOfSign, error) {
 	result := new(domain.ResultOfSign)
-	err := c.client.GetResult("crypto.sign", pOS, result)
+	err := c.client.GetResultContext(ctx, "crypto.sign", pOS, result)
 	return result, err
 }
 
 // VerifySignature - Verifies signed data using the provided public key. Raises error if verification is failed.
 func (c *crypto) VerifySignature(pOVS *domain.ParamsOfVerifySignature) (*domain.ResultOfVerifySignature, error) {
+	return c.VerifySignatureCtx(context.Background(), pOVS)
+}
+
+// VerifySignatureCtx - VerifySignature bounded by ctx.
+func (c *crypto) VerifySignatureCtx(ctx context.Context, pOVS *domain.ParamsOfVerifySignature) (*domain.ResultOfVerifySignature, error) {
 	result := new(domain.ResultOfVerifySignature)
-	err := c.client.GetResult("crypto.verify_signature", pOVS, result)
+	err := c.client.GetResultContext(ctx, "crypto.verify_signature", pOVS, result)
 	return result, err
 }
 
 // Sha256 - Calculates SHA256 hash of the specified data.
 func (c *crypto) Sha256(pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
+	return c.Sha256Ctx(context.Background(), pOH)
+}
+
+// Sha256Ctx - Sha256 bounded by ctx.
+func (c *crypto) Sha256Ctx(ctx context.Context, pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
 	result := new(domain.ResultOfHash)
-	err := c.client.GetResult("crypto.sha256", pOH, result)
+	err := c.client.GetResultContext(ctx, "crypto.sha256", pOH, result)
 	return result, err
 }
 
 // Sha512 - Calculates SHA512 hash of the specified data.
 func (c *crypto) Sha512(pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
+	return c.Sha512Ctx(context.Background(), pOH)
+}
+
+// Sha512Ctx - Sha512 bounded by ctx.
+func (c *crypto) Sha512Ctx(ctx context.Context, pOH *domain.ParamsOfHash) (*domain.ResultOfHash, error) {
 	result := new(domain.ResultOfHash)
-	err := c.client.GetResult("crypto.sha512", pOH, result)
+	err := c.client.GetResultContext(ctx, "crypto.sha512", pOH, result)
 	return result, err
 }
 
 // Scrypt - Derives key from password and key using scrypt algorithm.
 func (c *crypto) Scrypt(sD *domain.ParamsOfScrypt) (*domain.ResultOfScrypt, error) {
+	return c.ScryptCtx(context.Background(), sD)
+}
+
+// ScryptCtx - Scrypt bounded by ctx.
+func (c *crypto) ScryptCtx(ctx context.Context, sD *domain.ParamsOfScrypt) (*domain.ResultOfScrypt, error) {
 	result := new(domain.ResultOfScrypt)
-	err := c.client.GetResult("crypto.scrypt", sD, result)
+	err := c.client.GetResultContext(ctx, "crypto.scrypt", sD, result)
 	return result, err
 }
 
 // NaclSignKeypairFromSecretKey - Generates a key pair for signing from the secret key.
 func (c *crypto) NaclSignKeypairFromSecretKey(pONSKPFC *domain.ParamsOfNaclSignKeyPairFromSecret) (*domain.KeyPair, error) {
+	return c.NaclSignKeypairFromSecretKeyCtx(context.Background(), pONSKPFC)
+}
+
+// NaclSignKeypairFromSecretKeyCtx - NaclSignKeypairFromSecretKey bounded by ctx.
+func (c *crypto) NaclSignKeypairFromSecretKeyCtx(ctx context.Context, pONSKPFC *domain.ParamsOfNaclSignKeyPairFromSecret) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_sign_keypair_from_secret_key", pONSKPFC, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_keypair_from_secret_key", pONSKPFC, result)
 	return result, err
 }
 
 // NaclSign - Signs data using the signer's secret key.
 func (c *crypto) NaclSign(pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSign, error) {
+	return c.NaclSignCtx(context.Background(), pONS)
+}
+
+// NaclSignCtx - NaclSign bounded by ctx.
+func (c *crypto) NaclSignCtx(ctx context.Context, pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSign, error) {
 	result := new(domain.ResultOfNaclSign)
-	err := c.client.GetResult("crypto.nacl_sign", pONS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign", pONS, result)
 	return result, err
 }
 
 // NaclSignOpen - Verifies the signature and returns the unsigned message.
 // Verifies the signature in signed using the signer's public key public and returns the message unsigned.
-//If the signature fails verification, crypto_sign_open raises an exception.
+// If the signature fails verification, crypto_sign_open raises an exception.
 func (c *crypto) NaclSignOpen(pONSO *domain.ParamsOfNaclSignOpen) (*domain.ResultOfNaclSignOpen, error) {
+	return c.NaclSignOpenCtx(context.Background(), pONSO)
+}
+
+// NaclSignOpenCtx - NaclSignOpen bounded by ctx.
+func (c *crypto) NaclSignOpenCtx(ctx context.Context, pONSO *domain.ParamsOfNaclSignOpen) (*domain.ResultOfNaclSignOpen, error) {
 	result := new(domain.ResultOfNaclSignOpen)
-	err := c.client.GetResult("crypto.nacl_sign_open", pONSO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_open", pONSO, result)
 	return result, err
 }
 
 // NaclSignDetached - Signs the message using the secret key and returns a signature.
-//Signs the message unsigned using the secret key secret and returns a signature signature.
+// Signs the message unsigned using the secret key secret and returns a signature signature.
 func (c *crypto) NaclSignDetached(pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSignDetached, error) {
+	return c.NaclSignDetachedCtx(context.Background(), pONS)
+}
+
+// NaclSignDetachedCtx - NaclSignDetached bounded by ctx.
+func (c *crypto) NaclSignDetachedCtx(ctx context.Context, pONS *domain.ParamsOfNaclSign) (*domain.ResultOfNaclSignDetached, error) {
 	result := new(domain.ResultOfNaclSignDetached)
-	err := c.client.GetResult("crypto.nacl_sign_detached", pONS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_detached", pONS, result)
 	return result, err
 }
 
 // NaclSignDetachedVerify - Verifies the signature with public key and unsigned data.
 func (c *crypto) NaclSignDetachedVerify(pONSDV *domain.ParamsOfNaclSignDetachedVerify) (*domain.ResultOfNaclSignDetachedVerify, error) {
+	return c.NaclSignDetachedVerifyCtx(context.Background(), pONSDV)
+}
+
+// NaclSignDetachedVerifyCtx - NaclSignDetachedVerify bounded by ctx.
+func (c *crypto) NaclSignDetachedVerifyCtx(ctx context.Context, pONSDV *domain.ParamsOfNaclSignDetachedVerify) (*domain.ResultOfNaclSignDetachedVerify, error) {
 	result := new(domain.ResultOfNaclSignDetachedVerify)
-	err := c.client.GetResult("crypto.nacl_sign_detached_verify", pONSDV, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_sign_detached_verify", pONSDV, result)
 	return result, err
 }
 
 // NaclBoxKeypair - Generates a random NaCl key pair.
 func (c *crypto) NaclBoxKeypair() (*domain.KeyPair, error) {
+	return c.NaclBoxKeypairCtx(context.Background())
+}
+
+// NaclBoxKeypairCtx - NaclBoxKeypair bounded by ctx.
+func (c *crypto) NaclBoxKeypairCtx(ctx context.Context) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_box_keypair", "{}", result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_keypair", "{}", result)
 	return result, err
 }
 
 // NaclBoxKeypairFromSecretKey - Generates key pair from a secret key.
 func (c *crypto) NaclBoxKeypairFromSecretKey(pONKPFS *domain.ParamsOfNaclBoxKeyPairFromSecret) (*domain.KeyPair, error) {
+	return c.NaclBoxKeypairFromSecretKeyCtx(context.Background(), pONKPFS)
+}
+
+// NaclBoxKeypairFromSecretKeyCtx - NaclBoxKeypairFromSecretKey bounded by ctx.
+func (c *crypto) NaclBoxKeypairFromSecretKeyCtx(ctx context.Context, pONKPFS *domain.ParamsOfNaclBoxKeyPairFromSecret) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.nacl_box_keypair_from_secret_key", pONKPFS, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_keypair_from_secret_key", pONKPFS, result)
 	return result, err
 }
 
 // NaclBox - Public key authenticated encryption. Encrypt and authenticate a message using
 // the senders secret key, the receivers public key, and a nonce.
 func (c *crypto) NaclBox(pONB *domain.ParamsOfNaclBox) (*domain.ResultOfNaclBox, error) {
+	return c.NaclBoxCtx(context.Background(), pONB)
+}
+
+// NaclBoxCtx - NaclBox bounded by ctx.
+func (c *crypto) NaclBoxCtx(ctx context.Context, pONB *domain.ParamsOfNaclBox) (*domain.ResultOfNaclBox, error) {
 	result := new(domain.ResultOfNaclBox)
-	err := c.client.GetResult("crypto.nacl_box", pONB, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box", pONB, result)
 	return result, err
 }
 
 // NaclBoxOpen - Decrypt and verify the cipher text using the recievers secret key, the senders public
 // key, and the nonce.
 func (c *crypto) NaclBoxOpen(pONBO *domain.ParamsOfNaclBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
+	return c.NaclBoxOpenCtx(context.Background(), pONBO)
+}
+
+// NaclBoxOpenCtx - NaclBoxOpen bounded by ctx.
+func (c *crypto) NaclBoxOpenCtx(ctx context.Context, pONBO *domain.ParamsOfNaclBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
 	result := new(domain.ResultOfNaclBoxOpen)
-	err := c.client.GetResult("crypto.nacl_box_open", pONBO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_box_open", pONBO, result)
 	return result, err
 }
 
 // NaclSecretBox - Encrypt and authenticate message using nonce and secret key.
 func (c *crypto) NaclSecretBox(pONSB *domain.ParamsOfNaclSecretBox) (*domain.ResultOfNaclBox, error) {
+	return c.NaclSecretBoxCtx(context.Background(), pONSB)
+}
+
+// NaclSecretBoxCtx - NaclSecretBox bounded by ctx.
+func (c *crypto) NaclSecretBoxCtx(ctx context.Context, pONSB *domain.ParamsOfNaclSecretBox) (*domain.ResultOfNaclBox, error) {
 	result := new(domain.ResultOfNaclBox)
-	err := c.client.GetResult("crypto.nacl_secret_box", pONSB, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_secret_box", pONSB, result)
 	return result, err
 }
 
 // NaclSecretBoxOpen - Decrypts and verifies cipher text using nonce and secret key.
 func (c *crypto) NaclSecretBoxOpen(pONSBO *domain.ParamsOfNaclSecretBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
+	return c.NaclSecretBoxOpenCtx(context.Background(), pONSBO)
+}
+
+// NaclSecretBoxOpenCtx - NaclSecretBoxOpen bounded by ctx.
+func (c *crypto) NaclSecretBoxOpenCtx(ctx context.Context, pONSBO *domain.ParamsOfNaclSecretBoxOpen) (*domain.ResultOfNaclBoxOpen, error) {
 	result := new(domain.ResultOfNaclBoxOpen)
-	err := c.client.GetResult("crypto.nacl_secret_box_open", pONSBO, result)
+	err := c.client.GetResultContext(ctx, "crypto.nacl_secret_box_open", pONSBO, result)
 	return result, err
 }
 
 // MnemonicWords - Prints the list of words from the specified dictionary.
 func (c *crypto) MnemonicWords(pOMW *domain.ParamsOfMnemonicWords) (*domain.ResultOfMnemonicWords, error) {
+	return c.MnemonicWordsCtx(context.Background(), pOMW)
+}
+
+// MnemonicWordsCtx - MnemonicWords bounded by ctx.
+func (c *crypto) MnemonicWordsCtx(ctx context.Context, pOMW *domain.ParamsOfMnemonicWords) (*domain.ResultOfMnemonicWords, error) {
 	result := new(domain.ResultOfMnemonicWords)
-	err := c.client.GetResult("crypto.mnemonic_words", pOMW, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_words", pOMW, result)
 	return result, err
 }
 
 // MnemonicFromRandom - Generates a random mnemonic from the specified dictionary and word count.
 func (c *crypto) MnemonicFromRandom(pOMFR *domain.ParamsOfMnemonicFromRandom) (*domain.ResultOfMnemonicFromRandom, error) {
+	return c.MnemonicFromRandomCtx(context.Background(), pOMFR)
+}
+
+// MnemonicFromRandomCtx - MnemonicFromRandom bounded by ctx.
+func (c *crypto) MnemonicFromRandomCtx(ctx context.Context, pOMFR *domain.ParamsOfMnemonicFromRandom) (*domain.ResultOfMnemonicFromRandom, error) {
 	result := new(domain.ResultOfMnemonicFromRandom)
-	err := c.client.GetResult("crypto.mnemonic_from_random", pOMFR, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_from_random", pOMFR, result)
 	return result, err
 }
 
 // MnemonicFromEntropy - Generates mnemonic from pre-generated entropy.
 func (c *crypto) MnemonicFromEntropy(pOMFE *domain.ParamsOfMnemonicFromEntropy) (*domain.ResultOfMnemonicFromEntropy, error) {
+	return c.MnemonicFromEntropyCtx(context.Background(), pOMFE)
+}
+
+// MnemonicFromEntropyCtx - MnemonicFromEntropy bounded by ctx.
+func (c *crypto) MnemonicFromEntropyCtx(ctx context.Context, pOMFE *domain.ParamsOfMnemonicFromEntropy) (*domain.ResultOfMnemonicFromEntropy, error) {
 	result := new(domain.ResultOfMnemonicFromEntropy)
-	err := c.client.GetResult("crypto.mnemonic_from_entropy", pOMFE, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_from_entropy", pOMFE, result)
 	return result, err
 }
 
 // MnemonicVerify - The phrase supplied will be checked for word length and validated according to the
 // checksum specified in BIP0039.
 func (c *crypto) MnemonicVerify(pOMV *domain.ParamsOfMnemonicVerify) (*domain.ResultOfMnemonicVerify, error) {
+	return c.MnemonicVerifyCtx(context.Background(), pOMV)
+}
+
+// MnemonicVerifyCtx - MnemonicVerify bounded by ctx.
+func (c *crypto) MnemonicVerifyCtx(ctx context.Context, pOMV *domain.ParamsOfMnemonicVerify) (*domain.ResultOfMnemonicVerify, error) {
 	result := new(domain.ResultOfMnemonicVerify)
-	err := c.client.GetResult("crypto.mnemonic_verify", pOMV, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_verify", pOMV, result)
 	return result, err
 }
 
 // MnemonicDeriveSignKeys - Validates the seed phrase, generates master key and then derives the key pair from
 // the master key and the specified path.
 func (c *crypto) MnemonicDeriveSignKeys(pOMDSK *domain.ParamsOfMnemonicDeriveSignKeys) (*domain.KeyPair, error) {
+	return c.MnemonicDeriveSignKeysCtx(context.Background(), pOMDSK)
+}
+
+// MnemonicDeriveSignKeysCtx - MnemonicDeriveSignKeys bounded by ctx.
+func (c *crypto) MnemonicDeriveSignKeysCtx(ctx context.Context, pOMDSK *domain.ParamsOfMnemonicDeriveSignKeys) (*domain.KeyPair, error) {
 	result := new(domain.KeyPair)
-	err := c.client.GetResult("crypto.mnemonic_derive_sign_keys", pOMDSK, result)
+	err := c.client.GetResultContext(ctx, "crypto.mnemonic_derive_sign_keys", pOMDSK, result)
 	return result, err
 }
 
 // HDKeyXprvFromMnemonic - Generates an extended master private key that will be the root for all the derived keys.
 func (c *crypto) HDKeyXprvFromMnemonic(pOHKXFM *domain.ParamsOfHDKeyXPrvFromMnemonic) (*domain.ResultOfHDKeyXPrvFromMnemonic, error) {
+	return c.HDKeyXprvFromMnemonicCtx(context.Background(), pOHKXFM)
+}
+
+// HDKeyXprvFromMnemonicCtx - HDKeyXprvFromMnemonic bounded by ctx.
+func (c *crypto) HDKeyXprvFromMnemonicCtx(ctx context.Context, pOHKXFM *domain.ParamsOfHDKeyXPrvFromMnemonic) (*domain.ResultOfHDKeyXPrvFromMnemonic, error) {
 	result := new(domain.ResultOfHDKeyXPrvFromMnemonic)
-	err := c.client.GetResult("crypto.hdkey_xprv_from_mnemonic", pOHKXFM, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_xprv_from_mnemonic", pOHKXFM, result)
 	return result, err
 }
 
 // HDKeyDeriveFromXprv - Returns extended private key derived from the specified extended private key and child index.
 func (c *crypto) HDKeyDeriveFromXprv(hdP *domain.ParamsOfHDKeyDeriveFromXPrv) (*domain.ResultOfHDKeyDeriveFromXPrv, error) {
+	return c.HDKeyDeriveFromXprvCtx(context.Background(), hdP)
+}
+
+// HDKeyDeriveFromXprvCtx - HDKeyDeriveFromXprv bounded by ctx.
+func (c *crypto) HDKeyDeriveFromXprvCtx(ctx context.Context, hdP *domain.ParamsOfHDKeyDeriveFromXPrv) (*domain.ResultOfHDKeyDeriveFromXPrv, error) {
 	result := new(domain.ResultOfHDKeyDeriveFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_derive_from_xprv", hdP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_derive_from_xprv", hdP, result)
 	return result, err
 }
 
 // HDKeyDeriveFromXprvPath - Derives the extended private key from the specified key and path.
 func (c *crypto) HDKeyDeriveFromXprvPath(hdPD *domain.ParamsOfHDKeyDeriveFromXPrvPath) (*domain.ResultOfHDKeyDeriveFromXPrvPath, error) {
+	return c.HDKeyDeriveFromXprvPathCtx(context.Background(), hdPD)
+}
+
+// HDKeyDeriveFromXprvPathCtx - HDKeyDeriveFromXprvPath bounded by ctx.
+func (c *crypto) HDKeyDeriveFromXprvPathCtx(ctx context.Context, hdPD *domain.ParamsOfHDKeyDeriveFromXPrvPath) (*domain.ResultOfHDKeyDeriveFromXPrvPath, error) {
 	result := new(domain.ResultOfHDKeyDeriveFromXPrvPath)
-	err := c.client.GetResult("crypto.hdkey_derive_from_xprv_path", hdPD, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_derive_from_xprv_path", hdPD, result)
 	return result, err
 }
 
 // HDKeySecretFromXprv - Extracts the private key from the serialized extended private key.
 func (c *crypto) HDKeySecretFromXprv(pOHKSFXP *domain.ParamsOfHDKeySecretFromXPrv) (*domain.ResultOfHDKeySecretFromXPrv, error) {
+	return c.HDKeySecretFromXprvCtx(context.Background(), pOHKSFXP)
+}
+
+// HDKeySecretFromXprvCtx - HDKeySecretFromXprv bounded by ctx.
+func (c *crypto) HDKeySecretFromXprvCtx(ctx context.Context, pOHKSFXP *domain.ParamsOfHDKeySecretFromXPrv) (*domain.ResultOfHDKeySecretFromXPrv, error) {
 	result := new(domain.ResultOfHDKeySecretFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_secret_from_xprv", pOHKSFXP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_secret_from_xprv", pOHKSFXP, result)
 	return result, err
 }
 
 // HDKeyPublicFromXprv - Extracts the public key from the serialized extended private key.
 func (c *crypto) HDKeyPublicFromXprv(pOHKPFXP *domain.ParamsOfHDKeyPublicFromXPrv) (*domain.ResultOfHDKeyPublicFromXPrv, error) {
+	return c.HDKeyPublicFromXprvCtx(context.Background(), pOHKPFXP)
+}
+
+// HDKeyPublicFromXprvCtx - HDKeyPublicFromXprv bounded by ctx.
+func (c *crypto) HDKeyPublicFromXprvCtx(ctx context.Context, pOHKPFXP *domain.ParamsOfHDKeyPublicFromXPrv) (*domain.ResultOfHDKeyPublicFromXPrv, error) {
 	result := new(domain.ResultOfHDKeyPublicFromXPrv)
-	err := c.client.GetResult("crypto.hdkey_public_from_xprv", pOHKPFXP, result)
+	err := c.client.GetResultContext(ctx, "crypto.hdkey_public_from_xprv", pOHKPFXP, result)
 	return result, err
 }
 
 // Chacha20 - Performs symmetric chacha20 encryption.
 func (c *crypto) Chacha20(pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCha20, error) {
+	return c.Chacha20Ctx(context.Background(), pOFCC)
+}
+
+// Chacha20Ctx - Chacha20 bounded by ctx.
+func (c *crypto) Chacha20Ctx(ctx context.Context, pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCha20, error) {
 	result := new(domain.ResultOfChaCha20)
-	err := c.client.GetResult("crypto.chacha20", pOFCC, result)
+	err := c.client.GetResultContext(ctx, "crypto.chacha20", pOFCC, result)
 	return result, err
 }
 
@@ -267,96 +432,99 @@ func (c *crypto) Chacha20(pOFCC *domain.ParamsOfChaCha20) (*domain.ResultOfChaCh
 // When used, decrypted secret shows up in core library's memory for a very short period of time and then is immediately
 // overwritten with zeroes.
 func (c *crypto) CreateCryptoBox(pOCCB *domain.ParamsOfCreateCryptoBox, app domain.AppPasswordProvider) (*domain.RegisteredCryptoBox, error) {
-	result := new(domain.RegisteredCryptoBox)
-	responses, err := c.client.Request("crypto.create_crypto_box", pOCCB)
-	if err != nil {
-		return nil, err
-	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
+	return c.CreateCryptoBoxCtx(context.Background(), pOCCB, app)
+}
 
-	if err := json.Unmarshal(response.Data, result); err != nil {
+// CreateCryptoBoxCtx - CreateCryptoBox bounded by ctx.
+// ctx also bounds the lifetime of the app object: once it is done, callbacks are no longer served.
+func (c *crypto) CreateCryptoBoxCtx(ctx context.Context, pOCCB *domain.ParamsOfCreateCryptoBox, app domain.AppPasswordProvider) (*domain.RegisteredCryptoBox, error) {
+	result := new(domain.RegisteredCryptoBox)
+	if _, err := c.client.AppObjects().Register(ctx, "crypto.create_crypto_box", pOCCB, passwordProvider{app}, result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCreateCryptoBox(r.Data, app)
-			}
-		}
-	}()
-
 	return result, nil
 }
 
-func (c *crypto) appRequestCreateCryptoBox(payload []byte, app domain.AppPasswordProvider) {
-	var appRequest domain.ParamsOfAppRequest
+// passwordProvider - domain.AppObject of an application implemented password provider.
+type passwordProvider struct {
+	app domain.AppPasswordProvider
+}
+
+// Request answers the app requests of the library.
+func (p passwordProvider) Request(_ context.Context, requestData json.RawMessage) (interface{}, error) {
 	var appParams domain.ParamsOfAppPasswordProvider
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(requestData, &appParams); err != nil {
+		return nil, err
 	}
-	var appResponse interface{}
+	var (
+		appResponse interface{}
+		err         error
+	)
 	switch value := (appParams.ValueEnumType).(type) {
 	case domain.ParamsOfAppPasswordProviderGetPassword:
-		appResponse, err = app.GetPassword(value)
+		appResponse, err = p.app.GetPassword(value)
 	default:
 		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
 	}
-
-	appReqResult := &domain.AppRequestResult{}
 	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppPasswordProvider{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
-	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
+		return nil, err
 	}
-	panic(err)
+
+	return &domain.ResultOfAppPasswordProvider{ValueEnumType: appResponse}, nil
+}
+
+// Notify drops app notifications, the library sends none.
+func (passwordProvider) Notify(context.Context, json.RawMessage) error {
+	return nil
 }
 
 // RemoveCryptoBox - Removes Crypto Box. Clears all secret data.
 func (c *crypto) RemoveCryptoBox(box *domain.RegisteredCryptoBox) error {
-	_, err := c.client.GetResponse("crypto.remove_crypto_box", box)
+	return c.RemoveCryptoBoxCtx(context.Background(), box)
+}
+
+// RemoveCryptoBoxCtx - RemoveCryptoBox bounded by ctx.
+func (c *crypto) RemoveCryptoBoxCtx(ctx context.Context, box *domain.RegisteredCryptoBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_crypto_box", box)
 	return err
 }
 
 // GetCryptoBoxInfo - Get Crypto Box Info. Used to get encrypted_secret that should be used for all the cryptobox
 // initializations except the first one.
 func (c *crypto) GetCryptoBoxInfo(box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxInfo, error) {
+	return c.GetCryptoBoxInfoCtx(context.Background(), box)
+}
+
+// GetCryptoBoxInfoCtx - GetCryptoBoxInfo bounded by ctx.
+func (c *crypto) GetCryptoBoxInfoCtx(ctx context.Context, box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxInfo, error) {
 	result := new(domain.ResultOfGetCryptoBoxInfo)
-	err := c.client.GetResult("crypto.get_crypto_box_info", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_crypto_box_info", box, result)
 	return result, err
 }
 
 // GetCryptoBoxSeedPhrase - Get Crypto Box Seed Phrase.
 // Attention! Store this data in your application for a very short period of time and overwrite it with zeroes ASAP.
 func (c *crypto) GetCryptoBoxSeedPhrase(box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxSeedPhrase, error) {
+	return c.GetCryptoBoxSeedPhraseCtx(context.Background(), box)
+}
+
+// GetCryptoBoxSeedPhraseCtx - GetCryptoBoxSeedPhrase bounded by ctx.
+func (c *crypto) GetCryptoBoxSeedPhraseCtx(ctx context.Context, box *domain.RegisteredCryptoBox) (*domain.ResultOfGetCryptoBoxSeedPhrase, error) {
 	result := new(domain.ResultOfGetCryptoBoxSeedPhrase)
-	err := c.client.GetResult("crypto.get_crypto_box_seed_phrase", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_crypto_box_seed_phrase", box, result)
 	return result, err
 }
 
 // GetSigningBoxFromCryptoBox - Get handle of Signing Box derived from Crypto Box.
 func (c *crypto) GetSigningBoxFromCryptoBox(box *domain.ParamsOfGetSigningBoxFromCryptoBox) (*domain.RegisteredSigningBox, error) {
+	return c.GetSigningBoxFromCryptoBoxCtx(context.Background(), box)
+}
+
+// GetSigningBoxFromCryptoBoxCtx - GetSigningBoxFromCryptoBox bounded by ctx.
+func (c *crypto) GetSigningBoxFromCryptoBoxCtx(ctx context.Context, box *domain.ParamsOfGetSigningBoxFromCryptoBox) (*domain.RegisteredSigningBox, error) {
 	result := new(domain.RegisteredSigningBox)
-	err := c.client.GetResult("crypto.get_signing_box_from_crypto_box", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_signing_box_from_crypto_box", box, result)
 	return result, err
 }
 
@@ -365,213 +533,234 @@ func (c *crypto) GetSigningBoxFromCryptoBox(box *domain.ParamsOfGetSigningBoxFro
 // explicitly cleared by clear_crypto_box_secret_cache method. If secret_lifetime is not specified - overwrites
 // encryption secret with zeroes immediately after encryption operation.
 func (c *crypto) GetEncryptionBoxFromCryptoBox(box *domain.ParamsOfGetEncryptionBoxFromCryptoBox) (*domain.RegisteredEncryptionBox, error) {
+	return c.GetEncryptionBoxFromCryptoBoxCtx(context.Background(), box)
+}
+
+// GetEncryptionBoxFromCryptoBoxCtx - GetEncryptionBoxFromCryptoBox bounded by ctx.
+func (c *crypto) GetEncryptionBoxFromCryptoBoxCtx(ctx context.Context, box *domain.ParamsOfGetEncryptionBoxFromCryptoBox) (*domain.RegisteredEncryptionBox, error) {
 	result := new(domain.RegisteredEncryptionBox)
-	err := c.client.GetResult("crypto.get_encryption_box_from_crypto_box", box, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_encryption_box_from_crypto_box", box, result)
 	return result, err
 }
 
 // ClearCryptoBoxSecretCache - Removes cached secrets (overwrites with zeroes) from all signing and encryption boxes,
 // derived from crypto box.
 func (c *crypto) ClearCryptoBoxSecretCache(box *domain.RegisteredCryptoBox) error {
-	_, err := c.client.GetResponse("crypto.clear_crypto_box_secret_cache", box)
+	return c.ClearCryptoBoxSecretCacheCtx(context.Background(), box)
+}
+
+// ClearCryptoBoxSecretCacheCtx - ClearCryptoBoxSecretCache bounded by ctx.
+func (c *crypto) ClearCryptoBoxSecretCacheCtx(ctx context.Context, box *domain.RegisteredCryptoBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.clear_crypto_box_secret_cache", box)
 	return err
 }
 
 // RegisterSigningBox - Register an application implemented signing box.
 func (c *crypto) RegisterSigningBox(app domain.AppSigningBox) (*domain.RegisteredSigningBox, error) {
-	result := new(domain.RegisteredSigningBox)
-	responses, err := c.client.Request("crypto.register_signing_box", nil)
-	if err != nil {
-		return nil, err
-	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
+	return c.RegisterSigningBoxCtx(context.Background(), app)
+}
 
-	if err := json.Unmarshal(response.Data, result); err != nil {
+// RegisterSigningBoxCtx - RegisterSigningBox bounded by ctx.
+// ctx also bounds the lifetime of the app object: once it is done, callbacks are no longer served.
+func (c *crypto) RegisterSigningBoxCtx(ctx context.Context, app domain.AppSigningBox) (*domain.RegisteredSigningBox, error) {
+	result := new(domain.RegisteredSigningBox)
+	if _, err := c.client.AppObjects().Register(ctx, "crypto.register_signing_box", nil, signingBox{app}, result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCryptoRegisterSigningBox(r.Data, app)
-			}
-		}
-	}()
-
 	return result, nil
 }
 
-func (c *crypto) appRequestCryptoRegisterSigningBox(payload []byte, app domain.AppSigningBox) {
-	var appRequest domain.ParamsOfAppRequest
+// signingBox - domain.AppObject of an application implemented signing box.
+type signingBox struct {
+	app domain.AppSigningBox
+}
+
+// Request answers the app requests of the library.
+func (b signingBox) Request(_ context.Context, requestData json.RawMessage) (interface{}, error) {
 	var appParams domain.ParamsOfAppSigningBox
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(requestData, &appParams); err != nil {
+		return nil, err
 	}
-	var appResponse interface{}
+	var (
+		appResponse interface{}
+		err         error
+	)
 	switch value := (appParams.ValueEnumType).(type) {
 	case domain.ParamsOfAppSigningBoxGetPublicKey:
-		appResponse, err = app.GetPublicKey()
+		appResponse, err = b.app.GetPublicKey()
 	case domain.ParamsOfAppSigningBoxSign:
-		appResponse, err = app.Sign(value)
+		appResponse, err = b.app.Sign(value)
 	default:
 		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
 	}
-
-	appReqResult := &domain.AppRequestResult{}
 	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppSigningBox{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
-	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
+		return nil, err
 	}
-	panic(err)
+
+	return &domain.ResultOfAppSigningBox{ValueEnumType: appResponse}, nil
+}
+
+// Notify drops app notifications, the library sends none.
+func (signingBox) Notify(context.Context, json.RawMessage) error {
+	return nil
 }
 
 // GetSigningBox - Creates a default signing box implementation.
 func (c *crypto) GetSigningBox(keypair *domain.KeyPair) (*domain.RegisteredSigningBox, error) {
+	return c.GetSigningBoxCtx(context.Background(), keypair)
+}
+
+// GetSigningBoxCtx - GetSigningBox bounded by ctx.
+func (c *crypto) GetSigningBoxCtx(ctx context.Context, keypair *domain.KeyPair) (*domain.RegisteredSigningBox, error) {
 	result := new(domain.RegisteredSigningBox)
-	err := c.client.GetResult("crypto.get_signing_box", keypair, result)
+	err := c.client.GetResultContext(ctx, "crypto.get_signing_box", keypair, result)
 	return result, err
 }
 
 // SigningBoxGetPublicKey - Returns public key of signing key pair.
 func (c *crypto) SigningBoxGetPublicKey(keypair *domain.RegisteredSigningBox) (*domain.ResultOfSigningBoxGetPublicKey, error) {
+	return c.SigningBoxGetPublicKeyCtx(context.Background(), keypair)
+}
+
+// SigningBoxGetPublicKeyCtx - SigningBoxGetPublicKey bounded by ctx.
+func (c *crypto) SigningBoxGetPublicKeyCtx(ctx context.Context, keypair *domain.RegisteredSigningBox) (*domain.ResultOfSigningBoxGetPublicKey, error) {
 	result := new(domain.ResultOfSigningBoxGetPublicKey)
-	err := c.client.GetResult("crypto.signing_box_get_public_key", keypair, result)
+	err := c.client.GetResultContext(ctx, "crypto.signing_box_get_public_key", keypair, result)
 	return result, err
 }
 
 // SigningBoxSign - Returns signed user data.
 func (c *crypto) SigningBoxSign(pOSBS *domain.ParamsOfSigningBoxSign) (*domain.ResultOfSigningBoxSign, error) {
+	return c.SigningBoxSignCtx(context.Background(), pOSBS)
+}
+
+// SigningBoxSignCtx - SigningBoxSign bounded by ctx.
+func (c *crypto) SigningBoxSignCtx(ctx context.Context, pOSBS *domain.ParamsOfSigningBoxSign) (*domain.ResultOfSigningBoxSign, error) {
 	result := new(domain.ResultOfSigningBoxSign)
-	err := c.client.GetResult("crypto.signing_box_sign", pOSBS, result)
+	err := c.client.GetResultContext(ctx, "crypto.signing_box_sign", pOSBS, result)
 	return result, err
 }
 
 // RemoveSigningBox - Removes signing box from SDK.
 func (c *crypto) RemoveSigningBox(rSB *domain.RegisteredSigningBox) error {
-	_, err := c.client.GetResponse("crypto.remove_signing_box", rSB)
+	return c.RemoveSigningBoxCtx(context.Background(), rSB)
+}
+
+// RemoveSigningBoxCtx - RemoveSigningBox bounded by ctx.
+func (c *crypto) RemoveSigningBoxCtx(ctx context.Context, rSB *domain.RegisteredSigningBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_signing_box", rSB)
 	return err
 }
 
 // RegisterEncryptionBox - Register an application implemented encryption box.
 func (c *crypto) RegisterEncryptionBox(app domain.AppEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
-	result := new(domain.RegisteredEncryptionBox)
-	responses, err := c.client.Request("crypto.register_encryption_box", nil)
-	if err != nil {
-		return nil, err
-	}
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
+	return c.RegisterEncryptionBoxCtx(context.Background(), app)
+}
 
-	if err := json.Unmarshal(response.Data, result); err != nil {
+// RegisterEncryptionBoxCtx - RegisterEncryptionBox bounded by ctx.
+// ctx also bounds the lifetime of the app object: once it is done, callbacks are no longer served.
+func (c *crypto) RegisterEncryptionBoxCtx(ctx context.Context, app domain.AppEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
+	result := new(domain.RegisteredEncryptionBox)
+	if _, err := c.client.AppObjects().Register(ctx, "crypto.register_encryption_box", nil, encryptionBox{app}, result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				c.appRequestCryptoRegisterEncryptionBox(r.Data, app)
-			}
-		}
-	}()
-
 	return result, nil
 }
 
-func (c *crypto) appRequestCryptoRegisterEncryptionBox(payload []byte, app domain.AppEncryptionBox) {
-	var appRequest domain.ParamsOfAppRequest
+// encryptionBox - domain.AppObject of an application implemented encryption box.
+type encryptionBox struct {
+	app domain.AppEncryptionBox
+}
+
+// Request answers the app requests of the library.
+func (b encryptionBox) Request(_ context.Context, requestData json.RawMessage) (interface{}, error) {
 	var appParams domain.ParamsOfAppEncryptionBox
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(requestData, &appParams); err != nil {
+		return nil, err
 	}
-	var appResponse interface{}
+	var (
+		appResponse interface{}
+		err         error
+	)
 	switch value := (appParams.ValueEnumType).(type) {
 	case domain.ParamsOfAppEncryptionBoxGetInfo:
-		appResponse, err = app.GetInfo()
+		appResponse, err = b.app.GetInfo()
 	case domain.ParamsOfAppEncryptionBoxEncrypt:
-		appResponse, err = app.Encrypt(value)
+		appResponse, err = b.app.Encrypt(value)
 	case domain.ParamsOfAppEncryptionBoxDecrypt:
-		appResponse, err = app.Decrypt(value)
+		appResponse, err = b.app.Decrypt(value)
 	default:
 		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
 	}
-
-	appReqResult := &domain.AppRequestResult{}
 	if err != nil {
-		appReqResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marsh, err := json.Marshal(&domain.ResultOfAppEncryptionBox{ValueEnumType: appResponse})
-		if err != nil {
-			panic(err)
-		}
-		appReqResult.ValueEnumType = domain.AppRequestResultOk{Result: marsh}
-	}
-	err = c.client.ResolveAppRequest(&domain.ParamsOfResolveAppRequest{
-		AppRequestID: appRequest.AppRequestID,
-		Result:       appReqResult,
-	})
-	if err == nil || errors.Is(err, errors.New("channels is closed")) {
-		return
+		return nil, err
 	}
-	panic(err)
+
+	return &domain.ResultOfAppEncryptionBox{ValueEnumType: appResponse}, nil
+}
+
+// Notify drops app notifications, the library sends none.
+func (encryptionBox) Notify(context.Context, json.RawMessage) error {
+	return nil
 }
 
 // RemoveEncryptionBox - Removes encryption box from SDK.
 func (c *crypto) RemoveEncryptionBox(rEB *domain.RegisteredEncryptionBox) error {
-	_, err := c.client.GetResponse("crypto.remove_encryption_box", rEB)
+	return c.RemoveEncryptionBoxCtx(context.Background(), rEB)
+}
+
+// RemoveEncryptionBoxCtx - RemoveEncryptionBox bounded by ctx.
+func (c *crypto) RemoveEncryptionBoxCtx(ctx context.Context, rEB *domain.RegisteredEncryptionBox) error {
+	_, err := c.client.GetResponseContext(ctx, "crypto.remove_encryption_box", rEB)
 	return err
 }
 
 // EncryptionBoxGetInfo - Queries info from the given encryption box.
 func (c *crypto) EncryptionBoxGetInfo(pOEBGI *domain.ParamsOfEncryptionBoxGetInfo) (*domain.ResultOfEncryptionBoxGetInfo, error) {
+	return c.EncryptionBoxGetInfoCtx(context.Background(), pOEBGI)
+}
+
+// EncryptionBoxGetInfoCtx - EncryptionBoxGetInfo bounded by ctx.
+func (c *crypto) EncryptionBoxGetInfoCtx(ctx context.Context, pOEBGI *domain.ParamsOfEncryptionBoxGetInfo) (*domain.ResultOfEncryptionBoxGetInfo, error) {
 	result := new(domain.ResultOfEncryptionBoxGetInfo)
-	err := c.client.GetResult("crypto.encryption_box_get_info", pOEBGI, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_get_info", pOEBGI, result)
 	return result, err
 }
 
 // EncryptionBoxEncrypt - Encrypts data using given encryption box.
 func (c *crypto) EncryptionBoxEncrypt(pOAEBE *domain.ParamsOfEncryptionBoxEncrypt) (*domain.ResultOfEncryptionBoxEncrypt, error) {
+	return c.EncryptionBoxEncryptCtx(context.Background(), pOAEBE)
+}
+
+// EncryptionBoxEncryptCtx - EncryptionBoxEncrypt bounded by ctx.
+func (c *crypto) EncryptionBoxEncryptCtx(ctx context.Context, pOAEBE *domain.ParamsOfEncryptionBoxEncrypt) (*domain.ResultOfEncryptionBoxEncrypt, error) {
 	result := new(domain.ResultOfEncryptionBoxEncrypt)
-	err := c.client.GetResult("crypto.encryption_box_get_info", pOAEBE, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_encrypt", pOAEBE, result)
 	return result, err
 }
 
 // EncryptionBoxDecrypt - Decrypts data using given encryption box.
 func (c *crypto) EncryptionBoxDecrypt(pOAEBD *domain.ParamsOfEncryptionBoxDecrypt) (*domain.ResultOfEncryptionBoxDecrypt, error) {
+	return c.EncryptionBoxDecryptCtx(context.Background(), pOAEBD)
+}
+
+// EncryptionBoxDecryptCtx - EncryptionBoxDecrypt bounded by ctx.
+func (c *crypto) EncryptionBoxDecryptCtx(ctx context.Context, pOAEBD *domain.ParamsOfEncryptionBoxDecrypt) (*domain.ResultOfEncryptionBoxDecrypt, error) {
 	result := new(domain.ResultOfEncryptionBoxDecrypt)
-	err := c.client.GetResult("crypto.encryption_box_decrypt", pOAEBD, result)
+	err := c.client.GetResultContext(ctx, "crypto.encryption_box_decrypt", pOAEBD, result)
 	return result, err
 }
 
 // CreateEncryptionBox -Creates encryption box with specified algorithm.
 func (c *crypto) CreateEncryptionBox(pOCEB *domain.ParamsOfCreateEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
+	return c.CreateEncryptionBoxCtx(context.Background(), pOCEB)
+}
+
+// CreateEncryptionBoxCtx - CreateEncryptionBox bounded by ctx.
+func (c *crypto) CreateEncryptionBoxCtx(ctx context.Context, pOCEB *domain.ParamsOfCreateEncryptionBox) (*domain.RegisteredEncryptionBox, error) {
 	result := new(domain.RegisteredEncryptionBox)
-	err := c.client.GetResult("crypto.create_encryption_box", pOCEB, result)
+	err := c.client.GetResultContext(ctx, "crypto.create_encryption_box", pOCEB, result)
 	return result, err
 }
@@ -1,14 +1,17 @@
 package crypto
 
 import (
+	"context"
 	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/hex"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
 	"github.com/move-ton/ever-client-go/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -34,7 +37,8 @@ func (a *AppSigningBoxTest) Sign(sign domain.ParamsOfAppSigningBoxSign) (domain.
 }
 
 func TestCrypto(t *testing.T) {
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	configConn, err := domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientConn, err := client.NewClientGateway(configConn)
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
@@ -521,3 +525,57 @@ func TestCrypto(t *testing.T) {
 	})
 
 }
+
+func TestCryptoFake(t *testing.T) {
+	fake := clientmock.NewFake()
+	defer fake.Destroy()
+
+	cryptoUC := crypto{
+		config: domain.ClientConfig{},
+		client: fake,
+	}
+
+	t.Run("TestRegisterSigningBox", func(t *testing.T) {
+		fake.On("crypto.register_signing_box").
+			Respond(domain.RegisteredSigningBox{Handle: 2}).
+			AppRequest(map[string]string{"type": "GetPublicKey"}).
+			KeepOpen().
+			Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		box, err := cryptoUC.RegisterSigningBoxCtx(ctx, &AppSigningBoxTest{Public: "c4b4f8"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, domain.SigningBoxHandle(2), box.Handle)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(fake.CallsOf("client.resolve_app_request")) == 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		calls := fake.CallsOf("client.resolve_app_request")
+		assert.Equal(t, 1, len(calls))
+		assert.Contains(t, string(calls[0].Params), `"public_key":"c4b4f8"`)
+	})
+	t.Run("TestSigningBoxError", func(t *testing.T) {
+		fake.On("crypto.register_signing_box").
+			Respond(domain.RegisteredSigningBox{Handle: 3}).
+			AppRequest(map[string]string{"type": "Sign", "unsigned": "AA=="}).
+			KeepOpen().
+			Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := len(fake.CallsOf("client.resolve_app_request"))
+		_, err := cryptoUC.RegisterSigningBoxCtx(ctx, &AppSigningBoxTest{Private: "zz"})
+		assert.Equal(t, nil, err)
+
+		deadline := time.Now().Add(5 * time.Second)
+		for len(fake.CallsOf("client.resolve_app_request")) == calls && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		// The error of the app object is resolved as an Error result of the app request.
+		resolved := fake.CallsOf("client.resolve_app_request")
+		assert.Equal(t, calls+1, len(resolved))
+		assert.Contains(t, string(resolved[len(resolved)-1].Params), `"type":"Error"`)
+	})
+}
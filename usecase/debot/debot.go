@@ -1,8 +1,8 @@
 package debot
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/move-ton/ever-client-go/domain"
 )
@@ -23,98 +23,74 @@ func NewDebot(config domain.ClientConfig, client domain.ClientGateway) domain.De
 // Init - Creates and instance of DeBot.
 // Downloads debot smart contract (code and data) from blockchain and creates an instance of Debot Engine for it.
 func (d *debot) Init(pOI *domain.ParamsOfInit, app domain.AppDebotBrowser) (*domain.RegisteredDebot, error) {
-	result := new(domain.RegisteredDebot)
-	responses, err := d.client.Request("debot.init", pOI)
-	if err != nil {
-		return nil, err
-	}
-
-	response := <-responses
-	if response.Code == 1 {
-		return nil, response.Error
-	}
+	return d.InitCtx(context.Background(), pOI, app)
+}
 
-	if err := json.Unmarshal(response.Data, result); err != nil {
+// InitCtx - Init bounded by ctx.
+// ctx also bounds the lifetime of the browser callbacks: once it is done, they are no longer served.
+func (d *debot) InitCtx(ctx context.Context, pOI *domain.ParamsOfInit, app domain.AppDebotBrowser) (*domain.RegisteredDebot, error) {
+	result := new(domain.RegisteredDebot)
+	if _, err := d.client.AppObjects().Register(ctx, "debot.init", pOI, browser{app}, result); err != nil {
 		return nil, err
 	}
 
-	go func() {
-		for r := range responses {
-			if r.Code == 3 {
-				d.appRequestDebotInit(r.Data, app)
-			}
-			if r.Code == 4 {
-				d.appNotifyDebotInit(r.Data, app)
-			}
-		}
-	}()
-
 	return result, nil
 }
 
-// appRequestDebotInit ...
-func (d *debot) appRequestDebotInit(payload []byte, app domain.AppDebotBrowser) {
-	var appRequest domain.ParamsOfAppRequest
+// browser - domain.AppObject of an application implemented debot browser.
+type browser struct {
+	app domain.AppDebotBrowser
+}
+
+// Request answers the app requests of the debot.
+func (b browser) Request(_ context.Context, requestData json.RawMessage) (interface{}, error) {
 	var appParams domain.ParamsOfAppDebotBrowser
-	err := json.Unmarshal(payload, &appRequest)
-	if err != nil {
-		panic(err)
-	}
-	err = json.Unmarshal(appRequest.RequestData, &appParams)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(requestData, &appParams); err != nil {
+		return nil, err
 	}
-	var appResponse interface{}
-
+	var (
+		appResponse interface{}
+		err         error
+	)
 	switch value := (appParams.ValueEnumType).(type) {
 	case domain.ParamsOfAppDebotBrowserInput:
-		appResponse, err = app.Input(value)
+		appResponse, err = b.app.Input(value)
 	case domain.ParamsOfAppDebotBrowserGetSigningBox:
-		appResponse, err = app.GetSigningBox(value)
+		appResponse, err = b.app.GetSigningBox(value)
 	case domain.ParamsOfAppDebotBrowserInvokeDebot:
-		appResponse, err = app.InvokeDebot(value)
+		appResponse, err = b.app.InvokeDebot(value)
 	case domain.ParamsOfAppDebotBrowserApprove:
-		appResponse, err = app.Approve(value)
+		appResponse, err = b.app.Approve(value)
 	default:
 		err = fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
 	}
-
-	appRequestResult := &domain.AppRequestResult{}
 	if err != nil {
-		appRequestResult.ValueEnumType = domain.AppRequestResultError{Text: err.Error()}
-	} else {
-		marshal, _ := json.Marshal(&domain.ResultOfAppDebotBrowser{ValueEnumType: appResponse})
-		appRequestResult.ValueEnumType = domain.AppRequestResultOk{Result: marshal}
-	}
-	paramsResolved := &domain.ParamsOfResolveAppRequest{AppRequestID: appRequest.AppRequestID, Result: appRequestResult}
-	err = d.client.ResolveAppRequest(paramsResolved)
-	if err != nil || errors.Is(err, errors.New("channels is closed")) {
-		return
+		return nil, err
 	}
-	panic(err)
+
+	return &domain.ResultOfAppDebotBrowser{ValueEnumType: appResponse}, nil
 }
 
-// appNotifyDebotInit ...
-func (d *debot) appNotifyDebotInit(payload []byte, app domain.AppDebotBrowser) {
+// Notify receives the app notifications of the debot.
+func (b browser) Notify(_ context.Context, data json.RawMessage) error {
 	var appParams domain.ParamsOfAppDebotBrowser
-	err := json.Unmarshal(payload, &appParams)
-	if err != nil {
-		panic(err)
+	if err := json.Unmarshal(data, &appParams); err != nil {
+		return err
 	}
 
 	switch value := (appParams.ValueEnumType).(type) {
 	case domain.ParamsOfAppDebotBrowserLog:
-		_ = app.Log(value)
+		return b.app.Log(value)
 	case domain.ParamsOfAppDebotBrowserSwitch:
-		_ = app.Switch(value)
+		return b.app.Switch(value)
 	case domain.ParamsOfAppDebotBrowserSwitchCompleted:
-		_ = app.SwitchCompleted(value)
+		return b.app.SwitchCompleted(value)
 	case domain.ParamsOfAppDebotBrowserShowAction:
-		_ = app.ShowAction(value)
+		return b.app.ShowAction(value)
 	case domain.ParamsOfAppDebotBrowserSend:
-		_ = app.Send(value)
+		return b.app.Send(value)
 	default:
-		panic(fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType))
+		return fmt.Errorf("unsupported type for request %v", appParams.ValueEnumType)
 	}
 }
 
@@ -125,35 +101,60 @@ func (d *debot) appNotifyDebotInit(payload []byte, app domain.AppDebotBrowser) {
 // When the debot starts SDK registers BrowserCallbacks AppObject. Therefore when debote.remove is called the debot is
 // being deleted and the callback is called with finish=true which indicates that it will never be used again.
 func (d *debot) Start(poS *domain.ParamsOfStart) error {
-	_, err := d.client.GetResponse("debot.start", poS)
+	return d.StartCtx(context.Background(), poS)
+}
+
+// StartCtx - Start bounded by ctx.
+func (d *debot) StartCtx(ctx context.Context, poS *domain.ParamsOfStart) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.start", poS)
 	return err
 }
 
 // Fetch - Fetches DeBot metadata from blockchain.
 // Downloads DeBot from blockchain and creates and fetches its metadata.
 func (d *debot) Fetch(pOF *domain.ParamsOfFetch) (*domain.ResultOfFetch, error) {
+	return d.FetchCtx(context.Background(), pOF)
+}
+
+// FetchCtx - Fetch bounded by ctx.
+func (d *debot) FetchCtx(ctx context.Context, pOF *domain.ParamsOfFetch) (*domain.ResultOfFetch, error) {
 	result := new(domain.ResultOfFetch)
-	err := d.client.GetResult("debot.fetch", pOF, result)
+	err := d.client.GetResultContext(ctx, "debot.fetch", pOF, result)
 	return result, err
 }
 
 // Execute - Executes debot action.
 // Calls debot engine referenced by debot handle to execute input action. Calls Debot Browser Callbacks if needed.
 func (d *debot) Execute(pOE *domain.ParamsOfExecute) error {
-	_, err := d.client.GetResponse("debot.execute", pOE)
+	return d.ExecuteCtx(context.Background(), pOE)
+}
+
+// ExecuteCtx - Execute bounded by ctx.
+func (d *debot) ExecuteCtx(ctx context.Context, pOE *domain.ParamsOfExecute) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.execute", pOE)
 	return err
 }
 
 // Send - Sends message to Debot.
 // Used by Debot Browser to send response on Dinterface call or from other Debots.
 func (d *debot) Send(pOS *domain.ParamsOfSend) error {
-	_, err := d.client.GetResponse("debot.send", pOS)
+	return d.SendCtx(context.Background(), pOS)
+}
+
+// SendCtx - Send bounded by ctx.
+func (d *debot) SendCtx(ctx context.Context, pOS *domain.ParamsOfSend) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.send", pOS)
 	return err
 }
 
 // Remove - Destroys debot handle.
 // Removes handle from Client Context and drops debot engine referenced by that handle.
 func (d *debot) Remove(pOR *domain.ParamsOfRemove) error {
-	_, err := d.client.GetResponse("debot.remove", pOR)
+	return d.RemoveCtx(context.Background(), pOR)
+}
+
+// RemoveCtx - Remove bounded by ctx.
+func (d *debot) RemoveCtx(ctx context.Context, pOR *domain.ParamsOfRemove) error {
+	_, err := d.client.GetResponseContext(ctx, "debot.remove", pOR)
 	return err
 }
@@ -1,15 +1,20 @@
 package debot
 
 import (
+	"context"
+	"testing"
+	"time"
+
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestDebot(t *testing.T) {
 
-	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	config, err := domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientConn, err := client.NewClientGateway(config)
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
@@ -20,3 +25,31 @@ func TestDebot(t *testing.T) {
 	}
 	defer debotUC.client.Destroy()
 }
+
+func TestDebotFake(t *testing.T) {
+	fake := clientmock.NewFake()
+	defer fake.Destroy()
+
+	debotUC := debot{
+		config: domain.ClientConfig{},
+		client: fake,
+	}
+
+	t.Run("TestBrowserLifetime", func(t *testing.T) {
+		fake.On("debot.init").Respond(domain.RegisteredDebot{DebotHandle: 7, DebotAbi: "{}"}).KeepOpen()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		registered, err := debotUC.InitCtx(ctx, &domain.ParamsOfInit{Address: "0:11"}, nil)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 7, registered.DebotHandle)
+		assert.Equal(t, 1, len(debotUC.client.AppObjects().Registrations()))
+
+		// The browser is served until the context of Init is done.
+		cancel()
+		deadline := time.Now().Add(time.Second)
+		for len(debotUC.client.AppObjects().Registrations()) != 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, 0, len(debotUC.client.AppObjects().Registrations()))
+	})
+}
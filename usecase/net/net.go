@@ -1,7 +1,10 @@
 package net
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+
 	"github.com/move-ton/ever-client-go/domain"
 )
 
@@ -23,42 +26,72 @@ func NewNet(
 
 // Query - Performs DAppServer GraphQL query.
 func (n *net) Query(pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
+	return n.QueryCtx(context.Background(), pOQ)
+}
+
+// QueryCtx - Query bounded by ctx.
+func (n *net) QueryCtx(ctx context.Context, pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
 	result := new(domain.ResultOfQuery)
-	err := n.client.GetResult("net.query", pOQ, result)
+	err := n.client.GetResultContext(ctx, "net.query", pOQ, result)
 	return result, err
 }
 
 // BatchQuery - Performs multiple queries per single fetch.
 func (n *net) BatchQuery(pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
+	return n.BatchQueryCtx(context.Background(), pOBQ)
+}
+
+// BatchQueryCtx - BatchQuery bounded by ctx.
+func (n *net) BatchQueryCtx(ctx context.Context, pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
 	result := new(domain.ResultOfBatchQuery)
-	err := n.client.GetResult("net.batch_query", pOBQ, result)
+	err := n.client.GetResultContext(ctx, "net.batch_query", pOBQ, result)
 	return result, err
 }
 
 // QueryCollection - Queries collection data.
 func (n *net) QueryCollection(pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
+	return n.QueryCollectionCtx(context.Background(), pOQC)
+}
+
+// QueryCollectionCtx - QueryCollection bounded by ctx.
+func (n *net) QueryCollectionCtx(ctx context.Context, pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
 	result := new(domain.ResultOfQueryCollection)
-	err := n.client.GetResult("net.query_collection", pOQC, result)
+	err := n.client.GetResultContext(ctx, "net.query_collection", pOQC, result)
 	return result, err
 }
 
 // AggregateCollection - Aggregates collection data.
 func (n *net) AggregateCollection(pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
+	return n.AggregateCollectionCtx(context.Background(), pOAC)
+}
+
+// AggregateCollectionCtx - AggregateCollection bounded by ctx.
+func (n *net) AggregateCollectionCtx(ctx context.Context, pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
 	result := new(domain.ResultOfAggregateCollection)
-	err := n.client.GetResult("net.aggregate_collection", pOAC, result)
+	err := n.client.GetResultContext(ctx, "net.aggregate_collection", pOAC, result)
 	return result, err
 }
 
 // WaitForCollection - Returns an object that fulfills the conditions or waits for its appearance.
 func (n *net) WaitForCollection(pOWFC *domain.ParamsOfWaitForCollection) (*domain.ResultOfWaitForCollection, error) {
+	return n.WaitForCollectionCtx(context.Background(), pOWFC)
+}
+
+// WaitForCollectionCtx - WaitForCollection bounded by ctx.
+func (n *net) WaitForCollectionCtx(ctx context.Context, pOWFC *domain.ParamsOfWaitForCollection) (*domain.ResultOfWaitForCollection, error) {
 	result := new(domain.ResultOfWaitForCollection)
-	err := n.client.GetResult("net.wait_for_collection", pOWFC, result)
+	err := n.client.GetResultContext(ctx, "net.wait_for_collection", pOWFC, result)
 	return result, err
 }
 
 // Unsubscribe - Cancels a subscription.
 func (n *net) Unsubscribe(rOSC *domain.ResultOfSubscribeCollection) error {
-	_, err := n.client.GetResponse("net.unsubscribe", rOSC)
+	return n.UnsubscribeCtx(context.Background(), rOSC)
+}
+
+// UnsubscribeCtx - Unsubscribe bounded by ctx.
+func (n *net) UnsubscribeCtx(ctx context.Context, rOSC *domain.ResultOfSubscribeCollection) error {
+	_, err := n.client.GetResponseContext(ctx, "net.unsubscribe", rOSC)
 	return err
 }
 
@@ -69,109 +102,153 @@ func (n *net) Unsubscribe(rOSC *domain.ResultOfSubscribeCollection) error {
 // The subscription is a persistent communication channel between client and Free TON Network. All changes in the blockchain
 // will be reflected in realtime. Changes means inserts and updates of the blockchain entities.
 func (n *net) SubscribeCollection(pOSC *domain.ParamsOfSubscribeCollection) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
+	return n.SubscribeCollectionCtx(context.Background(), pOSC)
+}
+
+// SubscribeCollectionCtx - SubscribeCollection bounded by ctx.
+// The returned channel is closed when ctx is done; call Unsubscribe to release the subscription in the library.
+func (n *net) SubscribeCollectionCtx(ctx context.Context, pOSC *domain.ParamsOfSubscribeCollection) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
 	result := new(domain.ResultOfSubscribeCollection)
-	responses, err := n.client.Request("net.subscribe_collection", pOSC)
+	stream, err := domain.OpenStream(ctx, n.client, "net.subscribe_collection", pOSC)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	data := <-responses
-	if data.Error != nil {
-		return nil, nil, data.Error
-	}
-	if err := json.Unmarshal(data.Data, result); err != nil {
+	if err := stream.Wait(ctx, result, nil); err != nil {
+		stream.Close()
 		return nil, nil, err
 	}
 
-	respInBuffer := domain.DynBufferForResponses(responses)
-	chanResult := make(chan json.RawMessage, 1)
-	go func() {
-		var body struct {
-			Result json.RawMessage `json:"result"`
-		}
-		for r := range respInBuffer {
-			if err := json.Unmarshal(r.Data, &body); err != nil {
-				panic(err)
-			}
-			chanResult <- body.Result
-		}
-		close(chanResult)
-	}()
-
-	return chanResult, result, nil
+	return n.notifications(ctx, stream), result, nil
 }
 
 // Subscribe - Creates a subscription.
 // The subscription is a persistent communication channel between client and Everscale Network.
 func (n *net) Subscribe(pOS *domain.ParamsOfSubscribe) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
+	return n.SubscribeCtx(context.Background(), pOS)
+}
+
+// SubscribeCtx - Subscribe bounded by ctx.
+// The returned channel is closed when ctx is done; call Unsubscribe to release the subscription in the library.
+func (n *net) SubscribeCtx(ctx context.Context, pOS *domain.ParamsOfSubscribe) (<-chan json.RawMessage, *domain.ResultOfSubscribeCollection, error) {
 	result := new(domain.ResultOfSubscribeCollection)
-	responses, err := n.client.Request("net.subscribe", pOS)
+	stream, err := domain.OpenStream(ctx, n.client, "net.subscribe", pOS)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	data := <-responses
-	if data.Error != nil {
-		return nil, nil, data.Error
-	}
-	if err := json.Unmarshal(data.Data, result); err != nil {
+	if err := stream.Wait(ctx, result, nil); err != nil {
+		stream.Close()
 		return nil, nil, err
 	}
 
-	respInBuffer := domain.DynBufferForResponses(responses)
+	return n.notifications(ctx, stream), result, nil
+}
+
+// notifications passes the results of the subscription events of stream to the returned channel until the stream ends.
+// Errors of the subscription are passed to the error handler of the gateway.
+func (n *net) notifications(ctx context.Context, stream *domain.Stream) <-chan json.RawMessage {
 	chanResult := make(chan json.RawMessage, 1)
 	go func() {
-		var body struct {
-			Result json.RawMessage `json:"result"`
-		}
-		for r := range respInBuffer {
-			if err := json.Unmarshal(r.Data, &body); err != nil {
-				panic(err)
+		defer close(chanResult)
+		defer stream.Close()
+		for {
+			frame, err := stream.Next(ctx)
+			if err == io.EOF || ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				n.client.ReportError(err)
+				continue
+			}
+			event, ok := frame.(domain.Event)
+			if !ok {
+				continue
+			}
+			// A new body for every event: json.RawMessage reuses its buffer, which the receiver may still hold.
+			var body struct {
+				Result json.RawMessage `json:"result"`
+			}
+			if err := json.Unmarshal(event.Data, &body); err != nil {
+				n.client.ReportError(err)
+				continue
+			}
+			select {
+			case chanResult <- body.Result:
+			case <-ctx.Done():
+				// Nobody reads any more; Close cancels the request and drains the stream.
+				return
 			}
-			chanResult <- body.Result
 		}
-		close(chanResult)
 	}()
 
-	return chanResult, result, nil
+	return chanResult
 }
 
 // Suspend - Suspends network module to stop any network activity.
 func (n *net) Suspend() error {
-	_, err := n.client.GetResponse("net.suspend", nil)
+	return n.SuspendCtx(context.Background())
+}
+
+// SuspendCtx - Suspend bounded by ctx.
+func (n *net) SuspendCtx(ctx context.Context) error {
+	_, err := n.client.GetResponseContext(ctx, "net.suspend", nil)
 	return err
 }
 
 // Resume - Resumes network module to enable network activity.
 func (n *net) Resume() error {
-	_, err := n.client.GetResponse("net.resume", nil)
+	return n.ResumeCtx(context.Background())
+}
+
+// ResumeCtx - Resume bounded by ctx.
+func (n *net) ResumeCtx(ctx context.Context) error {
+	_, err := n.client.GetResponseContext(ctx, "net.resume", nil)
 	return err
 }
 
 // FindLastShardBlock - Returns ID of the last block in a specified account shard.
 func (n *net) FindLastShardBlock(pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
+	return n.FindLastShardBlockCtx(context.Background(), pOFLSB)
+}
+
+// FindLastShardBlockCtx - FindLastShardBlock bounded by ctx.
+func (n *net) FindLastShardBlockCtx(ctx context.Context, pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
 	result := new(domain.ResultOfFindLastShardBlock)
-	err := n.client.GetResult("net.find_last_shard_block", pOFLSB, result)
+	err := n.client.GetResultContext(ctx, "net.find_last_shard_block", pOFLSB, result)
 	return result, err
 }
 
 // FetchEndpoints - Requests the list of alternative endpoints from server.
 func (n *net) FetchEndpoints() (*domain.EndpointsSet, error) {
+	return n.FetchEndpointsCtx(context.Background())
+}
+
+// FetchEndpointsCtx - FetchEndpoints bounded by ctx.
+func (n *net) FetchEndpointsCtx(ctx context.Context) (*domain.EndpointsSet, error) {
 	result := new(domain.EndpointsSet)
-	err := n.client.GetResult("net.fetch_endpoints", nil, result)
+	err := n.client.GetResultContext(ctx, "net.fetch_endpoints", nil, result)
 	return result, err
 }
 
 // SetEndpoints - Sets the list of endpoints to use on reinit.
 func (n *net) SetEndpoints(eS *domain.EndpointsSet) error {
-	_, err := n.client.GetResponse("net.set_endpoints", eS)
+	return n.SetEndpointsCtx(context.Background(), eS)
+}
+
+// SetEndpointsCtx - SetEndpoints bounded by ctx.
+func (n *net) SetEndpointsCtx(ctx context.Context, eS *domain.EndpointsSet) error {
+	_, err := n.client.GetResponseContext(ctx, "net.set_endpoints", eS)
 	return err
 }
 
 // GetEndpoints - Requests the list of alternative endpoints from server.
 func (n *net) GetEndpoints() (*domain.ResultOfGetEndpoints, error) {
+	return n.GetEndpointsCtx(context.Background())
+}
+
+// GetEndpointsCtx - GetEndpoints bounded by ctx.
+func (n *net) GetEndpointsCtx(ctx context.Context) (*domain.ResultOfGetEndpoints, error) {
 	result := new(domain.ResultOfGetEndpoints)
-	err := n.client.GetResult("net.get_endpoints", nil, result)
+	err := n.client.GetResultContext(ctx, "net.get_endpoints", nil, result)
 	return result, err
 }
 
@@ -181,8 +258,13 @@ func (n *net) GetEndpoints() (*domain.ResultOfGetEndpoints, error) {
 // in the opensource version of DApp Server (and will not be supported) as well as in EVER OS SE
 // (will be supported in SE in future), but is always accessible via EVER OS Devnet/Mainnet Clouds
 func (n *net) QueryCounterparties(pOQC *domain.ParamsOfQueryCounterparties) (*domain.ResultOfQueryCollection, error) {
+	return n.QueryCounterpartiesCtx(context.Background(), pOQC)
+}
+
+// QueryCounterpartiesCtx - QueryCounterparties bounded by ctx.
+func (n *net) QueryCounterpartiesCtx(ctx context.Context, pOQC *domain.ParamsOfQueryCounterparties) (*domain.ResultOfQueryCollection, error) {
 	result := new(domain.ResultOfQueryCollection)
-	err := n.client.GetResult("net.query_counterparties", pOQC, result)
+	err := n.client.GetResultContext(ctx, "net.query_counterparties", pOQC, result)
 	return result, err
 }
 
@@ -196,8 +278,13 @@ func (n *net) QueryCounterparties(pOQC *domain.ParamsOfQueryCounterparties) (*do
 // application have to continue retrieval
 // for missing messages if it requires.
 func (n *net) QueryTransactionTree(pOQTT *domain.ParamsOfQueryTransactionTree) (*domain.ResultOfQueryTransactionTree, error) {
+	return n.QueryTransactionTreeCtx(context.Background(), pOQTT)
+}
+
+// QueryTransactionTreeCtx - QueryTransactionTree bounded by ctx.
+func (n *net) QueryTransactionTreeCtx(ctx context.Context, pOQTT *domain.ParamsOfQueryTransactionTree) (*domain.ResultOfQueryTransactionTree, error) {
 	result := new(domain.ResultOfQueryTransactionTree)
-	err := n.client.GetResult("net.query_transaction_tree", pOQTT, result)
+	err := n.client.GetResultContext(ctx, "net.query_transaction_tree", pOQTT, result)
 	return result, err
 }
 
@@ -205,8 +292,13 @@ func (n *net) QueryTransactionTree(pOQTT *domain.ParamsOfQueryTransactionTree) (
 // Block iterator uses robust iteration methods that guaranties that every block in the specified range isn't missed or
 // iterated twice.
 func (n *net) CreateBlockIterator(iterator *domain.ParamsOfCreateBlockIterator) (*domain.RegisteredIterator, error) {
+	return n.CreateBlockIteratorCtx(context.Background(), iterator)
+}
+
+// CreateBlockIteratorCtx - CreateBlockIterator bounded by ctx.
+func (n *net) CreateBlockIteratorCtx(ctx context.Context, iterator *domain.ParamsOfCreateBlockIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.create_block_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.create_block_iterator", iterator, result)
 	return result, err
 }
 
@@ -214,8 +306,13 @@ func (n *net) CreateBlockIterator(iterator *domain.ParamsOfCreateBlockIterator)
 // The iterator stays exactly at the same position where the resume_state was catched.
 // Application should call the remove_iterator when iterator is no longer required.
 func (n *net) ResumeBlockIterator(iterator *domain.ParamsOfResumeBlockIterator) (*domain.RegisteredIterator, error) {
+	return n.ResumeBlockIteratorCtx(context.Background(), iterator)
+}
+
+// ResumeBlockIteratorCtx - ResumeBlockIterator bounded by ctx.
+func (n *net) ResumeBlockIteratorCtx(ctx context.Context, iterator *domain.ParamsOfResumeBlockIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.resume_block_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.resume_block_iterator", iterator, result)
 	return result, err
 }
 
@@ -223,8 +320,13 @@ func (n *net) ResumeBlockIterator(iterator *domain.ParamsOfResumeBlockIterator)
 // Transaction iterator uses robust iteration methods that guaranty that every transaction in the specified range isn't
 // missed or iterated twice.
 func (n *net) CreateTransactionIterator(iterator *domain.ParamsOfCreateTransactionIterator) (*domain.RegisteredIterator, error) {
+	return n.CreateTransactionIteratorCtx(context.Background(), iterator)
+}
+
+// CreateTransactionIteratorCtx - CreateTransactionIterator bounded by ctx.
+func (n *net) CreateTransactionIteratorCtx(ctx context.Context, iterator *domain.ParamsOfCreateTransactionIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.create_transaction_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.create_transaction_iterator", iterator, result)
 	return result, err
 }
 
@@ -234,8 +336,13 @@ func (n *net) CreateTransactionIterator(iterator *domain.ParamsOfCreateTransacti
 // then the application must pass the account filter again in accounts_filter parameter.
 // Application should call the remove_iterator when iterator is no longer required.
 func (n *net) ResumeTransactionIterator(iterator *domain.ParamsOfResumeTransactionIterator) (*domain.RegisteredIterator, error) {
+	return n.ResumeTransactionIteratorCtx(context.Background(), iterator)
+}
+
+// ResumeTransactionIteratorCtx - ResumeTransactionIterator bounded by ctx.
+func (n *net) ResumeTransactionIteratorCtx(ctx context.Context, iterator *domain.ParamsOfResumeTransactionIterator) (*domain.RegisteredIterator, error) {
 	result := new(domain.RegisteredIterator)
-	err := n.client.GetResult("net.resume_transaction_iterator", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.resume_transaction_iterator", iterator, result)
 	return result, err
 }
 
@@ -249,8 +356,13 @@ func (n *net) ResumeTransactionIterator(iterator *domain.ParamsOfResumeTransacti
 // The structure of the items returned depends on the iterator used. See the description to the appropriated iterator
 // creation function.
 func (n *net) IteratorNext(iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
+	return n.IteratorNextCtx(context.Background(), iterator)
+}
+
+// IteratorNextCtx - IteratorNext bounded by ctx.
+func (n *net) IteratorNextCtx(ctx context.Context, iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
 	result := new(domain.ResultOfIteratorNext)
-	err := n.client.GetResult("net.iterator_next", iterator, result)
+	err := n.client.GetResultContext(ctx, "net.iterator_next", iterator, result)
 	return result, err
 }
 
@@ -258,6 +370,11 @@ func (n *net) IteratorNext(iterator *domain.ParamsOfIteratorNext) (*domain.Resul
 // Frees all resources allocated in library to serve iterator.
 // Application always should call the remove_iterator when iterator is no longer required.
 func (n *net) RemoveIterator(iterator *domain.RegisteredIterator) error {
-	_, err := n.client.GetResponse("net.remove_iterator", iterator)
+	return n.RemoveIteratorCtx(context.Background(), iterator)
+}
+
+// RemoveIteratorCtx - RemoveIterator bounded by ctx.
+func (n *net) RemoveIteratorCtx(ctx context.Context, iterator *domain.RegisteredIterator) error {
+	_, err := n.client.GetResponseContext(ctx, "net.remove_iterator", iterator)
 	return err
 }
@@ -1,9 +1,12 @@
 package net
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/move-ton/ever-client-go/util"
+	"runtime"
 	"strconv"
 	"sync"
 	"testing"
@@ -11,13 +14,22 @@ import (
 
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
+	"github.com/move-ton/ever-client-go/gateway/replay"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNet(t *testing.T) {
 
-	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
-	clientConn, err := client.NewClientGateway(config)
+	// The suite replays testdata/net.json if it was recorded with replay.RecordEnv set, and runs on devnet otherwise.
+	// Calls are matched by method, as the params change from run to run.
+	config, configErr := domain.Networks.Config("devnet")
+	clientConn, err := replay.Open("testdata/net.json", func() (domain.ClientGateway, error) {
+		if configErr != nil {
+			return nil, configErr
+		}
+		return client.NewClientGateway(config)
+	}, replay.WithMatcher(replay.MatchMethod))
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
 
@@ -27,65 +39,6 @@ func TestNet(t *testing.T) {
 	}
 	defer netUC.client.Destroy()
 
-	t.Run("TestQueryCollection", func(t *testing.T) {
-		queryParams := &domain.ParamsOfQueryCollection{Collection: "blocks_signatures", Result: "id", Limit: util.IntToPointerInt(1)}
-		result, err := netUC.QueryCollection(queryParams)
-		assert.Equal(t, nil, err)
-		assert.Greater(t, len(result.Result), 0)
-
-		queryParams = &domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id, balance", Limit: util.IntToPointerInt(5)}
-		result, err = netUC.QueryCollection(queryParams)
-		assert.Equal(t, nil, err)
-		assert.Equal(t, 5, len(result.Result))
-
-		queryParams = &domain.ParamsOfQueryCollection{Collection: "messages", Filter: json.RawMessage(`{"created_at":{"gt":1562342740}}`), Result: "body, created_at", Order: []*domain.OrderBy{{Path: "created_at", Direction: domain.SortDirectionASC}}, Limit: util.IntToPointerInt(10)}
-		result, err = netUC.QueryCollection(queryParams)
-		assert.Equal(t, nil, err)
-
-		type resultStruct struct {
-			Body      string `json:"body"`
-			CreatedAt int    `json:"created_at"`
-		}
-
-		res := &resultStruct{}
-		err = json.Unmarshal(result.Result[0], &res)
-		assert.Equal(t, nil, err)
-		assert.Greater(t, res.CreatedAt, 1562342740)
-
-		_, err = netUC.QueryCollection(&domain.ParamsOfQueryCollection{Collection: "messages"})
-		assert.NotEqual(t, nil, err)
-	})
-
-	t.Run("TestWaitCollection", func(t *testing.T) {
-		nowTime := int(time.Now().Unix())
-		queryParams := &domain.ParamsOfWaitForCollection{
-			Collection: "transactions",
-			Filter:     json.RawMessage(fmt.Sprintf(`{"now":{"gt":%d}}`, nowTime)),
-			Result:     "id,now",
-		}
-		result, err := netUC.WaitForCollection(queryParams)
-		assert.Equal(t, nil, err)
-
-		type resultStruct struct {
-			ID  string `json:"id"`
-			Now int    `json:"now"`
-		}
-
-		res := &resultStruct{}
-		err = json.Unmarshal(result.Result, res)
-		assert.Equal(t, nil, err)
-		assert.Greater(t, res.Now, nowTime)
-
-		queryParams = &domain.ParamsOfWaitForCollection{
-			Collection: "transactions",
-			Result:     "",
-			Timeout:    util.IntToPointerInt(1),
-		}
-		result, err = netUC.WaitForCollection(queryParams)
-		assert.NotEqual(t, nil, err)
-		assert.Equal(t, json.RawMessage(nil), result.Result)
-	})
-
 	t.Run("TestSubscribeCollection", func(t *testing.T) {
 
 		// # Prepare query
@@ -172,3 +125,100 @@ func TestNet(t *testing.T) {
 		assert.Greater(t, resToInt, 0)
 	})
 }
+
+func TestNetFake(t *testing.T) {
+	fake := clientmock.NewFake()
+	defer fake.Destroy()
+
+	netUC := net{
+		config: domain.ClientConfig{},
+		client: fake,
+	}
+
+	t.Run("TestQueryCollection", func(t *testing.T) {
+		params := &domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id balance", Limit: util.IntToPointerInt(1)}
+		fake.On("net.query_collection").WithParams(params).
+			Respond(domain.ResultOfQueryCollection{Result: []json.RawMessage{json.RawMessage(`{"id":"0:01","balance":"0x1"}`)}})
+
+		result, err := netUC.QueryCollection(params)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(result.Result))
+		assert.Equal(t, `{"id":"0:01","balance":"0x1"}`, string(result.Result[0]))
+	})
+
+	t.Run("TestSubscribeCollection", func(t *testing.T) {
+		fake.On("net.subscribe_collection").
+			Respond(domain.ResultOfSubscribeCollection{Handle: 3}).
+			StreamEvents(map[string]interface{}{"result": map[string]string{"id": "m1"}}, map[string]interface{}{"result": map[string]string{"id": "m2"}}).
+			KeepOpen().
+			Once()
+		fake.On("net.unsubscribe").WithParams(&domain.ResultOfSubscribeCollection{Handle: 3}).Respond(struct{}{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		messages, handle, err := netUC.SubscribeCollectionCtx(ctx, &domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 3, handle.Handle)
+		assert.Equal(t, `{"id":"m1"}`, string(<-messages))
+		assert.Equal(t, `{"id":"m2"}`, string(<-messages))
+
+		assert.Equal(t, nil, netUC.Unsubscribe(handle))
+		cancel()
+		for range messages {
+		}
+	})
+
+	t.Run("TestSubscribeCanceledUnread", func(t *testing.T) {
+		fake.On("net.subscribe").
+			Respond(domain.ResultOfSubscribeCollection{Handle: 4}).
+			StreamEvents(map[string]interface{}{"result": 1}, map[string]interface{}{"result": 2}, map[string]interface{}{"result": 3}).
+			KeepOpen()
+
+		before := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(context.Background())
+		messages, _, err := netUC.SubscribeCtx(ctx, &domain.ParamsOfSubscribe{Subscription: "subscription{accounts{id}}"})
+		assert.Equal(t, nil, err)
+		// Let the first event fill the channel and the second one wait for a reader.
+		for len(messages) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		// The notifications and the stream end although nobody reads them.
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		assert.True(t, runtime.NumGoroutine() <= before)
+	})
+
+	t.Run("TestSubscribeErrorReported", func(t *testing.T) {
+		reported := make(chan error, 1)
+		fake.ErrorHandler = func(err error) { reported <- err }
+		defer func() { fake.ErrorHandler = nil }()
+		fake.On("net.subscribe_collection").
+			Respond(domain.ResultOfSubscribeCollection{Handle: 5}).
+			StreamEvents(map[string]interface{}{"result": map[string]string{"id": "m1"}}).
+			Fail(domain.ClientError{Code: domain.NetErrorWebsocketDisconnected, Message: "Websocket disconnected"}).
+			StreamEvents(map[string]interface{}{"result": map[string]string{"id": "m2"}}).
+			KeepOpen().
+			Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		messages, _, err := netUC.SubscribeCollectionCtx(ctx, &domain.ParamsOfSubscribeCollection{Collection: "messages", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"id":"m1"}`, string(<-messages))
+		// The error goes to the error handler of the gateway and the subscription goes on.
+		assert.Equal(t, `{"id":"m2"}`, string(<-messages))
+		select {
+		case err := <-reported:
+			assert.True(t, errors.Is(err, domain.ErrWebsocketDisconnected))
+		case <-time.After(time.Second):
+			t.Error("the subscription error was not reported")
+		}
+		cancel()
+		for range messages {
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package net
+
+import (
+	"context"
+
+	"github.com/move-ton/ever-client-go/domain"
+)
+
+// retryingNet - NetUseCase which retries the idempotent read calls of the wrapped one.
+type retryingNet struct {
+	domain.NetUseCase
+	policy domain.RetryPolicy
+}
+
+// NewRetryingNet wraps net so that Query, BatchQuery, QueryCollection, AggregateCollection, FindLastShardBlock
+// and IteratorNext are retried according to policy. Other calls, e.g. subscriptions, are passed through once;
+// message processing is a separate use case and is never retried.
+func NewRetryingNet(net domain.NetUseCase, policy domain.RetryPolicy) domain.NetUseCase {
+	return &retryingNet{NetUseCase: net, policy: policy}
+}
+
+// Query - QueryCtx of the wrapped use case, retried.
+func (r *retryingNet) Query(pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
+	return r.QueryCtx(context.Background(), pOQ)
+}
+
+// QueryCtx - QueryCtx of the wrapped use case, retried.
+func (r *retryingNet) QueryCtx(ctx context.Context, pOQ *domain.ParamsOfQuery) (*domain.ResultOfQuery, error) {
+	var result *domain.ResultOfQuery
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.QueryCtx(ctx, pOQ)
+		return err
+	})
+	return result, err
+}
+
+// BatchQuery - BatchQueryCtx of the wrapped use case, retried.
+func (r *retryingNet) BatchQuery(pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
+	return r.BatchQueryCtx(context.Background(), pOBQ)
+}
+
+// BatchQueryCtx - BatchQueryCtx of the wrapped use case, retried.
+func (r *retryingNet) BatchQueryCtx(ctx context.Context, pOBQ *domain.ParamsOfBatchQuery) (*domain.ResultOfBatchQuery, error) {
+	var result *domain.ResultOfBatchQuery
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.BatchQueryCtx(ctx, pOBQ)
+		return err
+	})
+	return result, err
+}
+
+// QueryCollection - QueryCollectionCtx of the wrapped use case, retried.
+func (r *retryingNet) QueryCollection(pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
+	return r.QueryCollectionCtx(context.Background(), pOQC)
+}
+
+// QueryCollectionCtx - QueryCollectionCtx of the wrapped use case, retried.
+func (r *retryingNet) QueryCollectionCtx(ctx context.Context, pOQC *domain.ParamsOfQueryCollection) (*domain.ResultOfQueryCollection, error) {
+	var result *domain.ResultOfQueryCollection
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.QueryCollectionCtx(ctx, pOQC)
+		return err
+	})
+	return result, err
+}
+
+// AggregateCollection - AggregateCollectionCtx of the wrapped use case, retried.
+func (r *retryingNet) AggregateCollection(pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
+	return r.AggregateCollectionCtx(context.Background(), pOAC)
+}
+
+// AggregateCollectionCtx - AggregateCollectionCtx of the wrapped use case, retried.
+func (r *retryingNet) AggregateCollectionCtx(ctx context.Context, pOAC *domain.ParamsOfAggregateCollection) (*domain.ResultOfAggregateCollection, error) {
+	var result *domain.ResultOfAggregateCollection
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.AggregateCollectionCtx(ctx, pOAC)
+		return err
+	})
+	return result, err
+}
+
+// FindLastShardBlock - FindLastShardBlockCtx of the wrapped use case, retried.
+func (r *retryingNet) FindLastShardBlock(pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
+	return r.FindLastShardBlockCtx(context.Background(), pOFLSB)
+}
+
+// FindLastShardBlockCtx - FindLastShardBlockCtx of the wrapped use case, retried.
+func (r *retryingNet) FindLastShardBlockCtx(ctx context.Context, pOFLSB *domain.ParamsOfFindLastShardBlock) (*domain.ResultOfFindLastShardBlock, error) {
+	var result *domain.ResultOfFindLastShardBlock
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.FindLastShardBlockCtx(ctx, pOFLSB)
+		return err
+	})
+	return result, err
+}
+
+// IteratorNext - IteratorNextCtx of the wrapped use case, retried.
+func (r *retryingNet) IteratorNext(iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
+	return r.IteratorNextCtx(context.Background(), iterator)
+}
+
+// IteratorNextCtx - IteratorNextCtx of the wrapped use case, retried.
+func (r *retryingNet) IteratorNextCtx(ctx context.Context, iterator *domain.ParamsOfIteratorNext) (*domain.ResultOfIteratorNext, error) {
+	var result *domain.ResultOfIteratorNext
+	err := r.policy.Do(ctx, func(ctx context.Context) (err error) {
+		result, err = r.NetUseCase.IteratorNextCtx(ctx, iterator)
+		return err
+	})
+	return result, err
+}
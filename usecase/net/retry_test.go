@@ -0,0 +1,55 @@
+package net
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/move-ton/ever-client-go/domain"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryingNet(t *testing.T) {
+	config := domain.NewDefaultConfig("", []string{"http://localhost"}, "")
+	policy := domain.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	t.Run("TestRetried", func(t *testing.T) {
+		fake := clientmock.NewFake()
+		defer fake.Destroy()
+		fake.On("net.query_collection").Fail(domain.ClientError{Code: domain.NetErrorQueryFailed, Message: "Query failed"}).Once()
+		fake.On("net.query_collection").Respond(domain.ResultOfQueryCollection{Result: []json.RawMessage{json.RawMessage(`{"id":"a"}`)}})
+
+		retries := 0
+		policy := policy
+		policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+			retries++
+		}
+		result, err := NewRetryingNet(NewNet(config, fake), policy).QueryCollection(&domain.ParamsOfQueryCollection{Collection: "accounts", Result: "id"})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(result.Result))
+		assert.Equal(t, 1, retries)
+		assert.Equal(t, 2, len(fake.CallsOf("net.query_collection")))
+	})
+
+	t.Run("TestNotRetryable", func(t *testing.T) {
+		fake := clientmock.NewFake()
+		defer fake.Destroy()
+		fake.On("net.query").Fail(domain.ClientError{Code: domain.ClientErrorInvalidParams, Message: "Invalid params"})
+
+		_, err := NewRetryingNet(NewNet(config, fake), policy).Query(&domain.ParamsOfQuery{Query: "{info{version}}"})
+		assert.True(t, errors.Is(err, domain.ErrInvalidParams))
+		assert.Equal(t, 1, len(fake.CallsOf("net.query")))
+	})
+
+	t.Run("TestAttemptsExhausted", func(t *testing.T) {
+		fake := clientmock.NewFake()
+		defer fake.Destroy()
+		fake.On("net.batch_query").Fail(domain.ClientError{Code: domain.NetErrorWebsocketDisconnected, Message: "Websocket disconnected"})
+
+		_, err := NewRetryingNet(NewNet(config, fake), policy).BatchQuery(&domain.ParamsOfBatchQuery{})
+		assert.True(t, errors.Is(err, domain.ErrWebsocketDisconnected))
+		assert.Equal(t, 3, len(fake.CallsOf("net.batch_query")))
+	})
+}
@@ -1,6 +1,7 @@
 package processing
 
 import (
+	"context"
 	"errors"
 
 	"github.com/move-ton/ever-client-go/domain"
@@ -27,21 +28,21 @@ func NewProcessing(
 // generated shard block of the destination account before
 // the message was sent. It will be required later for message processing.
 func (p *processing) SendMessage(pOSM *domain.ParamsOfSendMessage, callback domain.EventCallback) (*domain.ResultOfSendMessage, error) {
+	return p.SendMessageCtx(context.Background(), pOSM, callback)
+}
+
+// SendMessageCtx - SendMessage bounded by ctx.
+func (p *processing) SendMessageCtx(ctx context.Context, pOSM *domain.ParamsOfSendMessage, callback domain.EventCallback) (*domain.ResultOfSendMessage, error) {
 	if pOSM.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.send_message", pOSM)
-	if err != nil {
-		return nil, err
-	}
-
-	if pOSM.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+	result := &domain.ResultOfSendMessage{}
+	if err := p.process(ctx, "processing.SendMessage", "processing.send_message", pOSM, result, callback); err != nil {
+		return result, err
 	}
 
-	result := &domain.ResultOfSendMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return result, nil
 }
 
 // WaitForTransaction - Performs monitoring of the network for the result transaction of the external inbound message processing.
@@ -50,21 +51,21 @@ func (p *processing) SendMessage(pOSM *domain.ParamsOfSendMessage, callback doma
 // Note, that presence of the abi parameter is critical for ABI compliant contracts.
 // Message processing uses drastically different strategy for processing message for contracts which ABI includes "expire" header.
 func (p *processing) WaitForTransaction(pOWFT *domain.ParamsOfWaitForTransaction, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
+	return p.WaitForTransactionCtx(context.Background(), pOWFT, callback)
+}
+
+// WaitForTransactionCtx - WaitForTransaction bounded by ctx.
+func (p *processing) WaitForTransactionCtx(ctx context.Context, pOWFT *domain.ParamsOfWaitForTransaction, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
 	if pOWFT.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.wait_for_transaction", pOWFT)
-	if err != nil {
-		return nil, err
-	}
-
-	if pOWFT.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+	result := &domain.ResultOfProcessMessage{}
+	if err := p.process(ctx, "processing.WaitForTransaction", "processing.wait_for_transaction", pOWFT, result, callback); err != nil {
+		return result, err
 	}
 
-	result := &domain.ResultOfProcessMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return result, nil
 }
 
 // ProcessMessage - Creates message, sends it to the network and monitors its processing.
@@ -78,19 +79,44 @@ func (p *processing) WaitForTransaction(pOWFT *domain.ParamsOfWaitForTransaction
 // If contract's ABI does not include "expire" header then, if no transaction is found within
 // the network timeout (see config parameter ), exits with error.
 func (p *processing) ProcessMessage(pOPM *domain.ParamsOfProcessMessage, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
+	return p.ProcessMessageCtx(context.Background(), pOPM, callback)
+}
+
+// ProcessMessageCtx - ProcessMessage bounded by ctx.
+func (p *processing) ProcessMessageCtx(ctx context.Context, pOPM *domain.ParamsOfProcessMessage, callback domain.EventCallback) (*domain.ResultOfProcessMessage, error) {
 	if pOPM.SendEvents && callback == nil {
 		return nil, errors.New("Don't find callback")
 	}
 
-	responses, err := p.client.Request("processing.process_message", pOPM)
-	if err != nil {
-		return nil, err
+	result := &domain.ResultOfProcessMessage{}
+	if err := p.process(ctx, "processing.ProcessMessage", "processing.process_message", pOPM, result, callback); err != nil {
+		return result, err
 	}
 
-	if pOPM.SendEvents {
-		responses = domain.DynBufferForResponses(responses)
+	return result, nil
+}
+
+// process calls method and waits for its result, passing the events to callback. The call is traced
+// with the tracer of the gateway: a span called name and child spans for the processing phases.
+func (p *processing) process(ctx context.Context, name, method string, params, result interface{}, callback domain.EventCallback) error {
+	tracer := domain.TracerOf(p.client)
+	ctx, span := tracer.StartSpan(ctx, name)
+	defer span.End()
+	trace := domain.TraceProcessing(ctx, tracer)
+
+	stream, err := domain.OpenStream(ctx, p.client, method, params)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := stream.Wait(ctx, result, trace.Events(domain.ProcessingEvents(callback))); err != nil {
+		trace.End(err)
+		span.RecordError(err)
+		stream.Close()
+		return err
 	}
+	trace.End(nil)
+	stream.Drain()
 
-	result := &domain.ResultOfProcessMessage{}
-	return result, domain.HandleEvents(responses, callback, result)
+	return nil
 }
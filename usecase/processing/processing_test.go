@@ -3,6 +3,7 @@ package processing
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -10,14 +11,23 @@ import (
 
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/gateway/clientmock"
+	"github.com/move-ton/ever-client-go/gateway/replay"
 	"github.com/move-ton/ever-client-go/usecase/abi"
 	"github.com/move-ton/ever-client-go/usecase/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestProcessing(t *testing.T) {
-	configConn := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
-	clientConn, err := client.NewClientGateway(configConn)
+	// The suite replays testdata/processing.json if it was recorded with replay.RecordEnv set, and runs on devnet otherwise.
+	// Calls are matched by method, as the params change from run to run.
+	configConn, configErr := domain.Networks.Config("devnet")
+	clientConn, err := replay.Open("testdata/processing.json", func() (domain.ClientGateway, error) {
+		if configErr != nil {
+			return nil, configErr
+		}
+		return client.NewClientGateway(configConn)
+	}, replay.WithMatcher(replay.MatchMethod))
 	assert.Equal(t, nil, err)
 
 	procUC := processing{
@@ -295,3 +305,50 @@ func printMessage(events chan *domain.ProcessingEvent) {
 		}
 	}
 }
+
+func TestProcessingFake(t *testing.T) {
+	fake := clientmock.NewFake()
+	defer fake.Destroy()
+
+	procUC := processing{
+		config: domain.ClientConfig{},
+		client: fake,
+	}
+
+	t.Run("TestProcessMessageWithEvents", func(t *testing.T) {
+		fake.On("processing.process_message").
+			StreamEvents(
+				&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventWillSend{ShardBlockID: "b1", MessageID: "m1", Message: "te6"}},
+				&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventDidSend{ShardBlockID: "b1", MessageID: "m1", Message: "te6"}},
+			).
+			Respond(domain.ResultOfProcessMessage{Transaction: json.RawMessage(`{"id":"t1"}`), OutMessages: []string{}})
+
+		var events []interface{}
+		result, err := procUC.ProcessMessage(&domain.ParamsOfProcessMessage{SendEvents: true}, func(event *domain.ProcessingEvent) {
+			events = append(events, event.ValueEnumType)
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `{"id":"t1"}`, string(result.Transaction))
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, domain.ProcessingEventWillSend{ShardBlockID: "b1", MessageID: "m1", Message: "te6"}, events[0])
+		assert.Equal(t, domain.ProcessingEventDidSend{ShardBlockID: "b1", MessageID: "m1", Message: "te6"}, events[1])
+	})
+
+	t.Run("TestWaitForTransactionExpiredAfterEvents", func(t *testing.T) {
+		fake.On("processing.wait_for_transaction").
+			StreamEvents(
+				&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventWillFetchNextBlock{ShardBlockID: "b1", MessageID: "m1", Message: "te6"}},
+				&domain.ProcessingEvent{ValueEnumType: domain.ProcessingEventWillFetchNextBlock{ShardBlockID: "b2", MessageID: "m1", Message: "te6"}},
+			).
+			Fail(domain.ClientError{Code: domain.ProcessingErrorMessageExpired, Message: "Message expired"})
+
+		var events []interface{}
+		_, err := procUC.WaitForTransaction(&domain.ParamsOfWaitForTransaction{Message: "te6", ShardBlockID: "b1", SendEvents: true}, func(event *domain.ProcessingEvent) {
+			events = append(events, event.ValueEnumType)
+		})
+		assert.True(t, errors.Is(err, domain.ErrMessageExpired))
+		// The events before the failure are delivered.
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, domain.ProcessingEventWillFetchNextBlock{ShardBlockID: "b2", MessageID: "m1", Message: "te6"}, events[1])
+	})
+}
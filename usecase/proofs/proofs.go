@@ -1,6 +1,7 @@
 package proofs
 
 import (
+	"context"
 	"github.com/move-ton/ever-client-go/domain"
 )
 
@@ -34,7 +35,12 @@ func NewProofs(
 // the persistent local storage (e.g. file system for native environments or browser's IndexedDB for the web); otherwise
 // all the data is cached only in memory in current client's context and will be lost after destruction of the client.
 func (pr *proofs) ProofBlockData(data *domain.ParamsOfProofBlockData) error {
-	_, err := pr.client.GetResponse("proofs.proof_block_data", data)
+	return pr.ProofBlockDataCtx(context.Background(), data)
+}
+
+// ProofBlockDataCtx - ProofBlockData bounded by ctx.
+func (pr *proofs) ProofBlockDataCtx(ctx context.Context, data *domain.ParamsOfProofBlockData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_block_data", data)
 	return err
 }
 
@@ -55,7 +61,12 @@ func (pr *proofs) ProofBlockData(data *domain.ParamsOfProofBlockData) error {
 // the persistent local storage (e.g. file system for native environments or browser's IndexedDB for the web); otherwise
 // all the data is cached only in memory in current client's context and will be lost after destruction of the client.
 func (pr *proofs) ProofTransactionData(data *domain.ParamsOfProofTransactionData) error {
-	_, err := pr.client.GetResponse("proofs.proof_transaction_data", data)
+	return pr.ProofTransactionDataCtx(context.Background(), data)
+}
+
+// ProofTransactionDataCtx - ProofTransactionData bounded by ctx.
+func (pr *proofs) ProofTransactionDataCtx(ctx context.Context, data *domain.ParamsOfProofTransactionData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_transaction_data", data)
 	return err
 }
 
@@ -74,6 +85,11 @@ func (pr *proofs) ProofTransactionData(data *domain.ParamsOfProofTransactionData
 //
 // For more information about proofs checking, see description of proof_block_data function.
 func (pr *proofs) ParamsMessageData(data *domain.ParamsOfProofMessageData) error {
-	_, err := pr.client.GetResponse("proofs.proof_message_data", data)
+	return pr.ParamsMessageDataCtx(context.Background(), data)
+}
+
+// ParamsMessageDataCtx - ParamsMessageData bounded by ctx.
+func (pr *proofs) ParamsMessageDataCtx(ctx context.Context, data *domain.ParamsOfProofMessageData) error {
+	_, err := pr.client.GetResponseContext(ctx, "proofs.proof_message_data", data)
 	return err
 }
@@ -1,15 +1,19 @@
 package proofs
 
 import (
+	"testing"
+
 	"github.com/move-ton/ever-client-go/domain"
 	"github.com/move-ton/ever-client-go/gateway/client"
+	"github.com/move-ton/ever-client-go/usecase/net"
+	"github.com/move-ton/ever-client-go/util"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 func TestProofs(t *testing.T) {
 
-	config := domain.NewDefaultConfig("", domain.GetDevNetBaseUrls(), "")
+	config, err := domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientConn, err := client.NewClientGateway(config)
 	assert.Equal(t, nil, err)
 	defer clientConn.Destroy()
@@ -19,4 +23,33 @@ func TestProofs(t *testing.T) {
 		client: clientConn,
 	}
 	defer proofsUC.client.Destroy()
+	netUC := net.NewNet(config, clientConn)
+
+	// The last entries of a collection, with the fields the proofs need.
+	last := func(collection string) *domain.ResultOfQueryCollection {
+		result, err := netUC.QueryCollection(&domain.ParamsOfQueryCollection{
+			Collection: collection,
+			Result:     "id boc",
+			Order:      []*domain.OrderBy{{Path: "id", Direction: domain.SortDirectionDESC}},
+			Limit:      util.IntToPointerInt(1),
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, len(result.Result))
+		return result
+	}
+
+	t.Run("TestProofBlockData", func(t *testing.T) {
+		err := proofsUC.ProofBlockData(&domain.ParamsOfProofBlockData{Block: last("blocks").Result[0]})
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("TestProofTransactionData", func(t *testing.T) {
+		err := proofsUC.ProofTransactionData(&domain.ParamsOfProofTransactionData{Transaction: last("transactions").Result[0]})
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("TestProofMessageData", func(t *testing.T) {
+		err := proofsUC.ParamsMessageData(&domain.ParamsOfProofMessageData{Message: last("messages").Result[0]})
+		assert.Equal(t, nil, err)
+	})
 }
@@ -1,6 +1,7 @@
 package tvm
 
 import (
+	"context"
 	"github.com/move-ton/ever-client-go/domain"
 )
 
@@ -24,21 +25,36 @@ func NewTvm(
 // Performs all the phases of contract execution on Transaction Executor
 // - the same component that is used on Validator Nodes.
 func (t *tvm) RunExecutor(pORE *domain.ParamsOfRunExecutor) (*domain.ResultOfRunExecuteMessage, error) {
+	return t.RunExecutorCtx(context.Background(), pORE)
+}
+
+// RunExecutorCtx - RunExecutor bounded by ctx.
+func (t *tvm) RunExecutorCtx(ctx context.Context, pORE *domain.ParamsOfRunExecutor) (*domain.ResultOfRunExecuteMessage, error) {
 	result := new(domain.ResultOfRunExecuteMessage)
-	err := t.client.GetResult("tvm.run_executor", pORE, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_executor", pORE, result)
 	return result, err
 }
 
 // RunTvm - Executes get-methods of ABI-compatible contracts.
 func (t *tvm) RunTvm(pORT *domain.ParamsOfRunTvm) (*domain.ResultOfRunTvm, error) {
+	return t.RunTvmCtx(context.Background(), pORT)
+}
+
+// RunTvmCtx - RunTvm bounded by ctx.
+func (t *tvm) RunTvmCtx(ctx context.Context, pORT *domain.ParamsOfRunTvm) (*domain.ResultOfRunTvm, error) {
 	result := new(domain.ResultOfRunTvm)
-	err := t.client.GetResult("tvm.run_tvm", pORT, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_tvm", pORT, result)
 	return result, err
 }
 
 // RunGet - Executes a get-method of FIFT contract.
 func (t *tvm) RunGet(pORG *domain.ParamsOfRunGet) (*domain.ResultOfRunGet, error) {
+	return t.RunGetCtx(context.Background(), pORG)
+}
+
+// RunGetCtx - RunGet bounded by ctx.
+func (t *tvm) RunGetCtx(ctx context.Context, pORG *domain.ParamsOfRunGet) (*domain.ResultOfRunGet, error) {
 	result := new(domain.ResultOfRunGet)
-	err := t.client.GetResult("tvm.run_get", pORG, result)
+	err := t.client.GetResultContext(ctx, "tvm.run_get", pORG, result)
 	return result, err
 }
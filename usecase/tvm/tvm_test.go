@@ -219,7 +219,8 @@ func TestTvm(t *testing.T) {
 
 	clientMain.Destroy()
 
-	configConn.Network.Endpoints = domain.GetDevNetBaseUrls()
+	configConn, err = domain.Networks.Config("devnet")
+	assert.Equal(t, nil, err)
 	clientMain, err = client.NewClientGateway(configConn)
 	assert.Equal(t, nil, err)
 	defer clientMain.Destroy()